@@ -0,0 +1,12 @@
+data "fastly_customer" "current" {
+  lifecycle {
+    postcondition {
+      condition     = self.force_2fa && self.has_billing_contact
+      error_message = "The Fastly account must require two-factor authentication and have a billing contact."
+    }
+  }
+}
+
+output "customer_id" {
+  value = data.fastly_customer.current.id
+}
@@ -0,0 +1,7 @@
+data "fastly_domain_search" "www" {
+  domain = "www.example.com"
+}
+
+output "www_example_com_claimed_by" {
+  value = data.fastly_domain_search.www.service_id
+}
@@ -0,0 +1,12 @@
+data "fastly_events" "activations" {
+  service_id    = fastly_service_vcl.production.id
+  event_type    = "version.activate"
+  created_after = "2022-01-01T00:00:00Z"
+}
+
+output "activations" {
+  value = [
+    for e in data.fastly_events.activations.events :
+    "${e.created_at}: ${e.user_id} activated version ${jsondecode(e.metadata).version}"
+  ]
+}
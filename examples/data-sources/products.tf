@@ -0,0 +1,32 @@
+data "fastly_products" "demo" {
+  service_id = fastly_service_vcl.demo.id
+
+  # Fail with a clear message instead of a failed apply when websockets isn't enabled.
+  required_products = ["websockets"]
+}
+
+data "fastly_products" "images" {
+  service_id = var.images_service_id
+}
+
+# Only send requests to the image optimizer when the product is enabled.
+resource "fastly_service_vcl" "images" {
+  name = "images"
+
+  domain {
+    name = "images.example.com"
+  }
+
+  dynamic "header" {
+    for_each = data.fastly_products.images.enabled["image_optimizer"] ? [1] : []
+    content {
+      name        = "Image Optimizer"
+      action      = "set"
+      type        = "request"
+      destination = "http.x-fastly-imageopto-api"
+      source      = "\"fastly\""
+    }
+  }
+
+  force_destroy = true
+}
@@ -0,0 +1,13 @@
+data "fastly_service_authorizations" "production_full" {
+  service_id  = fastly_service_vcl.production.id
+  permissions = ["full"]
+}
+
+data "fastly_users" "all" {}
+
+output "production_full_access" {
+  value = [
+    for u in data.fastly_users.all.users : u.login
+    if contains(data.fastly_service_authorizations.production_full.authorizations[*].user_id, u.id)
+  ]
+}
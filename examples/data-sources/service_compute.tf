@@ -0,0 +1,24 @@
+data "fastly_service_compute" "api" {
+  name = "api-production"
+}
+
+resource "fastly_service_vcl" "edge" {
+  name = "edge"
+
+  domain {
+    name = "www.notexample.com"
+  }
+
+  backend {
+    address = data.fastly_service_compute.api.domains[0].name
+    name    = "api"
+    port    = 443
+    use_ssl = true
+  }
+
+  force_destroy = true
+}
+
+output "api_package_hashsum" {
+  value = one(data.fastly_service_compute.api.package[*].hashsum)
+}
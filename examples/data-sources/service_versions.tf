@@ -0,0 +1,8 @@
+data "fastly_service_versions" "example" {
+  service_id = fastly_service_vcl.example.id
+}
+
+output "fastly_service_draft_versions" {
+  # get the numbers of any versions that have never been activated
+  value = [for version in data.fastly_service_versions.example.versions : version.number if !version.locked]
+}
@@ -0,0 +1,17 @@
+data "fastly_shields" "europe" {
+  group = "Europe"
+}
+
+resource "fastly_service_vcl" "demo" {
+  #...
+
+  backend {
+    address = "example.com"
+    name    = "example"
+    shield  = data.fastly_shields.europe.shields[0]
+  }
+}
+
+output "fastly_shields_europe" {
+  value = data.fastly_shields.europe.shields
+}
@@ -0,0 +1,15 @@
+data "fastly_tls_private_key_ids" "www" {
+  name = "www.example.com"
+}
+
+resource "fastly_tls_certificate" "www" {
+  certificate_body = file("www.example.com.crt")
+  name             = "www.example.com"
+
+  lifecycle {
+    precondition {
+      condition     = length(data.fastly_tls_private_key_ids.www.ids) == 1
+      error_message = "The private key for www.example.com must be uploaded first."
+    }
+  }
+}
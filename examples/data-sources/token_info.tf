@@ -0,0 +1,8 @@
+data "fastly_token_info" "current" {
+  required_scopes      = ["global"]
+  required_service_ids = [var.service_id]
+}
+
+output "token_expires_at" {
+  value = data.fastly_token_info.current.expires_at
+}
@@ -0,0 +1,9 @@
+data "fastly_users" "jane" {
+  login = "jane@example.com"
+}
+
+resource "fastly_service_authorization" "jane" {
+  service_id = fastly_service_vcl.demo.id
+  user_id    = data.fastly_users.jane.users[0].id
+  permission = "purge_all"
+}
@@ -0,0 +1,16 @@
+data "fastly_waf_firewalls" "all" {}
+
+# Firewalls whose configuration isn't active, for an audit.
+output "inactive_firewalls" {
+  value = [for firewall in data.fastly_waf_firewalls.all.firewalls : firewall.id if firewall.active_version == 0]
+}
+
+data "fastly_waf_firewalls" "demo" {
+  service_id = fastly_service_vcl.demo.id
+}
+
+# The ID to import the WAF configuration of the service with:
+# terraform import fastly_service_waf_configuration.waf <id>
+output "demo_firewall_id" {
+  value = data.fastly_waf_firewalls.demo.firewalls[0].id
+}
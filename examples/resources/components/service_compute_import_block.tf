@@ -0,0 +1,4 @@
+import {
+  to = fastly_service_compute.demo
+  id = "xxxxxxxxxxxxxxxxxxxx"
+}
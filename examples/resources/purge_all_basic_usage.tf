@@ -0,0 +1,25 @@
+resource "fastly_service_vcl" "demo" {
+  name = "demofastly"
+
+  domain {
+    name    = "demo.notexample.com"
+    comment = "demo"
+  }
+
+  backend {
+    address = "127.0.0.1"
+    name    = "localhost"
+    port    = 80
+  }
+
+  force_destroy = true
+}
+
+resource "fastly_purge_all" "release" {
+  service_id = fastly_service_vcl.demo.id
+  confirm    = true
+
+  triggers = {
+    release = var.release
+  }
+}
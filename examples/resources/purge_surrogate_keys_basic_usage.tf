@@ -0,0 +1,9 @@
+resource "fastly_purge_surrogate_keys" "articles" {
+  service_id = fastly_service_vcl.demo.id
+  keys       = var.changed_articles
+  soft       = true
+
+  triggers = {
+    release = var.release
+  }
+}
@@ -0,0 +1,8 @@
+resource "fastly_service_vcl" "demo" {
+  #...
+}
+
+resource "fastly_service_ddos_protection" "demo" {
+  service_id = fastly_service_vcl.demo.id
+  mode       = "block"
+}
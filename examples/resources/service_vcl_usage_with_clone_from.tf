@@ -0,0 +1,21 @@
+resource "fastly_service_vcl" "staging" {
+  name = "demofastly-staging"
+
+  clone_from {
+    service_id = fastly_service_vcl.demo.id
+    version    = fastly_service_vcl.demo.active_version
+  }
+
+  domain {
+    name    = "staging.notexample.com"
+    comment = "staging"
+  }
+
+  backend {
+    address = "staging-origin.notexample.com"
+    name    = "staging-origin"
+    port    = 443
+  }
+
+  force_destroy = true
+}
@@ -21,7 +21,7 @@ resource "fastly_service_vcl" "demo" {
   director {
     name = "mydirector"
     quorum = 0
-    type = 3
+    type = "hash"
     backends = [ "origin1", "origin2" ]
   }
 
@@ -0,0 +1,35 @@
+resource "fastly_service_vcl" "demo" {
+  name = "demofastly"
+
+  domain {
+    name    = "demo.notexample.com"
+    comment = "demo"
+  }
+
+  backend {
+    address = "127.0.0.1"
+    name    = "localhost"
+    port    = 80
+  }
+
+  logging_https {
+    name         = "honeycomb-eu"
+    url          = "https://api.eu1.honeycomb.io/1/batch/fastly"
+    content_type = "application/json"
+    json_format  = "1"
+    header_name  = "X-Honeycomb-Team"
+    header_value = var.honeycomb_api_key
+    format = jsonencode({
+      time = "%%{begin:%Y-%m-%dT%H:%M:%S}t"
+      data = {
+        host   = "%h"
+        method = "%m"
+        url    = "%%{json.escape(req.url)}V"
+        status = "%>s"
+      }
+    })
+    format_is_json = true
+  }
+
+  force_destroy = true
+}
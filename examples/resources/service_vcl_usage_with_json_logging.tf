@@ -0,0 +1,30 @@
+resource "fastly_service_vcl" "demo" {
+  name = "demofastly"
+
+  domain {
+    name    = "demo.notexample.com"
+    comment = "demo"
+  }
+
+  backend {
+    address = "127.0.0.1"
+    name    = "localhost"
+    port    = 80
+  }
+
+  logging_datadog {
+    name           = "datadog"
+    token          = var.datadog_token
+    format_version = 2
+    format_is_json = true
+    format = jsonencode({
+      host     = "%h"
+      method   = "%m"
+      url      = "%%{json.escape(req.url)}V"
+      status   = "%>s"
+      duration = "%%{time.elapsed.usec}V"
+    })
+  }
+
+  force_destroy = true
+}
@@ -0,0 +1,58 @@
+resource "fastly_service_vcl" "demo" {
+  name = "demofastly"
+
+  domain {
+    name    = "demo.notexample.com"
+    comment = "demo"
+  }
+
+  backend {
+    address = "127.0.0.1"
+    name    = "localhost"
+    port    = 80
+  }
+
+  condition {
+    name      = "maintenance"
+    statement = "req.url.path == \"/maintenance\""
+    type      = "REQUEST"
+  }
+
+  response_object {
+    name              = "maintenance"
+    status            = 503
+    response          = "Service Unavailable"
+    content_type      = "text/html"
+    source_file       = "${path.module}/maintenance.html"
+    source_sha256     = filesha256("${path.module}/maintenance.html")
+    request_condition = "maintenance"
+  }
+
+  snippet {
+    name     = "favicon"
+    type     = "recv"
+    priority = 100
+    content  = <<-EOT
+      if (req.url.path == "/favicon.ico") {
+        error 900 "favicon";
+      }
+    EOT
+  }
+
+  snippet {
+    name     = "favicon error"
+    type     = "error"
+    priority = 100
+    content  = <<-EOT
+      if (obj.status == 900) {
+        set obj.status = 200;
+        set obj.response = "OK";
+        set obj.http.Content-Type = "image/x-icon";
+        synthetic.base64 "${filebase64("${path.module}/favicon.ico")}";
+        return(deliver);
+      }
+    EOT
+  }
+
+  force_destroy = true
+}
@@ -0,0 +1,129 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// auditEntry is a line of the audit log, recording a mutating API request.
+type auditEntry struct {
+	Time         time.Time `json:"time"`
+	ResourceType string    `json:"resource_type,omitempty"`
+	ResourceID   string    `json:"resource_id,omitempty"`
+	Operation    string    `json:"operation,omitempty"`
+	Method       string    `json:"method"`
+	Endpoint     string    `json:"endpoint"`
+	Status       int       `json:"status,omitempty"`
+	Outcome      string    `json:"outcome"`
+	Error        string    `json:"error,omitempty"`
+	DurationMS   int64     `json:"duration_ms"`
+}
+
+// auditLog writes the mutating API requests the provider makes to a file, one
+// JSON object per line.
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openAuditLog opens the audit log at path, appending to it if it exists.
+func openAuditLog(path string) (*auditLog, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLog{file: f}, nil
+}
+
+func (l *auditLog) record(e auditEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.file.Write(append(b, '\n'))
+	return err
+}
+
+// auditTransport is a http.RoundTripper that records mutating requests in an
+// audit log, along with the resource operation that made them. Only the method
+// and path of requests are recorded, never their bodies, which may contain
+// secrets.
+type auditTransport struct {
+	base http.RoundTripper
+	log  *auditLog
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+
+	e := auditEntry{
+		Time:       start.UTC(),
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+		Outcome:    "success",
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if op, ok := req.Context().Value(auditOperationKey{}).(auditOperation); ok {
+		e.ResourceType, e.ResourceID, e.Operation = op.resourceType, op.resourceID, op.operation
+	}
+	switch {
+	case err != nil:
+		e.Outcome, e.Error = "error", err.Error()
+	case resp.StatusCode >= 400:
+		e.Status, e.Outcome = resp.StatusCode, "error"
+	default:
+		e.Status = resp.StatusCode
+	}
+
+	if logErr := t.log.record(e); logErr != nil {
+		// The request has already been made, so failing it would only lose
+		// track of the change in the state as well.
+		log.Printf("[ERROR] Error writing audit log for %s %s: %s", req.Method, req.URL.Path, logErr)
+	}
+	return resp, err
+}
+
+// auditOperationKey is the context key of the resource operation making API requests.
+type auditOperationKey struct{}
+
+// auditOperation is the resource operation making API requests. Terraform
+// doesn't tell providers the address of a resource, so it's identified by its
+// type and ID, which is empty until it has been created.
+type auditOperation struct {
+	resourceType, resourceID, operation string
+}
+
+// auditResource wraps the create, update and delete functions of a resource so
+// that the API requests they make are recorded in the audit log with the
+// resource and operation.
+func auditResource(resourceType string, r *schema.Resource) {
+	wrap := func(operation string, f schema.CreateContextFunc) schema.CreateContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			ctx = context.WithValue(ctx, auditOperationKey{}, auditOperation{resourceType: resourceType, resourceID: d.Id(), operation: operation})
+			return f(ctx, d, meta)
+		}
+	}
+	r.CreateContext = wrap("create", r.CreateContext)
+	r.UpdateContext = schema.UpdateContextFunc(wrap("update", schema.CreateContextFunc(r.UpdateContext)))
+	r.DeleteContext = schema.DeleteContextFunc(wrap("delete", schema.CreateContextFunc(r.DeleteContext)))
+}
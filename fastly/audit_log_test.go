@@ -0,0 +1,84 @@
+package fastly
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAuditLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/service/missing/purge_all" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"msg": "Record not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "ok", "id": "user-id"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+	c := Config{
+		APIKey:       "someapikey",
+		BaseURL:      server.URL,
+		AuditLogPath: path,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		CreateContext: func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			conn := meta.(*APIClient).connWithContext(ctx)
+			_, _ = conn.GetCurrentUser()
+			_, _ = conn.PurgeAll(&gofastly.PurgeAllInput{ServiceID: "service-id"})
+			_, _ = conn.PurgeAll(&gofastly.PurgeAllInput{ServiceID: "missing"})
+			return nil
+		},
+	}
+	auditResource("fastly_purge_all", r)
+	if diags := r.CreateContext(context.Background(), r.TestResourceData(), client); diags.HasError() {
+		t.Fatalf("unexpected error: %s", diagToErr(diags))
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %s", err)
+	}
+	defer f.Close()
+	var entries []auditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e auditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("expected each line to be a JSON object, got %q: %s", scanner.Text(), err)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected only the 2 mutating requests to be recorded, got %d", len(entries))
+	}
+	for i, expected := range []auditEntry{
+		{ResourceType: "fastly_purge_all", Operation: "create", Method: http.MethodPost, Endpoint: "/service/service-id/purge_all", Status: http.StatusOK, Outcome: "success"},
+		{ResourceType: "fastly_purge_all", Operation: "create", Method: http.MethodPost, Endpoint: "/service/missing/purge_all", Status: http.StatusNotFound, Outcome: "error"},
+	} {
+		got := entries[i]
+		got.Time, got.DurationMS = expected.Time, expected.DurationMS
+		if got != expected {
+			t.Errorf("expected entry %d to be %+v, got %+v", i, expected, got)
+		}
+	}
+}
@@ -2,9 +2,11 @@ package fastly
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,7 +14,9 @@ import (
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 var errFastlyNoServiceFound = errors.New("no matching Fastly service found")
@@ -24,6 +28,14 @@ const (
 	ServiceTypeCompute = "wasm"
 )
 
+const (
+	// DestroyBehaviorDelete deletes the service when the resource is destroyed.
+	DestroyBehaviorDelete = "delete"
+	// DestroyBehaviorDeactivate only deactivates the service when the resource
+	// is destroyed, preserving its versions and domains.
+	DestroyBehaviorDeactivate = "deactivate"
+)
+
 // ServiceDefinition defines the data model for service definitions
 // There are two types of service: VCL and Compute. This interface specifies the data object from which service resources
 // are constructed.
@@ -51,6 +63,25 @@ func (d *BaseServiceDefinition) GetAttributeHandler() []ServiceAttributeDefiniti
 	return d.Attributes
 }
 
+// defaultOperationTimeout is how long Terraform allows each operation on a resource to take, unless it is changed in
+// the resource's timeouts block.
+const defaultOperationTimeout = 20 * time.Minute
+
+// activationReadTimeout is how long to wait, after activating a version, for the API to report it as the active
+// version.
+const activationReadTimeout = 2 * time.Minute
+
+// operationTimeouts returns the timeouts of a resource whose create, read, update and delete operations can each be
+// given their own timeout in a timeouts block.
+func operationTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultOperationTimeout),
+		Read:   schema.DefaultTimeout(defaultOperationTimeout),
+		Update: schema.DefaultTimeout(defaultOperationTimeout),
+		Delete: schema.DefaultTimeout(defaultOperationTimeout),
+	}
+}
+
 // resourceService returns a Terraform resource schema for VCL or Compute.
 func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 	s := &schema.Resource{
@@ -59,23 +90,41 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 		UpdateContext: resourceUpdate(serviceDef),
 		DeleteContext: resourceDelete(serviceDef),
 		Importer:      resourceImport(),
+		Timeouts:      operationTimeouts(),
 		CustomizeDiff: customdiff.All(
-			customdiff.ComputedIf("cloned_version", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
-				// If anything other than name, comment and version_comment has changed, the current version will be
-				// cloned in resourceServiceUpdate so set it as recomputed. These three fields can be updated without
-				// creating a new version
-				for _, changedKey := range d.GetChangedKeysPrefix("") {
-					if changedKey == "name" || changedKey == "comment" || changedKey == "version_comment" {
-						continue
-					}
-					return true
+			customizeServiceAttributesDiff(serviceDef),
+			// Recording which attributes create the new version makes the plan show why a version will be cloned,
+			// rather than just that cloned_version is unknown. This must run before cloned_version and the other
+			// computed attributes are marked as changing. Every attribute of a new service is a change, so the list
+			// would tell a reviewer nothing when creating one.
+			func(_ context.Context, d *schema.ResourceDiff, _ any) error {
+				if d.Id() == "" {
+					return nil
+				}
+				if attributes := versionChangingAttributes(d); len(attributes) > 0 {
+					return d.SetNew("version_change_attributes", attributes)
 				}
-				return false
+				return nil
+			},
+			customdiff.ComputedIf("cloned_version", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
+				return serviceVersionWillChange(d)
 			}),
 			customdiff.ComputedIf("active_version", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
 				// If cloned_version is recomputed and we are automatically activating new versions (controlled with the
 				// activate flag) then the active_version will be recomputed too.
-				return d.HasChange("cloned_version") && d.Get("activate").(bool)
+				if !d.Get("activate").(bool) {
+					return false
+				}
+				return d.HasChange("cloned_version") || serviceActivationDrifted(d)
+			}),
+			customdiff.ComputedIf("latest_version", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
+				return d.HasChange("cloned_version")
+			}),
+			customdiff.ComputedIf("active_version_created_at", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
+				return d.HasChange("active_version")
+			}),
+			customdiff.ComputedIf("activated_by", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
+				return d.HasChange("active_version")
 			}),
 		),
 		Schema: map[string]*schema.Schema{
@@ -85,6 +134,18 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 				Default:     true,
 				Optional:    true,
 			},
+			"adopt_external_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a version activated outside of Terraform (e.g. an emergency change made in the UI) is adopted on refresh. When `true`, the newly active version becomes the version Terraform reads from and clones. When `false`, Terraform keeps tracking the version it last activated and the next apply will reactivate it. Default `true`",
+			},
+			"apply_lock": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to refuse to apply changes while another apply with `apply_lock` set is preparing or activating a new version of the service, so that concurrent applies can't clone and activate over each other's versions. The lock is a marker in the comment of the version being prepared, and expires after an hour if an apply is interrupted. Default `false`",
+			},
 			// Active Version represents the currently activated version in Fastly. In
 			// Terraform, we abstract this number away from the users and manage
 			// creating and activating. It's used internally, but also exported for
@@ -94,6 +155,49 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 				Computed:    true,
 				Description: "The currently active version of your Fastly Service",
 			},
+			"active_version_created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time (RFC 3339) the currently active version was created",
+			},
+			"activation_skipped": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the last apply left `cloned_version` as a draft because it couldn't be activated yet, e.g. because it is a version of a Compute@Edge service that has no package. The draft isn't reported as a deactivation, and is activated outside of Terraform",
+			},
+			"activated_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the user who activated the currently active version. This is looked up from the account's event log and will be empty if the API token cannot read events",
+			},
+			"clone_from": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Description: "A version of another service to copy blocks from when creating the service, e.g. to create a staging copy of a production service. The blocks of each type the configuration doesn't have are copied into the first version, and are then left as they are, like the blocks `managed_blocks` doesn't list, so that the services can diverge. The service settings, and the items of dictionaries and ACLs, aren't copied. Changing it replaces the service",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the service to copy blocks from",
+						},
+						"version": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							Description:      "The version of the service to copy blocks from",
+							ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+						},
+					},
+				},
+			},
+			"cloned_blocks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The types of the blocks copied from `clone_from` when the service was created. They are only managed if `managed_blocks` lists them",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 			// Cloned Version represents the latest cloned version by the provider. It
 			// gets set whenever Terraform detects changes and clones the currently
 			// activated version in order to modify it. Active Version and Cloned
@@ -102,7 +206,7 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 			"cloned_version": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "The latest cloned version by the provider",
+				Description: "The latest cloned version by the provider. When `activate` is false this is the draft version created by the last apply, which can then be reviewed and activated outside of Terraform",
 			},
 			"comment": {
 				Type:        schema.TypeString,
@@ -110,12 +214,31 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 				Default:     "Managed by Terraform",
 				Description: "Description field for the service. Default `Managed by Terraform`",
 			},
+			"detect_unmanaged_blocks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether each refresh looks for backends, domains and logging endpoints that the service has, but the configuration doesn't, e.g. because they were added in the UI. They are reported as warnings and in `unmanaged_blocks`. Elements of a block that is configured already show as removals in the plan, so this reads the blocks that aren't configured, which takes an API request for each of them. Default `false`",
+			},
+			"destroy_behavior": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          DestroyBehaviorDelete,
+				Description:      "What happens to the service when the resource is destroyed. `delete` permanently deletes the service. `deactivate` deactivates the active version but keeps the service, its version history and domains so that it can be reactivated or imported later. Default `delete`",
+				ValidateDiagFunc: validateServiceDestroyBehavior(),
+				ConflictsWith:    []string{"reuse"},
+			},
 			"force_destroy": {
 				Type:          schema.TypeBool,
 				Optional:      true,
 				Description:   "Services that are active cannot be destroyed. In order to destroy the Service, set `force_destroy` to `true`. Default `false`",
 				ConflictsWith: []string{"reuse"},
 			},
+			"latest_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The most recent version of the service, which may be a draft that has not been activated",
+			},
 			"imported": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -126,11 +249,41 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 				Required:    true,
 				Description: "The unique name for the Service to create",
 			},
+			"staged_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version currently staged for testing, or `0` if no version is staged",
+			},
 			"reuse": {
 				Type:          schema.TypeBool,
 				Optional:      true,
-				Description:   "Services that are active cannot be destroyed. If set to `true` a service Terraform intends to destroy will instead be deactivated (allowing it to be reused by importing it into another Terraform project). If `false`, attempting to destroy an active service will cause an error. Default `false`",
-				ConflictsWith: []string{"force_destroy"},
+				Description:   "Services that are active cannot be destroyed. If set to `true` a service Terraform intends to destroy will instead be deactivated (allowing it to be reused by importing it into another Terraform project). If `false`, attempting to destroy an active service will cause an error. This is equivalent to `destroy_behavior = \"deactivate\"`. Default `false`",
+				ConflictsWith: []string{"force_destroy", "destroy_behavior"},
+			},
+			"unmanaged_blocks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backends, domains and logging endpoints that the service has, but the configuration doesn't, found by the last refresh with `detect_unmanaged_blocks` set. Ordered by block and name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"block": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the block, e.g. `logging_s3`",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the block",
+						},
+					},
+				},
+			},
+			"version_change_attributes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The top-level attributes and blocks whose changes created the latest version of the service. In a plan, these are the changes that will clone and, if `activate` is true, activate a new version, so that a deployment of edge configuration can be told apart from a versionless change such as to `name` or `comment`",
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 			"version_comment": {
 				Type:        schema.TypeString,
@@ -146,10 +299,193 @@ func resourceService(serviceDef ServiceDefinition) *schema.Resource {
 	for _, a := range serviceDef.GetAttributeHandler() {
 		_ = a.Register(s)
 	}
+	deprecateLoggingEndpoints(s)
+	suppressJSONLoggingFormatDiffs(s)
+	manageBlocks(s, serviceDef)
+
+	// Conditions are referenced by name from many blocks, so the references can only be checked once every block
+	// has been registered.
+	if _, ok := s.Schema["condition"]; ok {
+		s.CustomizeDiff = customdiff.All(s.CustomizeDiff, validateConditionReferences(s.Schema))
+	}
+
+	// The upgrades work on the raw state, so the types of the earlier schemas are only needed to satisfy the SDK.
+	// Attributes that the current schema doesn't have, e.g. the removed director capacity, are dropped by the SDK
+	// after the upgrade.
+	s.SchemaVersion = 2
+	s.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    s.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeServiceStateV0,
+		},
+		{
+			Version: 1,
+			Type:    s.CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeServiceStateV1,
+		},
+	}
 
 	return s
 }
 
+// serviceBlockRenamesV0 maps the names of the logging blocks before version 1.0.0 of the provider to their current
+// names.
+var serviceBlockRenamesV0 = map[string]string{
+	"bigquerylogging":    "logging_bigquery",
+	"blobstoragelogging": "logging_blobstorage",
+	"gcslogging":         "logging_gcs",
+	"httpslogging":       "logging_https",
+	"logentries":         "logging_logentries",
+	"papertrail":         "logging_papertrail",
+	"s3logging":          "logging_s3",
+	"splunk":             "logging_splunk",
+	"sumologic":          "logging_sumologic",
+	"syslog":             "logging_syslog",
+}
+
+// upgradeServiceStateV0 migrates state written before version 1.0.0 of the provider, which renamed the logging
+// blocks and the GCS logging email attribute. State already using the current names is left as it is.
+func upgradeServiceStateV0(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for oldName, newName := range serviceBlockRenamesV0 {
+		blocks, ok := rawState[oldName]
+		if !ok {
+			continue
+		}
+		delete(rawState, oldName)
+		if existing, _ := rawState[newName].([]any); len(existing) > 0 {
+			continue
+		}
+		rawState[newName] = blocks
+	}
+
+	gcs, _ := rawState["logging_gcs"].([]any)
+	for _, g := range gcs {
+		endpoint, ok := g.(map[string]any)
+		if !ok {
+			continue
+		}
+		if email, ok := endpoint["email"]; ok {
+			if user, _ := endpoint["user"].(string); user == "" {
+				endpoint["user"] = email
+			}
+			delete(endpoint, "email")
+		}
+	}
+
+	return rawState, nil
+}
+
+// upgradeServiceStateV1 migrates state written before director types had names, when the type was a number. The
+// number is kept, as a string, so that the state matches configurations that still set it, e.g. `type = 3`; the
+// director schema treats a number and its name as the same type.
+func upgradeServiceStateV1(_ context.Context, rawState map[string]any, _ any) (map[string]any, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	directors, _ := rawState["director"].([]any)
+	for _, d := range directors {
+		director, ok := d.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch v := director["type"].(type) {
+		case float64:
+			director["type"] = strconv.Itoa(int(v))
+		case json.Number:
+			director["type"] = v.String()
+		}
+	}
+
+	return rawState, nil
+}
+
+// customizeServiceAttributesDiff returns a CustomizeDiffFunc that calls the CustomizeDiff of each attribute handler
+// implementing ServiceAttributeDiffCustomizer, so that errors from every handler are reported together.
+//
+// NOTE: Terraform doesn't call CustomizeDiff when the whole resource is being destroyed, so these checks only apply to
+// changes made to an existing service.
+func customizeServiceAttributesDiff(serviceDef ServiceDefinition) schema.CustomizeDiffFunc {
+	var funcs []schema.CustomizeDiffFunc
+	for _, a := range serviceDef.GetAttributeHandler() {
+		if c, ok := a.(ServiceAttributeDiffCustomizer); ok {
+			funcs = append(funcs, c.CustomizeDiff)
+		}
+	}
+	return customdiff.All(funcs...)
+}
+
+// versionlessServiceAttributes are the attributes that can be updated without creating a new version of the service.
+var versionlessServiceAttributes = map[string]bool{
+	"name":                          true,
+	"comment":                       true,
+	"activate":                      true,
+	"adopt_external_changes":        true,
+	"destroy_behavior":              true,
+	"force_destroy":                 true,
+	"reuse":                         true,
+	"apply_lock":                    true,
+	"managed_blocks":                true,
+	"activation_skipped":            true,
+	"clone_from":                    true,
+	"cloned_blocks":                 true,
+	"detect_unmanaged_blocks":       true,
+	"unmanaged_blocks":              true,
+	"version_comment":               true,
+	"include_generated_vcl":         true,
+	"generated_vcl":                 true,
+	"wait_for_package_propagation":  true,
+	"package_propagation_timeout":   true,
+	"package_propagation_check_url": true,
+	"verify":                        true,
+	"version_change_attributes":     true,
+}
+
+// serviceVersionWillChange returns whether the planned changes will create a new version of the service. If anything
+// other than the versionlessServiceAttributes has changed, the current version will be cloned in resourceServiceUpdate.
+func serviceVersionWillChange(d *schema.ResourceDiff) bool {
+	return len(versionChangingAttributes(d)) > 0
+}
+
+// versionChangingAttributes returns the sorted top-level attributes whose planned changes will create a new version
+// of the service.
+func versionChangingAttributes(d *schema.ResourceDiff) []string {
+	seen := map[string]bool{}
+	var attributes []string
+	for _, changedKey := range d.GetChangedKeysPrefix("") {
+		// Nested keys, e.g. verify.0.url, are versionless if their top-level attribute is.
+		attribute := strings.SplitN(changedKey, ".", 2)[0]
+		if versionlessServiceAttributes[attribute] || seen[attribute] {
+			continue
+		}
+		seen[attribute] = true
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// serviceActivationDrifted returns whether the active version of an existing
+// service has been changed outside of Terraform without being adopted, i.e. the
+// service was deactivated or adopt_external_changes is false. In that case the
+// refresh will have left cloned_version pointing at the version that Terraform
+// last activated, and it needs to be activated again.
+func serviceActivationDrifted(d *schema.ResourceDiff) bool {
+	return d.Id() != "" && d.Get("cloned_version").(int) != 0 && d.Get("active_version").(int) != d.Get("cloned_version").(int) && !d.Get("activation_skipped").(bool)
+}
+
+// serviceDeactivated returns whether a service with no active version that Terraform previously created or activated a
+// version for has been deactivated outside of Terraform. A service whose version Terraform didn't activate on purpose,
+// as recorded in activation_skipped, hasn't been.
+func serviceDeactivated(d *schema.ResourceData, s *gofastly.ServiceDetail) bool {
+	return s.ActiveVersion.Number == 0 && d.Get("cloned_version").(int) != 0 && !d.Get("activation_skipped").(bool)
+}
+
 // resourceCreate satisfies the Terraform resource schema Create "interface"
 // while injecting the ServiceDefinition into the true Create functionality.
 func resourceCreate(serviceDef ServiceDefinition) schema.CreateContextFunc {
@@ -221,7 +557,7 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, meta any
 		return diag.FromErr(err)
 	}
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 	service, err := conn.CreateService(&gofastly.CreateServiceInput{
 		Name:    d.Get("name").(string),
 		Comment: d.Get("comment").(string),
@@ -233,6 +569,10 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, meta any
 
 	d.SetId(service.ID)
 
+	if err := cloneServiceBlocks(ctx, d, conn, serviceDef.GetAttributeHandler()); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// If the service was just created, there is an empty Version 1 available
 	// that is unlocked and can be updated.
 	err = d.Set("cloned_version", 1)
@@ -249,20 +589,9 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 		return diag.FromErr(err)
 	}
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	shouldActivate := d.Get("activate").(bool)
-	// Update Name and/or Comment. No new version is required for this.
-	if d.HasChanges("name", "comment") && shouldActivate {
-		_, err := conn.UpdateService(&gofastly.UpdateServiceInput{
-			ServiceID: d.Id(),
-			Name:      gofastly.String(d.Get("name").(string)),
-			Comment:   gofastly.String(d.Get("comment").(string)),
-		})
-		if err != nil {
-			return diag.FromErr(err)
-		}
-	}
 
 	// Once activated, Versions are locked and become immutable.
 	// This loops over all AttributeHandlers calling HasChange. In this way each attribute handler can contribute
@@ -271,12 +600,35 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 	// version. We only need one change to trigger this, so a break is OK.
 	var needsChange bool
 	for _, a := range serviceDef.GetAttributeHandler() {
-		if a.HasChange(d) {
+		if (d.IsNewResource() || managesHandler(d, a)) && a.HasChange(d) {
 			needsChange = true
 			break
 		}
 	}
 
+	// When new versions are forbidden, fail before making any changes at all
+	// so that the service isn't left partially updated.
+	if meta.(*APIClient).forbidNewVersions && !d.IsNewResource() {
+		if needsChange {
+			return diag.Errorf("changes to %s require cloning version %d of Fastly Service (%s) and activating a new version, which is not allowed as 'forbid_new_versions' is set in the provider configuration", strings.Join(changedServiceAttributes(d, serviceDef), ", "), d.Get("cloned_version").(int), d.Id())
+		}
+		if shouldActivate && d.Get("cloned_version") != d.Get("active_version") {
+			return diag.Errorf("applying this change requires activating version %d of Fastly Service (%s), which is not allowed as 'forbid_new_versions' is set in the provider configuration", d.Get("cloned_version").(int), d.Id())
+		}
+	}
+
+	// Update Name and/or Comment. No new version is required for this.
+	if d.HasChanges("name", "comment") && shouldActivate {
+		_, err := conn.UpdateService(&gofastly.UpdateServiceInput{
+			ServiceID: d.Id(),
+			Name:      gofastly.String(d.Get("name").(string)),
+			Comment:   gofastly.String(d.Get("comment").(string)),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	// Update the cloned version's comment. No new version is required for this.
 	if d.HasChange("version_comment") && (!needsChange || d.IsNewResource()) {
 		opts := gofastly.UpdateVersionInput{
@@ -293,6 +645,10 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 	}
 
 	initialVersion := false
+	// lock is the apply lock held while the new version is prepared and activated, if apply_lock is set, and
+	// lockedVersion is the version whose comment holds it.
+	var lock *serviceLock
+	var lockedVersion int
 
 	if needsChange {
 		var latestVersion int
@@ -302,6 +658,16 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 			// that is unlocked and can be updated.
 			latestVersion = 1
 		} else {
+			if d.Get("apply_lock").(bool) {
+				var err error
+				if lock, err = newServiceLock(); err != nil {
+					return diag.FromErr(err)
+				}
+				if err := lock.check(conn, d.Id(), 0); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+
 			latestVersion = d.Get("cloned_version").(int)
 			// Clone the latest version, giving us an unlocked version we can modify.
 			log.Printf("[DEBUG] Creating clone of version (%d) for updates", latestVersion)
@@ -325,12 +691,16 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 			// https://github.com/bflad/tfproviderlint/tree/main/passes/R018
 			time.Sleep(7 * time.Second)
 
-			// Update the cloned version's comment.
-			if d.Get("version_comment").(string) != "" {
+			// Update the cloned version's comment, marking it with the lock if there is one.
+			comment := d.Get("version_comment").(string)
+			if lock != nil {
+				comment = lock.comment(comment)
+			}
+			if comment != "" {
 				opts := gofastly.UpdateVersionInput{
 					ServiceID:      d.Id(),
 					ServiceVersion: latestVersion,
-					Comment:        gofastly.String(d.Get("version_comment").(string)),
+					Comment:        gofastly.String(comment),
 				}
 
 				log.Printf("[DEBUG] Update Version opts: %#v", opts)
@@ -339,12 +709,31 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 					return diag.FromErr(err)
 				}
 			}
+
+			if lock != nil {
+				// Release the lock if the apply fails, so that the next apply isn't blocked until the lock expires.
+				lockedVersion = latestVersion
+				defer func() {
+					if lock != nil {
+						if err := lock.release(conn, d.Id(), lockedVersion, d.Get("version_comment").(string)); err != nil {
+							log.Printf("[WARN] %s", err)
+						}
+					}
+				}()
+				// Another apply may have checked for a lock at the same time as this one.
+				if err := lock.check(conn, d.Id(), latestVersion); err != nil {
+					return diag.FromErr(err)
+				}
+			}
 		}
 
 		// This delegates the bulk of processing to attribute handlers which manage state
-		// for their own attributes.
+		// for their own attributes. Handlers use the upload client for requests that
+		// upload packages or VCL.
+		ctx := withUploadConn(ctx, meta.(*APIClient).uploadConnWithContext(ctx))
+		ctx = withRegistryClient(ctx, meta.(*APIClient).registryClient)
 		for _, a := range serviceDef.GetAttributeHandler() {
-			if a.MustProcess(d, initialVersion) {
+			if (d.IsNewResource() || managesHandler(d, a)) && a.MustProcess(d, initialVersion) {
 				// Check if the Update has been cancelled and return early if so
 				if err := ctx.Err(); err != nil {
 					if errors.Is(err, context.Canceled) {
@@ -355,7 +744,7 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 				}
 
 				if err := a.Process(ctx, d, latestVersion, conn); err != nil {
-					return diag.FromErr(err)
+					return apiErrorDiagnostics(err, conn)
 				}
 			}
 		}
@@ -382,6 +771,24 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 
 	versionNotYetActivated := d.Get("cloned_version") != d.Get("active_version")
 	latestVersion := d.Get("cloned_version").(int)
+	if shouldActivate && versionNotYetActivated {
+		for _, a := range serviceDef.GetAttributeHandler() {
+			if c, ok := a.(ServiceAttributeActivationCheck); ok {
+				canActivate, reason, err := c.CanActivate(ctx, d, latestVersion, conn)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				if !canActivate {
+					log.Printf("[WARN] Not activating Fastly Service (%s), Version (%v): %s", d.Id(), latestVersion, reason)
+					shouldActivate = false
+					if err := d.Set("activation_skipped", true); err != nil {
+						return diag.FromErr(err)
+					}
+					break
+				}
+			}
+		}
+	}
 	if shouldActivate && versionNotYetActivated {
 		log.Printf("[DEBUG] Activating Fastly Service (%s), Version (%v)", d.Id(), latestVersion)
 		_, err := conn.ActivateVersion(&gofastly.ActivateVersionInput{
@@ -398,6 +805,22 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		if err := d.Set("activation_skipped", false); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// The API can briefly keep returning the previously active version, which the read below would record.
+		if err := waitForActiveVersion(ctx, conn, d.Id(), latestVersion, activationReadTimeout); err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, a := range serviceDef.GetAttributeHandler() {
+			if h, ok := a.(ServiceAttributeActivationHook); ok {
+				if err := h.AfterActivation(ctx, d, latestVersion, conn); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
 	} else {
 		log.Printf("[INFO] Skipping activation of Fastly Service (%s), Version (%v)", d.Id(), latestVersion)
 		log.Print("[INFO] The Terraform definition is explicitly specified to not activate the changes on Fastly")
@@ -405,14 +828,61 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, meta any
 		log.Printf("[INFO] Visit https://manage.fastly.com/configure/services/%s/versions/%v and activate it manually", d.Id(), latestVersion)
 	}
 
+	if lock != nil {
+		err := lock.release(conn, d.Id(), lockedVersion, d.Get("version_comment").(string))
+		lock = nil
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceServiceRead(ctx, d, meta, serviceDef)
 }
 
+// waitForActiveVersion waits until the API reports version as the active version of the service, so that reading the
+// service after activating a version doesn't see stale data.
+func waitForActiveVersion(ctx context.Context, conn *gofastly.Client, serviceID string, version int, timeout time.Duration) error {
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+			ID: serviceID,
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if s.ActiveVersion.Number != version {
+			log.Printf("[DEBUG] Fastly Service (%s) reports version (%d) as active, waiting for version (%d)", serviceID, s.ActiveVersion.Number, version)
+			return resource.RetryableError(fmt.Errorf("the active version is %d", s.ActiveVersion.Number))
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for version (%d) of Fastly Service (%s) to be reported as active: %s", version, serviceID, err)
+	}
+	return nil
+}
+
+// changedServiceAttributes returns the names of the attributes whose changes
+// require a new version of the service to be created.
+func changedServiceAttributes(d *schema.ResourceData, serviceDef ServiceDefinition) []string {
+	var keys []string
+	for _, a := range serviceDef.GetAttributeHandler() {
+		if !a.HasChange(d) {
+			continue
+		}
+		if k, ok := a.(interface{ GetKey() string }); ok {
+			keys = append(keys, k.GetKey())
+		} else {
+			keys = append(keys, "settings")
+		}
+	}
+	return keys
+}
+
 // resourceServiceRead provides service resource Read functionality.
 func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any, serviceDef ServiceDefinition) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing Service Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
@@ -448,11 +918,34 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("version_comment", s.ActiveVersion.Comment)
+
+	deactivated := serviceDeactivated(d, s)
+	// A version that Terraform didn't activate on purpose stays a draft until
+	// it is activated outside of Terraform.
+	activationSkipped := s.ActiveVersion.Number == 0 && d.Get("activation_skipped").(bool)
+	if !activationSkipped {
+		if err := d.Set("activation_skipped", false); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// When not adopting external changes, the version comment of a version
+	// activated outside of Terraform shouldn't be tracked either.
+	adoptExternal := adoptExternalChanges(d)
+	trackingActiveVersion := adoptExternal || !d.Get("activate").(bool) || d.Get("cloned_version").(int) == 0 || s.ActiveVersion.Number == d.Get("cloned_version").(int)
+
+	if !deactivated && !activationSkipped && trackingActiveVersion {
+		err = d.Set("version_comment", s.ActiveVersion.Comment)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	previousActiveVersion := d.Get("active_version").(int)
+	err = d.Set("active_version", s.ActiveVersion.Number)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("active_version", s.ActiveVersion.Number)
+	err = readServiceVersionMetadata(d, s, previousActiveVersion, conn)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -491,12 +984,48 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any,
 		}
 	}
 
-	// If activate is false, then read the state from cloned_version instead of
-	// the active version.
-	// Otherwise, cloned_version should track the active version
-	if !d.Get("activate").(bool) {
-		s.ActiveVersion.Number = d.Get("cloned_version").(int)
-	} else {
+	// If activate is false, or the service has been deactivated, then read the
+	// state from cloned_version instead of the active version.
+	// Otherwise, cloned_version should track the active version, unless
+	// adopt_external_changes is false and the active version was changed
+	// outside of Terraform, in which case we keep reading the version Terraform
+	// last activated so that the next apply reactivates it.
+	activate := d.Get("activate").(bool)
+	clonedVersion := d.Get("cloned_version").(int)
+	switch {
+	case activationSkipped:
+		s.ActiveVersion.Number = clonedVersion
+	case deactivated:
+		s.ActiveVersion.Number = clonedVersion
+
+		if activate {
+			log.Printf("[WARN] Service (%s) has no active version, it was deactivated outside of Terraform", d.Id())
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Service has been deactivated outside of Terraform",
+				Detail:   fmt.Sprintf("Service (%s) has no active version. The next apply will reactivate version %d.", d.Id(), clonedVersion),
+			})
+		}
+	case !activate:
+		// A newer version activated outside of Terraform supersedes the draft
+		// we were tracking, so it becomes the version to clone from.
+		if adoptExternal && !d.Get("imported").(bool) && s.ActiveVersion.Number > clonedVersion {
+			err := d.Set("cloned_version", s.ActiveVersion.Number)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		} else {
+			s.ActiveVersion.Number = clonedVersion
+		}
+	case !adoptExternal && s.ActiveVersion.Number != clonedVersion:
+		log.Printf("[WARN] Service (%s) version (%d) was activated outside of Terraform", d.Id(), s.ActiveVersion.Number)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Service version activated outside of Terraform",
+			Detail:   fmt.Sprintf("Service (%s) version %d was activated outside of Terraform and 'adopt_external_changes' is false. The next apply will reactivate version %d.", d.Id(), s.ActiveVersion.Number, clonedVersion),
+		})
+		s.ActiveVersion.Number = clonedVersion
+	default:
 		err := d.Set("cloned_version", s.ActiveVersion.Number)
 		if err != nil {
 			return diag.FromErr(err)
@@ -519,10 +1048,20 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any,
 				return diag.FromErr(err)
 			}
 
+			// The blocks that managed_blocks doesn't list aren't refreshed, and are removed from state so that they
+			// don't show as removals.
+			if !managesHandler(d, a) {
+				if err := d.Set(a.(*blockSetAttributeHandler).GetKey(), nil); err != nil {
+					return diag.FromErr(err)
+				}
+				continue
+			}
+
 			if err := a.Read(ctx, d, s, conn); err != nil {
 				return diag.FromErr(err)
 			}
 		}
+		diags = append(diags, readUnmanagedBlocks(ctx, d, s, conn, serviceDef.GetAttributeHandler())...)
 	} else {
 		log.Printf("[DEBUG] Active Version for Service (%s) is empty, no state to refresh", d.Id())
 	}
@@ -535,17 +1074,139 @@ func resourceServiceRead(ctx context.Context, d *schema.ResourceData, meta any,
 		return diag.FromErr(err)
 	}
 
-	return diags
+	return append(diags, loggingEndpointUpgradeDiagnostics(d)...)
+}
+
+// adoptExternalChanges returns the value of adopt_external_changes. State that
+// was written before the attribute existed, or that is being imported, won't
+// contain it, in which case the default of true applies.
+func adoptExternalChanges(d *schema.ResourceData) bool {
+	v, ok := d.GetOkExists("adopt_external_changes") //nolint:staticcheck // Needed to distinguish unset from false.
+	return !ok || v.(bool)
+}
+
+// readServiceVersionMetadata sets the computed attributes describing the
+// versions of the service: when the active version was created and who
+// activated it, along with the latest and staged version numbers.
+func readServiceVersionMetadata(d *schema.ResourceData, s *gofastly.ServiceDetail, previousActiveVersion int, conn *gofastly.Client) error {
+	var createdAt string
+	if s.ActiveVersion.Number != 0 {
+		createdAt = formatOptionalTime(s.ActiveVersion.CreatedAt)
+	}
+	if err := d.Set("active_version_created_at", createdAt); err != nil {
+		return err
+	}
+	if err := d.Set("latest_version", latestServiceVersion(s.Versions)); err != nil {
+		return err
+	}
+	if err := d.Set("staged_version", stagedServiceVersion(s.Versions)); err != nil {
+		return err
+	}
+
+	// Finding out who activated a version requires reading the service's
+	// activation events, so we only do it when the active version has changed
+	// rather than on every refresh. A lookup that found no one, e.g. because the
+	// API token can't read events, isn't retried until the next activation.
+	activatedBy := d.Get("activated_by").(string)
+	switch {
+	case s.ActiveVersion.Number == 0:
+		activatedBy = ""
+	case s.ActiveVersion.Number != previousActiveVersion:
+		activatedBy = lookupVersionActivator(conn, s.ID, s.ActiveVersion.Number)
+	}
+	return d.Set("activated_by", activatedBy)
+}
+
+// latestServiceVersion returns the highest version number in the list.
+func latestServiceVersion(versions []*gofastly.Version) int {
+	var latest int
+	for _, v := range versions {
+		if v.Number > latest {
+			latest = v.Number
+		}
+	}
+	return latest
+}
+
+// stagedServiceVersion returns the number of the version that is staged, or 0
+// if there isn't one.
+func stagedServiceVersion(versions []*gofastly.Version) int {
+	var staged int
+	for _, v := range versions {
+		if v.Staging && v.Number > staged {
+			staged = v.Number
+		}
+	}
+	return staged
+}
+
+// lookupVersionActivator returns the ID of the user who most recently
+// activated the given version of a service. Failures are logged rather than
+// returned, as not every API token is permitted to read the event log and this
+// information is not required to manage the service.
+func lookupVersionActivator(conn *gofastly.Client, serviceID string, version int) string {
+	log.Printf("[DEBUG] Looking up activation events for service (%s), version (%d)", serviceID, version)
+	resp, err := conn.GetAPIEvents(&gofastly.GetAPIEventsFilterInput{
+		ServiceID: serviceID,
+		EventType: "version.activate",
+	})
+	if err != nil {
+		log.Printf("[WARN] Unable to look up activation events for service (%s): %s", serviceID, err)
+		return ""
+	}
+	return findVersionActivator(resp.Events, version)
+}
+
+// findVersionActivator returns the user ID of the latest event whose metadata
+// references the given version.
+func findVersionActivator(events []*gofastly.Event, version int) string {
+	var (
+		userID string
+		latest time.Time
+	)
+	for _, e := range events {
+		if eventVersion(e) != version {
+			continue
+		}
+		if userID == "" || (e.CreatedAt != nil && e.CreatedAt.After(latest)) {
+			userID = e.UserID
+			if e.CreatedAt != nil {
+				latest = *e.CreatedAt
+			}
+		}
+	}
+	return userID
+}
+
+// eventVersion extracts the service version number from an event's metadata.
+// The JSON decoding of the metadata means the value may be either a number or
+// a string.
+func eventVersion(e *gofastly.Event) int {
+	switch v := e.Metadata["version"].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0
+		}
+		return n
+	}
+	return 0
 }
 
 // resourceServiceDelete provides service resource Delete functionality.
-func resourceServiceDelete(_ context.Context, d *schema.ResourceData, meta any, _ ServiceDefinition) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceServiceDelete(ctx context.Context, d *schema.ResourceData, meta any, _ ServiceDefinition) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	deactivateOnly := d.Get("reuse").(bool) || d.Get("destroy_behavior").(string) == DestroyBehaviorDeactivate
 
 	// Fastly will fail to delete any service with an Active Version.
 	// If `force_destroy` is given, we deactivate the active version and then send
 	// the DELETE call.
-	if d.Get("force_destroy").(bool) || d.Get("reuse").(bool) {
+	if d.Get("force_destroy").(bool) || deactivateOnly {
 		s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
 			ID: d.Id(),
 		})
@@ -564,7 +1225,7 @@ func resourceServiceDelete(_ context.Context, d *schema.ResourceData, meta any,
 		}
 	}
 
-	if !d.Get("reuse").(bool) {
+	if !deactivateOnly {
 		err := conn.DeleteService(&gofastly.DeleteServiceInput{
 			ID: d.Id(),
 		})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -112,15 +113,50 @@ func (h *ACLServiceAttributeHandler) Update(context.Context, *schema.ResourceDat
 	return nil
 }
 
+// CustomizeDiff reports, when planning, any ACLs that are going to be deleted while they still contain entries and
+// don't have force_destroy set, as the deletion would otherwise fail part way through the apply.
+func (h *ACLServiceAttributeHandler) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" || !d.HasChange(h.Key()) || isOffline(meta) {
+		return nil
+	}
+
+	removed, err := removedSetElements(d, h.Key())
+	if err != nil {
+		return err
+	}
+
+	conn := meta.(*APIClient).connWithContext(ctx)
+	var blocking []string
+	for _, resource := range removed {
+		aclID, _ := resource["acl_id"].(string)
+		if force, _ := resource["force_destroy"].(bool); force || aclID == "" {
+			continue
+		}
+
+		entries, err := countACLEntries(d.Id(), aclID, conn)
+		if err != nil {
+			return fmt.Errorf("error checking entries of ACL (%s): %w", aclID, err)
+		}
+		if entries > 0 {
+			blocking = append(blocking, fmt.Sprintf("%q (%d entries)", resource["name"], entries))
+		}
+	}
+
+	if len(blocking) > 0 {
+		return fmt.Errorf("cannot delete ACLs that are not empty: %s. Either delete the entries first, or set force_destroy to true and apply it before making this change", strings.Join(blocking, ", "))
+	}
+	return nil
+}
+
 // Delete deletes the resource.
 func (h *ACLServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, latestVersion int, conn *gofastly.Client) error {
 	if !resource["force_destroy"].(bool) {
-		mayDelete, err := isACLEmpty(d.Id(), resource["acl_id"].(string), conn)
+		entries, err := countACLEntries(d.Id(), resource["acl_id"].(string), conn)
 		if err != nil {
 			return err
 		}
 
-		if !mayDelete {
+		if entries > 0 {
 			return fmt.Errorf("cannot delete ACL (%s), list is not empty. Either delete the entries first, or set force_destroy to true and apply it before making this change", resource["acl_id"].(string))
 		}
 	}
@@ -167,14 +203,14 @@ func flattenACLs(aclList []*gofastly.ACL) []map[string]any {
 	return al
 }
 
-func isACLEmpty(serviceID, aclID string, conn *gofastly.Client) (bool, error) {
+func countACLEntries(serviceID, aclID string, conn *gofastly.Client) (int, error) {
 	entries, err := conn.ListACLEntries(&gofastly.ListACLEntriesInput{
 		ServiceID: serviceID,
 		ACLID:     aclID,
 	})
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
-	return len(entries) == 0, nil
+	return len(entries), nil
 }
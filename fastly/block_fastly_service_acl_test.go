@@ -54,7 +54,7 @@ func TestAccFastlyServiceVCL_acl(t *testing.T) {
 	// 1. Create service with 2 ACLs
 	// 2. Rename both the ACLs, should succeed because the ACLs are empty
 	// 3. Keep both ACLs the same and add an entry
-	// 4. Try to rename the ACLs, expect to fail with "list not empty error"
+	// 4. Try to rename the ACLs, expect planning to fail with "not empty" error
 	// 5. Without renaming the ACLs, set force_destroy=true to skip the deletion check
 	// 6. Try to rename the ACLs again, expect to succeed
 	resource.ParallelTest(t, resource.TestCase{
@@ -90,7 +90,8 @@ func TestAccFastlyServiceVCL_acl(t *testing.T) {
 			},
 			{
 				Config:      testAccServiceVCLConfigACL(name, aclName, domain),
-				ExpectError: regexp.MustCompile("cannot delete.*list is not empty.*"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot delete ACLs that are not empty.*entries"),
 			},
 			{
 				Config: testAccServiceVCLConfigACLForceDestroy(name, aclNameUpdated, domain),
@@ -9,6 +9,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// The longest backend timeouts, in milliseconds, that the Fastly platform allows.
+const (
+	backendMaxConnectTimeout      = 60000
+	backendMaxFirstByteTimeout    = 600000
+	backendMaxBetweenBytesTimeout = 600000
+)
+
 // BackendServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
 type BackendServiceAttributeHandler struct {
 	*DefaultServiceAttributeHandler
@@ -29,6 +36,11 @@ func (h *BackendServiceAttributeHandler) Key() string {
 	return h.key
 }
 
+// CustomizeDiff checks the shield of each backend if the provider's validate_shields is set.
+func (h *BackendServiceAttributeHandler) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	return validateBlockShields(ctx, d, h.GetKey(), meta)
+}
+
 // GetSchema returns the resource schema.
 func (h *BackendServiceAttributeHandler) GetSchema() *schema.Schema {
 	blockAttributes := map[string]*schema.Schema{
@@ -44,16 +56,24 @@ func (h *BackendServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "Denotes if this Backend should be included in the pool of backends that requests are load balanced against. Default `false`",
 		},
 		"between_bytes_timeout": {
-			Type:        schema.TypeInt,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          10000,
+			Description:      "How long to wait between bytes in milliseconds. At most `600000`. Default `10000`",
+			ValidateDiagFunc: validateBackendTimeout(backendMaxBetweenBytesTimeout),
+		},
+		"comment": {
+			Type:        schema.TypeString,
 			Optional:    true,
-			Default:     10000,
-			Description: "How long to wait between bytes in milliseconds. Default `10000`",
+			Default:     "",
+			Description: "An optional comment about the Backend",
 		},
 		"connect_timeout": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Default:     1000,
-			Description: "How long to wait for a timeout in milliseconds. Default `1000`",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          1000,
+			Description:      "How long to wait for a timeout in milliseconds. At most `60000`. Default `1000`",
+			ValidateDiagFunc: validateBackendTimeout(backendMaxConnectTimeout),
 		},
 		"error_threshold": {
 			Type:        schema.TypeInt,
@@ -62,10 +82,11 @@ func (h *BackendServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "Number of errors to allow before the Backend is marked as down. Default `0`",
 		},
 		"first_byte_timeout": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Default:     15000,
-			Description: "How long to wait for the first bytes in milliseconds. Default `15000`",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          15000,
+			Description:      "How long to wait for the first bytes in milliseconds. At most `600000`. Default `15000`",
+			ValidateDiagFunc: validateBackendTimeout(backendMaxFirstByteTimeout),
 		},
 		"healthcheck": {
 			Type:        schema.TypeString,
@@ -253,6 +274,12 @@ func (h *BackendServiceAttributeHandler) Update(_ context.Context, d *schema.Res
 func (h *BackendServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.createDeleteBackendInput(d.Id(), serviceVersion, resource)
 
+	if h.GetServiceMetadata().serviceType == ServiceTypeVCL {
+		if err := removeBackendFromDirectors(d, opts.Name, serviceVersion, conn); err != nil {
+			return err
+		}
+	}
+
 	log.Printf("[DEBUG] Fastly Backend removal opts: %#v", opts)
 	err := conn.DeleteBackend(&opts)
 	if errRes, ok := err.(*gofastly.HTTPError); ok {
@@ -280,6 +307,7 @@ func (h *BackendServiceAttributeHandler) buildCreateBackendInput(service string,
 		ServiceVersion:      latestVersion,
 		Name:                df["name"].(string),
 		Address:             df["address"].(string),
+		Comment:             df["comment"].(string),
 		OverrideHost:        df["override_host"].(string),
 		AutoLoadbalance:     gofastly.Compatibool(df["auto_loadbalance"].(bool)),
 		SSLCheckCert:        gofastly.Compatibool(df["ssl_check_cert"].(bool)),
@@ -412,6 +440,7 @@ func flattenBackend(backendList []*gofastly.Backend, sa ServiceMetadata) []map[s
 			"address":               b.Address,
 			"auto_loadbalance":      b.AutoLoadbalance,
 			"between_bytes_timeout": int(b.BetweenBytesTimeout),
+			"comment":               b.Comment,
 			"connect_timeout":       int(b.ConnectTimeout),
 			"error_threshold":       int(b.ErrorThreshold),
 			"first_byte_timeout":    int(b.FirstByteTimeout),
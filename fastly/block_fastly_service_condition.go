@@ -2,8 +2,10 @@ package fastly
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -215,3 +217,87 @@ func flattenConditions(conditionList []*gofastly.Condition) []map[string]any {
 
 	return cl
 }
+
+// conditionReferenceFields are the block attributes that refer to a condition by name, and the type of condition
+// each one accepts.
+var conditionReferenceFields = map[string]string{
+	"request_condition":  "REQUEST",
+	"cache_condition":    "CACHE",
+	"response_condition": "RESPONSE",
+}
+
+// validateConditionReferences returns a CustomizeDiffFunc that checks that every condition named by a block in
+// blockSchemas is declared by a condition block of the type the attribute naming it accepts, so that mistakes are
+// reported when planning rather than by the API part way through an apply.
+func validateConditionReferences(blockSchemas map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	// The blocks referring to conditions, and which of the reference fields each one has.
+	references := map[string][]string{}
+	for key, s := range blockSchemas {
+		elem, ok := s.Elem.(*schema.Resource)
+		if !ok || s.Type != schema.TypeSet {
+			continue
+		}
+		for field := range conditionReferenceFields {
+			if _, ok := elem.Schema[field]; ok {
+				references[key] = append(references[key], field)
+			}
+		}
+		sort.Strings(references[key])
+	}
+	keys := make([]string, 0, len(references))
+	for key := range references {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return func(_ context.Context, d *schema.ResourceDiff, _ any) error {
+		// Conditions whose names are only known after apply can't be checked.
+		if !d.NewValueKnown("condition") {
+			return nil
+		}
+		declared := map[string]string{}
+		for _, c := range d.Get("condition").(*schema.Set).List() {
+			condition := c.(map[string]any)
+			declared[condition["name"].(string)] = condition["type"].(string)
+		}
+
+		var undeclared, mistyped []string
+		for _, key := range keys {
+			resources, ok := d.Get(key).(*schema.Set)
+			if !ok {
+				continue
+			}
+			for _, r := range resources.List() {
+				resource := r.(map[string]any)
+				for _, field := range references[key] {
+					name, _ := resource[field].(string)
+					if name == "" {
+						continue
+					}
+					conditionType, ok := declared[name]
+					if !ok {
+						undeclared = append(undeclared, fmt.Sprintf("%s %q: %s %q", key, resource["name"], field, name))
+						continue
+					}
+					// PREFETCH conditions aren't tied to one of the reference fields, and a type that isn't known
+					// until apply is empty.
+					if conditionType != "" && conditionType != "PREFETCH" && conditionType != conditionReferenceFields[field] {
+						mistyped = append(mistyped, fmt.Sprintf("%s %q: %s %q is a %s condition, expected %s", key, resource["name"], field, name, conditionType, conditionReferenceFields[field]))
+					}
+				}
+			}
+		}
+
+		var errs []string
+		if len(undeclared) > 0 {
+			errs = append(errs, fmt.Sprintf("undeclared conditions referenced, add a condition block for each of: %s", strings.Join(undeclared, ", ")))
+		}
+		if len(mistyped) > 0 {
+			errs = append(errs, fmt.Sprintf("conditions of the wrong type referenced: %s", strings.Join(mistyped, ", ")))
+		}
+		if len(errs) > 0 {
+			return errors.New(strings.Join(errs, "; "))
+		}
+		return nil
+	}
+}
@@ -1,8 +1,10 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -44,6 +46,45 @@ func TestResourceFastlyFlattenConditions(t *testing.T) {
 	}
 }
 
+func TestConditionReferenceValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		header      map[string]any
+		expectError string
+	}{
+		"no condition":        {header: map[string]any{}},
+		"declared condition":  {header: map[string]any{"request_condition": "is_api"}},
+		"undeclared response": {header: map[string]any{"response_condition": "is_apj"}, expectError: `header "header": response_condition "is_apj"`},
+		"wrong type":          {header: map[string]any{"cache_condition": "is_api"}, expectError: `header "header": cache_condition "is_api" is a REQUEST condition, expected CACHE`},
+	} {
+		header := map[string]any{
+			"name":        "header",
+			"action":      "set",
+			"type":        "request",
+			"destination": "http.X-API",
+			"source":      `"true"`,
+		}
+		for k, v := range testcase.header {
+			header[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"condition": []any{
+				map[string]any{"name": "is_api", "type": "REQUEST", "statement": `req.url ~ "^/api/"`},
+			},
+			"header": []any{header},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_conditional_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -52,6 +53,12 @@ func (h *DictionaryServiceAttributeHandler) GetSchema() *schema.Schema {
 					Required:    true,
 					Description: "A unique name to identify this dictionary. It is important to note that changing this attribute will delete and recreate the dictionary, and discard the current items in the dictionary",
 				},
+				"prevent_destroy_when_populated": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Refuse to delete the dictionary while it contains items, even if `force_destroy` is `true`. Defaults to false.",
+				},
 				"write_only": {
 					Type:        schema.TypeBool,
 					Optional:    true,
@@ -97,13 +104,15 @@ func (h *DictionaryServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 
 		dictionaries := flattenDictionaries(dictList)
 
-		// Match up force_destroy on each ACL from schema.ResourceData to avoid d.Set overwriting it with null
+		// Match up force_destroy and prevent_destroy_when_populated on each dictionary from schema.ResourceData to avoid
+		// d.Set overwriting them with null
 		stateDicts := d.Get(h.GetKey()).(*schema.Set).List()
 		for _, dictionary := range dictionaries {
 			for _, sd := range stateDicts {
 				stateDict := sd.(map[string]any)
 				if dictionary["name"] == stateDict["name"] {
 					dictionary["force_destroy"] = stateDict["force_destroy"]
+					dictionary["prevent_destroy_when_populated"] = stateDict["prevent_destroy_when_populated"]
 					break
 				}
 			}
@@ -122,16 +131,51 @@ func (h *DictionaryServiceAttributeHandler) Update(_ context.Context, _ *schema.
 	return nil
 }
 
+// CustomizeDiff reports, when planning, any dictionaries that are going to be deleted while they still contain items
+// and don't allow it, as the deletion would otherwise fail part way through the apply.
+func (h *DictionaryServiceAttributeHandler) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" || !d.HasChange(h.GetKey()) || isOffline(meta) {
+		return nil
+	}
+
+	removed, err := removedSetElements(d, h.GetKey())
+	if err != nil {
+		return err
+	}
+
+	conn := meta.(*APIClient).connWithContext(ctx)
+	var blocking []string
+	for _, resource := range removed {
+		dictID, _ := resource["dictionary_id"].(string)
+		if !dictionaryDeletionNeedsCheck(resource) || dictID == "" {
+			continue
+		}
+
+		items, err := countDictionaryItems(d.Id(), dictID, conn)
+		if err != nil {
+			return fmt.Errorf("error checking items of dictionary (%s): %w", dictID, err)
+		}
+		if items > 0 {
+			blocking = append(blocking, fmt.Sprintf("%q (%d items)", resource["name"], items))
+		}
+	}
+
+	if len(blocking) > 0 {
+		return fmt.Errorf("cannot delete dictionaries that are not empty: %s. Either delete the items first, or set force_destroy to true (and prevent_destroy_when_populated to false) and apply it before making this change", strings.Join(blocking, ", "))
+	}
+	return nil
+}
+
 // Delete deletes the resource.
 func (h *DictionaryServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
-	if !resource["force_destroy"].(bool) {
-		mayDelete, err := isDictionaryEmpty(d.Id(), resource["dictionary_id"].(string), conn)
+	if dictionaryDeletionNeedsCheck(resource) {
+		items, err := countDictionaryItems(d.Id(), resource["dictionary_id"].(string), conn)
 		if err != nil {
 			return err
 		}
 
-		if !mayDelete {
-			return fmt.Errorf("cannot delete dictionary (%s), it is not empty. Either delete the items first, or set force_destroy to true and apply it before making this change", resource["dictionary_id"].(string))
+		if items > 0 {
+			return fmt.Errorf("cannot delete dictionary (%s), it is not empty. Either delete the items first, or set force_destroy to true (and prevent_destroy_when_populated to false) and apply it before making this change", resource["dictionary_id"].(string))
 		}
 	}
 
@@ -153,6 +197,14 @@ func (h *DictionaryServiceAttributeHandler) Delete(_ context.Context, d *schema.
 	return nil
 }
 
+// dictionaryDeletionNeedsCheck returns whether a dictionary must be empty before it is deleted. That is the case
+// unless force_destroy is set, and prevent_destroy_when_populated always requires it.
+func dictionaryDeletionNeedsCheck(resource map[string]any) bool {
+	force, _ := resource["force_destroy"].(bool)
+	prevent, _ := resource["prevent_destroy_when_populated"].(bool)
+	return !force || prevent
+}
+
 func flattenDictionaries(dictList []*gofastly.Dictionary) []map[string]any {
 	var dl []map[string]any
 	for _, currentDict := range dictList {
@@ -185,14 +237,14 @@ func buildDictionary(dictMap any) (*gofastly.CreateDictionaryInput, error) {
 	return &opts, nil
 }
 
-func isDictionaryEmpty(serviceID, dictID string, conn *gofastly.Client) (bool, error) {
+func countDictionaryItems(serviceID, dictID string, conn *gofastly.Client) (int, error) {
 	items, err := conn.ListDictionaryItems(&gofastly.ListDictionaryItemsInput{
 		ServiceID:    serviceID,
 		DictionaryID: dictID,
 	})
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
-	return len(items) == 0, nil
+	return len(items), nil
 }
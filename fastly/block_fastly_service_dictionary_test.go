@@ -1,9 +1,12 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -43,6 +46,54 @@ func TestResourceFastlyFlattenDictionary(t *testing.T) {
 	}
 }
 
+func TestDictionaryDeletePreventDestroyWhenPopulated(t *testing.T) {
+	deleted := false
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/service/service-id/dictionary/dict-id/items":
+			w.Write([]byte(`[{"item_key": "key", "item_value": "value"}]`))
+		case r.Method == http.MethodDelete:
+			deleted = true
+			w.Write([]byte(`{"status": "ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})).conn
+
+	d := resourceServiceVCL().TestResourceData()
+	d.SetId("service-id")
+	h := &DictionaryServiceAttributeHandler{&DefaultServiceAttributeHandler{key: "dictionary"}}
+
+	for name, testcase := range map[string]struct {
+		forceDestroy  bool
+		prevent       bool
+		expectDeleted bool
+	}{
+		"default":                {},
+		"force_destroy":          {forceDestroy: true, expectDeleted: true},
+		"prevent":                {prevent: true},
+		"force_destroy, prevent": {forceDestroy: true, prevent: true},
+	} {
+		deleted = false
+		err := h.Delete(context.Background(), d, map[string]any{
+			"dictionary_id":                  "dict-id",
+			"name":                           "dict",
+			"force_destroy":                  testcase.forceDestroy,
+			"prevent_destroy_when_populated": testcase.prevent,
+		}, 2, conn)
+		if testcase.expectDeleted && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if !testcase.expectDeleted && (err == nil || !strings.Contains(err.Error(), "not empty")) {
+			t.Errorf("%s: expected a not empty error, got %v", name, err)
+		}
+		if deleted != testcase.expectDeleted {
+			t.Errorf("%s: expected deleted to be %t", name, testcase.expectDeleted)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_dictionary(t *testing.T) {
 	var service gofastly.ServiceDetail
 	var dictionary gofastly.Dictionary
@@ -56,7 +107,7 @@ func TestAccFastlyServiceVCL_dictionary(t *testing.T) {
 	// 1. Create service with dictionary
 	// 2. Rename the dictionary, should succeed because it is empty
 	// 3. Keep dictionary the same and add an item to it
-	// 4. Try to rename it, expect to fail with "dictionary not empty error"
+	// 4. Try to rename it, expect planning to fail with "not empty" error
 	// 5. Without renaming, set force_destroy=true to skip the deletion check
 	// 6. Try to rename again, expect to succeed
 	resource.ParallelTest(t, resource.TestCase{
@@ -86,7 +137,8 @@ func TestAccFastlyServiceVCL_dictionary(t *testing.T) {
 			},
 			{
 				Config:      testAccServiceVCLConfigDictionary(name, dictName, backendName, domainName),
-				ExpectError: regexp.MustCompile("cannot delete.*not empty.*"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("cannot delete dictionaries that are not empty.*items"),
 			},
 			{
 				Config: testAccServiceVCLConfigDictionaryForceDestroy(name, updatedDictName, backendName, domainName),
@@ -4,12 +4,45 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// directorTypes maps the names of the director types that can be configured to their values in the API.
+var directorTypes = map[string]gofastly.DirectorType{
+	"random": gofastly.DirectorTypeRandom,
+	"hash":   gofastly.DirectorTypeHash,
+	"client": gofastly.DirectorTypeClient,
+}
+
+// directorType returns the API value of a director type, which is either a name from directorTypes or, as before
+// director types had names, the API value itself, e.g. "3".
+func directorType(t string) gofastly.DirectorType {
+	if v, ok := directorTypes[t]; ok {
+		return v
+	}
+	v, _ := strconv.Atoi(t)
+	return gofastly.DirectorType(v)
+}
+
+// directorTypeName returns the name of the director type with the API value t, or the value itself if the type has no
+// name, e.g. the round robin type, which can't be configured.
+func directorTypeName(t gofastly.DirectorType) string {
+	for name, v := range directorTypes {
+		if v == t {
+			return name
+		}
+	}
+	if t == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(t))
+}
+
 // DirectorServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
 type DirectorServiceAttributeHandler struct {
 	*DefaultServiceAttributeHandler
@@ -30,61 +63,175 @@ func (h *DirectorServiceAttributeHandler) Key() string {
 	return h.key
 }
 
+// CustomizeDiff checks that each director only refers to configured backends, and its shield if the provider's
+// validate_shields is set.
+func (h *DirectorServiceAttributeHandler) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if err := validateDirectorBackends(d, h.GetKey()); err != nil {
+		return err
+	}
+	return validateBlockShields(ctx, d, h.GetKey(), meta)
+}
+
+// validateDirectorBackends returns an error if a director refers to a backend that isn't configured, e.g. because the
+// backend was renamed without updating the director, which would otherwise only fail partway through the apply.
+//
+// Names that aren't known until apply are empty when planning, so the check is skipped if any backend name is empty.
+func validateDirectorBackends(d *schema.ResourceDiff, key string) error {
+	directors, ok := d.Get(key).(*schema.Set)
+	if !ok || directors.Len() == 0 {
+		return nil
+	}
+	backends, ok := d.Get("backend").(*schema.Set)
+	if !ok {
+		return nil
+	}
+	configured := map[string]bool{}
+	for _, b := range backends.List() {
+		name, _ := b.(map[string]any)["name"].(string)
+		if name == "" {
+			return nil
+		}
+		configured[name] = true
+	}
+
+	var invalid []string
+	for _, r := range directors.List() {
+		director := r.(map[string]any)
+		members, ok := director["backends"].(*schema.Set)
+		if !ok {
+			continue
+		}
+		var missing []string
+		for _, b := range members.List() {
+			if name := b.(string); name != "" && !configured[name] {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			invalid = append(invalid, fmt.Sprintf("%q refers to backends that aren't configured: %s", director["name"], strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s (if a backend was renamed, rename it in the director's backends too)", key, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// removeBackendFromDirectors removes backend from the directors it belonged to before this apply. Backends are
+// processed before directors, so this lets a backend that belongs to a director be deleted, e.g. because it was
+// renamed, before the director is updated to refer to its new name.
+func removeBackendFromDirectors(d *schema.ResourceData, backend string, serviceVersion int, conn *gofastly.Client) error {
+	od, _ := d.GetChange("director")
+	directors, ok := od.(*schema.Set)
+	if !ok {
+		return nil
+	}
+	for _, r := range directors.List() {
+		director := r.(map[string]any)
+		members, ok := director["backends"].(*schema.Set)
+		if !ok || !members.Contains(backend) {
+			continue
+		}
+		opts := gofastly.DeleteDirectorBackendInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: serviceVersion,
+			Director:       director["name"].(string),
+			Backend:        backend,
+		}
+		log.Printf("[DEBUG] Director Backend removal opts: %#v", opts)
+		err := conn.DeleteDirectorBackend(&opts)
+		if errRes, ok := err.(*gofastly.HTTPError); ok {
+			if errRes.StatusCode != 404 {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSchema returns the resource schema.
 func (h *DirectorServiceAttributeHandler) GetSchema() *schema.Schema {
+	elem := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"backends": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Names of defined backends to map the director to. Example: `[ \"origin1\", \"origin2\" ]`",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "An optional comment about the Director",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Unique name for this Director. It is important to note that changing this attribute will delete and recreate the resource",
+			},
+			"quorum": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          75,
+				Description:      "Percentage of capacity that needs to be up for the director itself to be considered up. Default `75`",
+				ValidateDiagFunc: validateDirectorQuorum(),
+			},
+			"retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "How many backends to search if it fails. Default `5`",
+			},
+			"shield": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "Selected POP to serve as a \"shield\" for backends. Valid values for `shield` are included in the [`GET /datacenters`](https://developer.fastly.com/reference/api/utils/datacenter/) API response",
+			},
+			"type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "random",
+				Description:      "Type of load balance group to use. Can be `random`, `hash` or `client`. The numbers the API uses for them, `1`, `3` and `4`, are accepted too. Default `random`",
+				ValidateDiagFunc: validateDirectorType(),
+				DiffSuppressFunc: suppressDirectorTypeDiff,
+			},
+		},
+	}
 	return &schema.Schema{
 		Type:     schema.TypeSet,
 		Optional: true,
-		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"backends": {
-					Type:        schema.TypeSet,
-					Required:    true,
-					Description: "Names of defined backends to map the director to. Example: `[ \"origin1\", \"origin2\" ]`",
-					Elem:        &schema.Schema{Type: schema.TypeString},
-				},
-				"comment": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Default:     "",
-					Description: "An optional comment about the Director",
-				},
-				"name": {
-					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Unique name for this Director. It is important to note that changing this attribute will delete and recreate the resource",
-				},
-				"quorum": {
-					Type:             schema.TypeInt,
-					Optional:         true,
-					Default:          75,
-					Description:      "Percentage of capacity that needs to be up for the director itself to be considered up. Default `75`",
-					ValidateDiagFunc: validateDirectorQuorum(),
-				},
-				"retries": {
-					Type:        schema.TypeInt,
-					Optional:    true,
-					Default:     5,
-					Description: "How many backends to search if it fails. Default `5`",
-				},
-				"shield": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Default:     "",
-					Description: "Selected POP to serve as a \"shield\" for backends. Valid values for `shield` are included in the [`GET /datacenters`](https://developer.fastly.com/reference/api/utils/datacenter/) API response",
-				},
-				"type": {
-					Type:             schema.TypeInt,
-					Optional:         true,
-					Default:          1,
-					Description:      "Type of load balance group to use. Integer, 1 to 4. Values: `1` (random), `3` (hash), `4` (client). Default `1`",
-					ValidateDiagFunc: validateDirectorType(),
-				},
-			},
-		},
+		Elem:     elem,
+		Set:      hashDirector(elem),
 	}
 }
 
+// hashDirector returns the set hash of a director, which hashes the type by its API value so that a type written as a
+// number, e.g. `3`, and its name, e.g. `hash`, are the same director.
+func hashDirector(elem *schema.Resource) schema.SchemaSetFunc {
+	hash := schema.HashResource(elem)
+	return func(v any) int {
+		director := map[string]any{}
+		for k, v := range v.(map[string]any) {
+			director[k] = v
+		}
+		if t, ok := director["type"].(string); ok {
+			director["type"] = directorTypeName(directorType(t))
+		}
+		return hash(director)
+	}
+}
+
+// suppressDirectorTypeDiff suppresses the diff between two spellings of the same director type, e.g. `3` and `hash`.
+func suppressDirectorTypeDiff(_, old, new string, _ *schema.ResourceData) bool {
+	return old != "" && new != "" && directorType(old) == directorType(new)
+}
+
 // Create creates a new resource instance.
 func (h *DirectorServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.CreateDirectorInput{
@@ -95,17 +242,7 @@ func (h *DirectorServiceAttributeHandler) Create(_ context.Context, d *schema.Re
 		Shield:         resource["shield"].(string),
 		Quorum:         gofastly.Uint(uint(resource["quorum"].(int))),
 		Retries:        gofastly.Uint(uint(resource["retries"].(int))),
-	}
-
-	switch resource["type"].(int) {
-	case 1:
-		opts.Type = gofastly.DirectorTypeRandom
-	case 2:
-		opts.Type = gofastly.DirectorTypeRoundRobin
-	case 3:
-		opts.Type = gofastly.DirectorTypeHash
-	case 4:
-		opts.Type = gofastly.DirectorTypeClient
+		Type:           directorType(resource["type"].(string)),
 	}
 
 	log.Printf("[DEBUG] Director Create opts: %#v", opts)
@@ -151,6 +288,7 @@ func (h *DirectorServiceAttributeHandler) Read(_ context.Context, d *schema.Reso
 		}
 
 		dirl := flattenDirectors(directorList)
+		preserveDirectorTypes(resources, dirl)
 
 		if err := d.Set(h.GetKey(), dirl); err != nil {
 			log.Printf("[WARN] Error setting Directors for (%s): %s", d.Id(), err)
@@ -183,16 +321,7 @@ func (h *DirectorServiceAttributeHandler) Update(_ context.Context, d *schema.Re
 		opts.Quorum = gofastly.Uint(uint(v.(int)))
 	}
 	if v, ok := modified["type"]; ok {
-		switch v.(int) {
-		case 1:
-			opts.Type = gofastly.DirectorTypeRandom
-		case 2:
-			opts.Type = gofastly.DirectorTypeRoundRobin
-		case 3:
-			opts.Type = gofastly.DirectorTypeHash
-		case 4:
-			opts.Type = gofastly.DirectorTypeClient
-		}
+		opts.Type = directorType(v.(string))
 	}
 	if v, ok := modified["retries"]; ok {
 		opts.Retries = gofastly.Uint(uint(v.(int)))
@@ -273,7 +402,7 @@ func flattenDirectors(directorList []*gofastly.Director) []map[string]any {
 			"name":    d.Name,
 			"comment": d.Comment,
 			"shield":  d.Shield,
-			"type":    d.Type,
+			"type":    directorTypeName(d.Type),
 			"quorum":  int(d.Quorum),
 			"retries": int(d.Retries),
 		}
@@ -300,6 +429,23 @@ func flattenDirectors(directorList []*gofastly.Director) []map[string]any {
 	return dl
 }
 
+// preserveDirectorTypes keeps the type of each director from state when it names the same type as the API value, so
+// that a type configured as a number, e.g. `3`, doesn't show a diff against its name.
+func preserveDirectorTypes(state []any, dl []map[string]any) {
+	types := map[string]string{}
+	for _, s := range state {
+		director := s.(map[string]any)
+		types[director["name"].(string)], _ = director["type"].(string)
+	}
+
+	for _, director := range dl {
+		t, ok := types[director["name"].(string)]
+		if ok && t != "" && director["type"] != nil && directorType(t) == directorType(director["type"].(string)) {
+			director["type"] = t
+		}
+	}
+}
+
 func getDirectorBackendChange(d *schema.ResourceData, resource map[string]any) (odb *schema.Set, ndb *schema.Set) {
 	od, nd := d.GetChange("director")
 
@@ -1,9 +1,12 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -33,7 +36,7 @@ func TestResourceFastlyFlattenDirectors(t *testing.T) {
 			local: []map[string]any{
 				{
 					"name":     "somedirector",
-					"type":     3,
+					"type":     "hash",
 					"quorum":   75,
 					"retries":  10,
 					"backends": schema.NewSet(schema.HashString, []any{"somebackend"}),
@@ -100,11 +103,102 @@ func TestResourceFastlyFlattenDirectors(t *testing.T) {
 	}
 }
 
+func TestPreserveDirectorTypes(t *testing.T) {
+	state := []any{
+		map[string]any{"name": "numbered", "type": "3"},
+		map[string]any{"name": "named", "type": "hash"},
+		map[string]any{"name": "changed", "type": "3"},
+	}
+	dl := flattenDirectors([]*gofastly.Director{
+		{Name: "numbered", Type: gofastly.DirectorTypeHash},
+		{Name: "named", Type: gofastly.DirectorTypeHash},
+		{Name: "changed", Type: gofastly.DirectorTypeClient},
+		{Name: "imported", Type: gofastly.DirectorTypeRandom},
+	})
+
+	preserveDirectorTypes(state, dl)
+
+	expected := map[string]string{"numbered": "3", "named": "hash", "changed": "client", "imported": "random"}
+	for _, director := range dl {
+		if name := director["name"].(string); director["type"] != expected[name] {
+			t.Errorf("expected director %q to have type %q, got %v", name, expected[name], director["type"])
+		}
+	}
+}
+
 // This test validates that two directors are created successfully,
 // and in the next Terraform run the first director is updated while
 // the second director is unchanged and a third director is added.
 // In the final test, the first director is removed while the second
 // director is unchanged and one backend for the third director is removed.
+func TestDirectorBackendsValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		backends    []any
+		expectError string
+	}{
+		"configured": {backends: []any{"origin1", "origin2"}},
+		"renamed":    {backends: []any{"origin1", "origin3"}, expectError: `invalid director: "director" refers to backends that aren't configured: origin3 (if a backend was renamed, rename it in the director's backends too)`},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"backend": []any{
+				map[string]any{"name": "origin1", "address": "origin1.example.com"},
+				map[string]any{"name": "origin2", "address": "origin2.example.com"},
+			},
+			"director": []any{map[string]any{"name": "director", "backends": testcase.backends}},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
+func TestRemoveBackendFromDirectors(t *testing.T) {
+	var deleted []string
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected %s request for %s", r.Method, r.URL.Path)
+		}
+		deleted = append(deleted, r.URL.Path)
+		if strings.Contains(r.URL.Path, "/director/gone/") {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"msg": "Record not found"}`)
+			return
+		}
+		fmt.Fprint(w, `{"status": "ok"}`)
+	})).conn
+
+	r := resourceServiceVCL()
+	configured := schema.TestResourceDataRaw(t, r.Schema, map[string]any{
+		"name": "service",
+		"director": []any{
+			map[string]any{"name": "primary", "backends": []any{"origin1", "origin2"}},
+			map[string]any{"name": "gone", "backends": []any{"origin1"}},
+			map[string]any{"name": "other", "backends": []any{"origin2"}},
+		},
+	})
+	configured.SetId("service-id")
+	d := r.Data(configured.State())
+
+	if err := removeBackendFromDirectors(d, "origin1", 2, conn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	sort.Strings(deleted)
+	expected := []string{
+		"/service/service-id/version/2/director/gone/backend/origin1",
+		"/service/service-id/version/2/director/primary/backend/origin1",
+	}
+	if !reflect.DeepEqual(deleted, expected) {
+		t.Errorf("expected requests %v, got %v", expected, deleted)
+	}
+}
+
 func TestAccFastlyServiceVCL_directors_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -0,0 +1,77 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GeneratedVCLServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
+type GeneratedVCLServiceAttributeHandler struct{}
+
+// NewServiceGeneratedVCL returns a new resource.
+func NewServiceGeneratedVCL() ServiceAttributeDefinition {
+	return &GeneratedVCLServiceAttributeHandler{}
+}
+
+// Process creates or updates the attribute against the Fastly API.
+//
+// The generated VCL is only ever read, so there is nothing to process.
+func (h *GeneratedVCLServiceAttributeHandler) Process(_ context.Context, _ *schema.ResourceData, _ int, _ *gofastly.Client) error {
+	return nil
+}
+
+// Read refreshes the attribute state against the Fastly API.
+func (h *GeneratedVCLServiceAttributeHandler) Read(_ context.Context, d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
+	if !d.Get("include_generated_vcl").(bool) || s.ActiveVersion.Number == 0 {
+		return d.Set("generated_vcl", "")
+	}
+
+	vcl, err := conn.GetGeneratedVCL(&gofastly.GetGeneratedVCLInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: s.ActiveVersion.Number,
+	})
+	if err != nil {
+		return fmt.Errorf("error looking up generated VCL for (%s), version (%v): %s", d.Id(), s.ActiveVersion.Number, err)
+	}
+
+	return d.Set("generated_vcl", vcl.Content)
+}
+
+// HasChange returns whether the state of the attribute has changed against Terraform stored state.
+//
+// Including the generated VCL doesn't change the service, so never requires a new version.
+func (h *GeneratedVCLServiceAttributeHandler) HasChange(_ *schema.ResourceData) bool {
+	return false
+}
+
+// MustProcess returns whether we must process the resource.
+func (h *GeneratedVCLServiceAttributeHandler) MustProcess(d *schema.ResourceData, _ bool) bool {
+	return h.HasChange(d)
+}
+
+// CustomizeDiff marks the generated VCL as recomputed when it is first included or a new version will be created.
+func (h *GeneratedVCLServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Get("include_generated_vcl").(bool) && (d.HasChange("include_generated_vcl") || serviceVersionWillChange(d)) {
+		return d.SetNewComputed("generated_vcl")
+	}
+	return nil
+}
+
+// Register add the attribute to the resource schema.
+func (h *GeneratedVCLServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema["include_generated_vcl"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to read the VCL that Fastly generates for the service into `generated_vcl`, so that changes to the edge logic can be reviewed. Default `false`",
+	}
+	s.Schema["generated_vcl"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The VCL generated by Fastly for the version of the service managed by Terraform. Only set when `include_generated_vcl` is `true`",
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -44,16 +45,24 @@ func (h *GzipServiceAttributeHandler) GetSchema() *schema.Schema {
 					Description: "Name of already defined `condition` controlling when this gzip configuration applies. This `condition` must be of type `CACHE`. For detailed information about Conditionals, see [Fastly's Documentation on Conditionals](https://docs.fastly.com/en/guides/using-conditions)",
 				},
 				"content_types": {
-					Type:        schema.TypeList,
+					Type:        schema.TypeSet,
 					Optional:    true,
-					Description: "The content-type for each type of content you wish to have dynamically gzip'ed. Example: `[\"text/html\", \"text/css\"]`",
-					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "The content-type for each type of content you wish to have dynamically gzip'ed, without parameters. Content types are compared case-insensitively. Example: `[\"text/html\", \"text/css\"]`",
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: validateGzipContentType(),
+					},
+					Set: hashGzipContentType,
 				},
 				"extensions": {
-					Type:        schema.TypeList,
+					Type:        schema.TypeSet,
 					Optional:    true,
-					Description: "File extensions for each file type to dynamically gzip. Example: `[\"css\", \"js\"]`",
-					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "File extensions for each file type to dynamically gzip. Extensions are compared case-insensitively and may have a leading dot. Example: `[\"css\", \"js\"]`",
+					Elem: &schema.Schema{
+						Type:             schema.TypeString,
+						ValidateDiagFunc: validateGzipExtension(),
+					},
+					Set: hashGzipExtension,
 				},
 				"name": {
 					Type:        schema.TypeString,
@@ -75,11 +84,11 @@ func (h *GzipServiceAttributeHandler) Create(_ context.Context, d *schema.Resour
 	}
 
 	if v, ok := resource["content_types"]; ok {
-		opts.ContentTypes = sliceToString(v.([]any))
+		opts.ContentTypes = gzipSetToString(v.(*schema.Set), normalizeGzipContentType)
 	}
 
 	if v, ok := resource["extensions"]; ok {
-		opts.Extensions = sliceToString(v.([]any))
+		opts.Extensions = gzipSetToString(v.(*schema.Set), normalizeGzipExtension)
 	}
 
 	log.Printf("[DEBUG] Fastly Gzip Addition opts: %#v", opts)
@@ -105,6 +114,7 @@ func (h *GzipServiceAttributeHandler) Read(_ context.Context, d *schema.Resource
 		}
 
 		gl := flattenGzips(gzipsList)
+		preserveGzipSpellings(resources, gl)
 
 		// NOTE: Although "content_types" and "extensions" fields are optional in spec,
 		// Fastly API will actually set the default value silently when these fields are not sent
@@ -119,10 +129,10 @@ func (h *GzipServiceAttributeHandler) Read(_ context.Context, d *schema.Resource
 			for _, elem := range d.Get("gzip").(*schema.Set).List() {
 				m := elem.(map[string]any)
 				name := m["name"].(string)
-				if len(m["content_types"].([]any)) == 0 {
+				if m["content_types"].(*schema.Set).Len() == 0 {
 					ignoreList[name] = append(ignoreList[name], IgnoreFields{Name: "content_types"})
 				}
-				if len(m["extensions"].([]any)) == 0 {
+				if m["extensions"].(*schema.Set).Len() == 0 {
 					ignoreList[name] = append(ignoreList[name], IgnoreFields{Name: "extensions"})
 				}
 			}
@@ -164,16 +174,14 @@ func (h *GzipServiceAttributeHandler) Update(_ context.Context, d *schema.Resour
 		// we always default to sending an empty string
 		opts.ContentTypes = gofastly.String("")
 
-		list := v.([]any)
-		if len(list) > 0 {
-			opts.ContentTypes = gofastly.String(sliceToString(list))
+		if set := v.(*schema.Set); set.Len() > 0 {
+			opts.ContentTypes = gofastly.String(gzipSetToString(set, normalizeGzipContentType))
 		}
 	}
 	if v, ok := modified["extensions"]; ok {
 		opts.Extensions = gofastly.String("")
-		list := v.([]any)
-		if len(list) > 0 {
-			opts.Extensions = gofastly.String(sliceToString(list))
+		if set := v.(*schema.Set); set.Len() > 0 {
+			opts.Extensions = gofastly.String(gzipSetToString(set, normalizeGzipExtension))
 		}
 	}
 	if v, ok := modified["cache_condition"]; ok {
@@ -218,19 +226,17 @@ func flattenGzips(gzipsList []*gofastly.Gzip) []map[string]any {
 		}
 
 		if g.Extensions != "" {
-			e := strings.Split(g.Extensions, " ")
 			var et []any
-			for _, ev := range e {
-				et = append(et, ev)
+			for _, ev := range strings.Fields(g.Extensions) {
+				et = append(et, normalizeGzipExtension(ev))
 			}
 			ng["extensions"] = et
 		}
 
 		if g.ContentTypes != "" {
-			c := strings.Split(g.ContentTypes, " ")
 			var ct []any
-			for _, cv := range c {
-				ct = append(ct, cv)
+			for _, cv := range strings.Fields(g.ContentTypes) {
+				ct = append(ct, normalizeGzipContentType(cv))
 			}
 			ng["content_types"] = ct
 		}
@@ -248,10 +254,70 @@ func flattenGzips(gzipsList []*gofastly.Gzip) []map[string]any {
 	return gl
 }
 
-func sliceToString(src []any) string {
+// preserveGzipSpellings keeps the content types and extensions of each gzip from state when they are spelled
+// differently from the normalized values the API returns, e.g. `Text/HTML` or `.css`, so that they don't show a diff.
+func preserveGzipSpellings(state []any, gl []map[string]any) {
+	spellings := map[string]map[string]string{}
+	for _, s := range state {
+		g := s.(map[string]any)
+		name := g["name"].(string)
+		spellings[name] = map[string]string{}
+		if set, ok := g["content_types"].(*schema.Set); ok {
+			for _, v := range set.List() {
+				spellings[name]["content_types:"+normalizeGzipContentType(v.(string))] = v.(string)
+			}
+		}
+		if set, ok := g["extensions"].(*schema.Set); ok {
+			for _, v := range set.List() {
+				spellings[name]["extensions:"+normalizeGzipExtension(v.(string))] = v.(string)
+			}
+		}
+	}
+
+	for _, g := range gl {
+		configured, ok := spellings[g["name"].(string)]
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"content_types", "extensions"} {
+			values, _ := g[key].([]any)
+			for i, v := range values {
+				if spelling, ok := configured[key+":"+v.(string)]; ok {
+					values[i] = spelling
+				}
+			}
+		}
+	}
+}
+
+// normalizeGzipContentType returns the form of a content type that is sent to the API.
+func normalizeGzipContentType(contentType string) string {
+	return strings.ToLower(contentType)
+}
+
+// normalizeGzipExtension returns the form of an extension that is sent to the API.
+func normalizeGzipExtension(extension string) string {
+	return strings.ToLower(strings.TrimPrefix(extension, "."))
+}
+
+// hashGzipContentType hashes the normalized content type, so that content types that only differ in case are the same
+// element of the set.
+func hashGzipContentType(v any) int {
+	return schema.HashString(normalizeGzipContentType(v.(string)))
+}
+
+// hashGzipExtension hashes the normalized extension, so that extensions that only differ in case or a leading dot are
+// the same element of the set.
+func hashGzipExtension(v any) int {
+	return schema.HashString(normalizeGzipExtension(v.(string)))
+}
+
+// gzipSetToString returns the normalized elements of set as the sorted, space-delimited list the API expects.
+func gzipSetToString(set *schema.Set, normalize func(string) string) string {
 	var result []string
-	for _, el := range src {
-		result = append(result, el.(string))
+	for _, el := range set.List() {
+		result = append(result, normalize(el.(string)))
 	}
+	sort.Strings(result)
 	return strings.Join(result, " ")
 }
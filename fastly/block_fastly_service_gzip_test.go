@@ -8,6 +8,7 @@ import (
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -66,6 +67,50 @@ func TestResourceFastlyFlattenGzips(t *testing.T) {
 	}
 }
 
+func TestPreserveGzipSpellings(t *testing.T) {
+	state := []any{
+		map[string]any{
+			"name":          "configured",
+			"content_types": schema.NewSet(hashGzipContentType, []any{"Text/HTML", "text/css"}),
+			"extensions":    schema.NewSet(hashGzipExtension, []any{".css", "JS"}),
+		},
+	}
+	gl := flattenGzips([]*gofastly.Gzip{
+		{Name: "configured", ContentTypes: "text/css text/html text/xml", Extensions: "css js"},
+		{Name: "imported", ContentTypes: "text/html", Extensions: "css"},
+	})
+
+	preserveGzipSpellings(state, gl)
+
+	expected := []map[string]any{
+		{
+			"name":          "configured",
+			"content_types": []any{"text/css", "Text/HTML", "text/xml"},
+			"extensions":    []any{".css", "JS"},
+		},
+		{
+			"name":          "imported",
+			"content_types": []any{"text/html"},
+			"extensions":    []any{"css"},
+		},
+	}
+	if !reflect.DeepEqual(gl, expected) {
+		t.Errorf("Error matching:\nexpected: %#v\ngot: %#v", expected, gl)
+	}
+}
+
+func TestGzipSetToString(t *testing.T) {
+	extensions := schema.NewSet(hashGzipExtension, []any{"js", ".CSS", "css", "html"})
+	if expected, actual := "css html js", gzipSetToString(extensions, normalizeGzipExtension); actual != expected {
+		t.Errorf("expected extensions %q, got %q", expected, actual)
+	}
+
+	contentTypes := schema.NewSet(hashGzipContentType, []any{"text/html", "Text/HTML", "application/javascript"})
+	if expected, actual := "application/javascript text/html", gzipSetToString(contentTypes, normalizeGzipContentType); actual != expected {
+		t.Errorf("expected content types %q, got %q", expected, actual)
+	}
+}
+
 func TestAccFastlyServiceVCL_gzips_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -87,8 +132,8 @@ func TestAccFastlyServiceVCL_gzips_basic(t *testing.T) {
 	log3 := gofastly.Gzip{
 		ServiceVersion: 1,
 		Name:           "all",
-		Extensions:     "css js html",
-		ContentTypes:   "text/javascript application/x-javascript application/javascript text/css text/html",
+		Extensions:     "css html js",
+		ContentTypes:   "application/javascript application/x-javascript text/css text/html text/javascript",
 	}
 
 	log4 := gofastly.Gzip{
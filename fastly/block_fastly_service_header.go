@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"regexp"
+	"regexp/syntax"
+	"strconv"
 	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -30,6 +33,74 @@ func (h *HeaderServiceAttributeHandler) Key() string {
 	return h.key
 }
 
+// CustomizeDiff checks the regular expression and substitution of each header with the regex or regex_repeat action.
+func (h *HeaderServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	resources, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		for _, problem := range validateHeaderRegex(resource) {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], problem))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// headerSubstitutionBackreference matches the references to capture groups in a substitution.
+var headerSubstitutionBackreference = regexp.MustCompile(`\\(\d)`)
+
+// validateHeaderRegex returns the problems with the regex and substitution of a header that would only be reported when
+// the VCL is compiled, or that would make the substitution never work as intended.
+//
+// Fastly uses PCRE regular expressions. Go's regular expressions are a subset of them, so an expression that Go can't
+// parse is only reported if PCRE can't parse it either, and the substitution is only checked against an expression
+// that Go can parse. Values that aren't known until apply are empty when planning, so they aren't checked.
+func validateHeaderRegex(resource map[string]any) []string {
+	action, _ := resource["action"].(string)
+	if action != string(gofastly.HeaderActionRegex) && action != string(gofastly.HeaderActionRegexRepeat) {
+		return nil
+	}
+	regex, _ := resource["regex"].(string)
+	if regex == "" {
+		return nil
+	}
+
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		if e, ok := err.(*syntax.Error); ok && pcreOnlySyntax(e.Code) {
+			return nil
+		}
+		return []string{fmt.Sprintf("regex %q is invalid: %s", regex, err)}
+	}
+
+	var problems []string
+	substitution, _ := resource["substitution"].(string)
+	for _, m := range headerSubstitutionBackreference.FindAllStringSubmatch(substitution, -1) {
+		if n, _ := strconv.Atoi(m[1]); n > re.MaxCap() {
+			problems = append(problems, fmt.Sprintf("substitution %q refers to capture group %d, but regex %q has %d", substitution, n, regex, re.MaxCap()))
+		}
+	}
+	return problems
+}
+
+// pcreOnlySyntax returns whether a Go regular expression parsing error may be caused by syntax that PCRE supports,
+// e.g. lookarounds, backreferences, possessive quantifiers and repetitions of more than 1000.
+func pcreOnlySyntax(code syntax.ErrorCode) bool {
+	switch code {
+	case syntax.ErrInvalidPerlOp, syntax.ErrInvalidEscape, syntax.ErrInvalidRepeatOp, syntax.ErrInvalidRepeatSize, syntax.ErrInvalidNamedCapture:
+		return true
+	}
+	return false
+}
+
 // GetSchema returns the resource schema.
 func (h *HeaderServiceAttributeHandler) GetSchema() *schema.Schema {
 	return &schema.Schema{
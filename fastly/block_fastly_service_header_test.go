@@ -1,8 +1,10 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -118,6 +120,47 @@ func TestFastlyServiceVCL_BuildHeaders(t *testing.T) {
 	}
 }
 
+func TestHeaderRegexValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		header      map[string]any
+		expectError string
+	}{
+		"set action":               {header: map[string]any{"action": "set", "regex": "(", "substitution": `\2`}},
+		"valid":                    {header: map[string]any{"regex": "^/foo/(.*)$", "substitution": `/bar/\1`}},
+		"unknown regex":            {header: map[string]any{"substitution": `\1`}},
+		"lookahead":                {header: map[string]any{"regex": "^/(?!admin)(.*)$", "substitution": `\5`}},
+		"backreference":            {header: map[string]any{"action": "regex_repeat", "regex": `(a)\1`}},
+		"unclosed group":           {header: map[string]any{"regex": "^/(foo"}, expectError: "regex \"^/(foo\" is invalid: error parsing regexp: missing closing ): `^/(foo`"},
+		"unclosed class":           {header: map[string]any{"action": "regex_repeat", "regex": "[a-"}, expectError: "regex \"[a-\" is invalid: error parsing regexp: missing closing ]: `[a-`"},
+		"missing capture group":    {header: map[string]any{"regex": "^/foo/(.*)$", "substitution": `/bar/\2`}, expectError: `substitution "/bar/\\2" refers to capture group 2, but regex "^/foo/(.*)$" has 1`},
+		"non-capturing group only": {header: map[string]any{"regex": "^/(?:foo)$", "substitution": `\1`}, expectError: "refers to capture group 1"},
+	} {
+		header := map[string]any{
+			"name":        "header",
+			"action":      "regex",
+			"type":        "request",
+			"destination": "url",
+			"source":      "req.url",
+		}
+		for k, v := range testcase.header {
+			header[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"header": []any{header},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), `invalid header: "header" `) || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_headers_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -42,11 +43,18 @@ func (h *HealthCheckServiceAttributeHandler) GetSchema() *schema.Schema {
 					Default:     5000,
 					Description: "How often to run the Healthcheck in milliseconds. Default `5000`",
 				},
-				"expected_response": {
-					Type:        schema.TypeInt,
+				"comment": {
+					Type:        schema.TypeString,
 					Optional:    true,
-					Default:     200,
-					Description: "The status code expected from the host. Default `200`",
+					Default:     "",
+					Description: "An optional comment about the Healthcheck",
+				},
+				"expected_response": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          200,
+					Description:      "The status code expected from the host. Only one status code can be expected, so an origin that answers health checks with e.g. `204` or `301` needs this set to that code, or `path` set to a path that returns it. Default `200`",
+					ValidateDiagFunc: validateHealthCheckExpectedResponse(),
 				},
 				// NOTE: We can't use TypeList as the Fastly API orders the headers.
 				//
@@ -103,13 +111,13 @@ func (h *HealthCheckServiceAttributeHandler) GetSchema() *schema.Schema {
 					Type:        schema.TypeInt,
 					Optional:    true,
 					Default:     3,
-					Description: "How many Healthchecks must succeed to be considered healthy. Default `3`",
+					Description: "How many Healthchecks must succeed to be considered healthy. Must not be greater than `window`. Default `3`",
 				},
 				"timeout": {
 					Type:        schema.TypeInt,
 					Optional:    true,
 					Default:     500,
-					Description: "Timeout in milliseconds. Default `500`",
+					Description: "Timeout in milliseconds. Must not be greater than `check_interval`. Default `500`",
 				},
 				"window": {
 					Type:        schema.TypeInt,
@@ -138,6 +146,7 @@ func (h *HealthCheckServiceAttributeHandler) Create(_ context.Context, d *schema
 		Host:             resource["host"].(string),
 		Path:             resource["path"].(string),
 		CheckInterval:    gofastly.Uint(uint(resource["check_interval"].(int))),
+		Comment:          resource["comment"].(string),
 		ExpectedResponse: gofastly.Uint(uint(resource["expected_response"].(int))),
 		HTTPVersion:      resource["http_version"].(string),
 		Initial:          gofastly.Uint(uint(resource["initial"].(int))),
@@ -245,6 +254,50 @@ func (h *HealthCheckServiceAttributeHandler) Update(_ context.Context, d *schema
 	return nil
 }
 
+// CustomizeDiff validates the relationships between the fields of each healthcheck.
+func (h *HealthCheckServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	resources, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		for _, problem := range validateHealthcheck(resource) {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], problem))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// validateHealthcheck returns the combinations of fields in a healthcheck that
+// the API accepts but that can never work as intended.
+//
+// Values that aren't known until apply are zero when planning, so a
+// comparison is skipped when either side is zero.
+func validateHealthcheck(resource map[string]any) []string {
+	var problems []string
+
+	threshold, _ := resource["threshold"].(int)
+	window, _ := resource["window"].(int)
+	if threshold != 0 && window != 0 && threshold > window {
+		problems = append(problems, fmt.Sprintf("threshold (%d) is greater than window (%d), so the backend can never be considered healthy", threshold, window))
+	}
+
+	timeout, _ := resource["timeout"].(int)
+	interval, _ := resource["check_interval"].(int)
+	if timeout != 0 && interval != 0 && timeout > interval {
+		problems = append(problems, fmt.Sprintf("timeout (%d) is greater than check_interval (%d), so checks would overlap", timeout, interval))
+	}
+
+	return problems
+}
+
 // Delete deletes the resource.
 func (h *HealthCheckServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteHealthCheckInput{
@@ -275,6 +328,7 @@ func flattenHealthchecks(healthcheckList []*gofastly.HealthCheck) []map[string]a
 			"host":              h.Host,
 			"path":              h.Path,
 			"check_interval":    h.CheckInterval,
+			"comment":           h.Comment,
 			"expected_response": h.ExpectedResponse,
 			"http_version":      h.HTTPVersion,
 			"initial":           h.Initial,
@@ -1,9 +1,11 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -26,6 +28,7 @@ func TestResourceFastlyFlattenHealthChecks(t *testing.T) {
 					Host:             "example1.com",
 					Path:             "/test1.txt",
 					CheckInterval:    4000,
+					Comment:          "origin health",
 					ExpectedResponse: 200,
 					HTTPVersion:      "1.1",
 					Initial:          2,
@@ -42,6 +45,7 @@ func TestResourceFastlyFlattenHealthChecks(t *testing.T) {
 					"host":              "example1.com",
 					"path":              "/test1.txt",
 					"check_interval":    uint(4000),
+					"comment":           "origin health",
 					"expected_response": uint(200),
 					"http_version":      "1.1",
 					"initial":           uint(2),
@@ -62,6 +66,40 @@ func TestResourceFastlyFlattenHealthChecks(t *testing.T) {
 	}
 }
 
+func TestHealthcheckValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		healthcheck map[string]any
+		expectError string
+	}{
+		"defaults":                 {healthcheck: map[string]any{}},
+		"threshold equals window":  {healthcheck: map[string]any{"threshold": 5, "window": 5}},
+		"threshold exceeds window": {healthcheck: map[string]any{"threshold": 6, "window": 5}, expectError: "threshold (6) is greater than window (5)"},
+		"timeout exceeds interval": {healthcheck: map[string]any{"timeout": 6000, "check_interval": 5000}, expectError: "timeout (6000) is greater than check_interval (5000)"},
+	} {
+		healthcheck := map[string]any{
+			"name": "healthcheck",
+			"host": "example.com",
+			"path": "/",
+		}
+		for k, v := range testcase.healthcheck {
+			healthcheck[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":        "service",
+			"domain":      []any{map[string]any{"name": "example.com"}},
+			"healthcheck": []any{healthcheck},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), `invalid healthcheck: "healthcheck" `+testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_healthcheck_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -79,7 +117,7 @@ func TestAccFastlyServiceVCL_healthcheck_basic(t *testing.T) {
 		Initial:          2,
 		Method:           "HEAD",
 		Threshold:        3,
-		Timeout:          5000,
+		Timeout:          3000,
 		Window:           5,
 	}
 
@@ -203,7 +241,7 @@ resource "fastly_service_vcl" "foo" {
 		name              = "example-healthcheck1"
 		path              = "/test1.txt"
 		threshold         = 3
-		timeout           = 5000
+		timeout           = 3000
 		window            = 5
   }
 
@@ -237,7 +275,7 @@ resource "fastly_service_vcl" "foo" {
 		name              = "example-healthcheck1"
 		path              = "/test1.txt"
 		threshold         = 3
-		timeout           = 5000
+		timeout           = 3000
 		window            = 5
   }
 
@@ -82,6 +82,18 @@ func (h *BigQueryLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The logging format desired.",
 			Default:     "%h %l %u %t \"%r\" %>s %b",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["response_condition"] = &schema.Schema{
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -154,6 +166,7 @@ func (h *BigQueryLoggingServiceAttributeHandler) Read(_ context.Context, d *sche
 		for _, element := range bql {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, bql)
 
 		if err := d.Set(h.GetKey(), bql); err != nil {
 			log.Printf("[WARN] Error setting BigQuery for (%s): %s", d.Id(), err)
@@ -165,6 +178,8 @@ func (h *BigQueryLoggingServiceAttributeHandler) Read(_ context.Context, d *sche
 
 // Update updates the resource.
 func (h *BigQueryLoggingServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateBigQueryInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -216,6 +231,11 @@ func (h *BigQueryLoggingServiceAttributeHandler) Update(_ context.Context, d *sc
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *BigQueryLoggingServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *BigQueryLoggingServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteBigQueryInput{
@@ -110,6 +110,18 @@ func (h *BlobStorageLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     "%h %l %u %t \"%r\" %>s %b",
 			Description: "Apache-style string or VCL variables to use for log formatting (default: `%h %l %u %t \"%r\" %>s %b`)",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -190,6 +202,7 @@ func (h *BlobStorageLoggingServiceAttributeHandler) Read(_ context.Context, d *s
 		for _, element := range bsl {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, bsl)
 
 		if err := d.Set(h.GetKey(), bsl); err != nil {
 			log.Printf("[WARN] Error setting Blob Storages for (%s): %s", d.Id(), err)
@@ -201,6 +214,8 @@ func (h *BlobStorageLoggingServiceAttributeHandler) Read(_ context.Context, d *s
 
 // Update updates the resource.
 func (h *BlobStorageLoggingServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateBlobStorageInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -266,6 +281,11 @@ func (h *BlobStorageLoggingServiceAttributeHandler) Update(_ context.Context, d
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *BlobStorageLoggingServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *BlobStorageLoggingServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteBlobStorageInput{
@@ -108,6 +108,18 @@ func (h *CloudfilesServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -166,6 +178,7 @@ func (h *CloudfilesServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting Cloud Files logging endpoints for (%s): %s", d.Id(), err)
@@ -177,6 +190,8 @@ func (h *CloudfilesServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 
 // Update updates the resource.
 func (h *CloudfilesServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateCloudfilesInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -209,6 +224,9 @@ func (h *CloudfilesServiceAttributeHandler) Update(_ context.Context, d *schema.
 	if v, ok := modified["period"]; ok {
 		opts.Period = gofastly.Uint(uint(v.(int)))
 	}
+	if v, ok := modified["compression_codec"]; ok {
+		opts.CompressionCodec = gofastly.String(v.(string))
+	}
 	if v, ok := modified["gzip_level"]; ok {
 		opts.GzipLevel = gofastly.Uint8(uint8(v.(int)))
 	}
@@ -239,6 +257,11 @@ func (h *CloudfilesServiceAttributeHandler) Update(_ context.Context, d *schema.
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *CloudfilesServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *CloudfilesServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -57,6 +57,18 @@ func (h *DatadogServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -114,6 +126,7 @@ func (h *DatadogServiceAttributeHandler) Read(_ context.Context, d *schema.Resou
 		for _, element := range dll {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, dll)
 
 		if err := d.Set(h.GetKey(), dll); err != nil {
 			log.Printf("[WARN] Error setting Datadog logging endpoints for (%s): %s", d.Id(), err)
@@ -125,6 +138,8 @@ func (h *DatadogServiceAttributeHandler) Read(_ context.Context, d *schema.Resou
 
 // Update updates the resource.
 func (h *DatadogServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateDatadogInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -163,6 +178,11 @@ func (h *DatadogServiceAttributeHandler) Update(_ context.Context, d *schema.Res
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *DatadogServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *DatadogServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -1,8 +1,10 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -128,6 +130,41 @@ var datadogDefaultFormat = `{
   "socket_ploss": %{client.socket.ploss}V
 }`
 
+func TestDatadogFormatValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		format            string
+		validateVariables bool
+		expectError       string
+	}{
+		"valid":               {format: `{"status": %>s, "url": "%{json.escape(req.url)}V"}`},
+		"invalid":             {format: `{"status": %>s,}`, expectError: `"datadog" format is not valid JSON`},
+		"unknown variable":    {format: `{"url": "%{req.ulr}V"}`, validateVariables: true, expectError: `"datadog" format has unknown variables req.ulr`},
+		"unchecked variables": {format: `{"url": "%{req.ulr}V"}`},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"logging_datadog": []any{
+				map[string]any{
+					"name":                      "datadog",
+					"token":                     "token",
+					"format":                    testcase.format,
+					"format_is_json":            true,
+					"validate_format_variables": testcase.validateVariables,
+				},
+			},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), "invalid logging_datadog: "+testcase.expectError)) {
+			t.Errorf("%s: expected an error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_logging_datadog_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -4,11 +4,18 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// digitalOceanSpacesDomainSuffix is the suffix of the domain of the endpoint of each DigitalOcean Spaces region.
+const digitalOceanSpacesDomainSuffix = ".digitaloceanspaces.com"
+
+// defaultDigitalOceanSpacesDomain is the domain of the endpoint used when neither domain nor region is set.
+const defaultDigitalOceanSpacesDomain = "nyc3" + digitalOceanSpacesDomainSuffix
+
 // DigitalOceanServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
 type DigitalOceanServiceAttributeHandler struct {
 	*DefaultServiceAttributeHandler
@@ -50,10 +57,11 @@ func (h *DigitalOceanServiceAttributeHandler) GetSchema() *schema.Schema {
 			ValidateDiagFunc: validateLoggingCompressionCodec(),
 		},
 		"domain": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Description: "The domain of the DigitalOcean Spaces endpoint (default `nyc3.digitaloceanspaces.com`)",
-			Default:     "nyc3.digitaloceanspaces.com",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The domain of the DigitalOcean Spaces endpoint, e.g. `sfo3.digitaloceanspaces.com` (default `nyc3.digitaloceanspaces.com`). Leave it unset when `region` is set",
+			Default:          defaultDigitalOceanSpacesDomain,
+			ValidateDiagFunc: validateDigitalOceanSpacesDomain(),
 		},
 		"gzip_level": {
 			Type:        schema.TypeInt,
@@ -88,6 +96,12 @@ func (h *DigitalOceanServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description:      "A PGP public key that Fastly will use to encrypt your log files before writing them to disk",
 			ValidateDiagFunc: validateStringTrimmed,
 		},
+		"region": {
+			Type:             schema.TypeString,
+			Optional:         true,
+			Description:      "The DigitalOcean Spaces region, e.g. `sfo3`. The endpoint's `domain` is derived from it",
+			ValidateDiagFunc: validateDigitalOceanSpacesRegion(),
+		},
 		"secret_key": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -107,6 +121,18 @@ func (h *DigitalOceanServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -164,6 +190,8 @@ func (h *DigitalOceanServiceAttributeHandler) Read(_ context.Context, d *schema.
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
+		preserveDigitalOceanSpacesRegions(d, h.GetKey(), ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting DigitalOcean Spaces logging endpoints for (%s): %s", d.Id(), err)
@@ -175,6 +203,8 @@ func (h *DigitalOceanServiceAttributeHandler) Read(_ context.Context, d *schema.
 
 // Update updates the resource.
 func (h *DigitalOceanServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateDigitalOceanInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -189,8 +219,10 @@ func (h *DigitalOceanServiceAttributeHandler) Update(_ context.Context, d *schem
 	if v, ok := modified["bucket_name"]; ok {
 		opts.BucketName = gofastly.String(v.(string))
 	}
-	if v, ok := modified["domain"]; ok {
-		opts.Domain = gofastly.String(v.(string))
+	_, domainModified := modified["domain"]
+	_, regionModified := modified["region"]
+	if domainModified || regionModified {
+		opts.Domain = gofastly.String(digitalOceanSpacesDomain(resource))
 	}
 	if v, ok := modified["access_key"]; ok {
 		opts.AccessKey = gofastly.String(v.(string))
@@ -237,6 +269,60 @@ func (h *DigitalOceanServiceAttributeHandler) Update(_ context.Context, d *schem
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint, including that region and domain don't disagree.
+func (h *DigitalOceanServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := h.validateLoggingEndpoints(d); err != nil {
+		return err
+	}
+
+	resources, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		region, _ := resource["region"].(string)
+		domain, _ := resource["domain"].(string)
+		if region != "" && domain != defaultDigitalOceanSpacesDomain && domain != digitalOceanSpacesDomain(resource) {
+			invalid = append(invalid, fmt.Sprintf("%q sets domain %s, which isn't in region %s. Leave domain unset when region is set", resource["name"], domain, region))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// digitalOceanSpacesDomain returns the domain of the endpoint that an endpoint's logs are sent to: the domain of its
+// region when region is set, otherwise its domain.
+func digitalOceanSpacesDomain(resource map[string]any) string {
+	if region, _ := resource["region"].(string); region != "" {
+		return region + digitalOceanSpacesDomainSuffix
+	}
+	domain, _ := resource["domain"].(string)
+	return domain
+}
+
+// preserveDigitalOceanSpacesRegions keeps the region in the state of each block with the same name, as the API only
+// has the domain derived from it. The domain is kept too, so that leaving it unset doesn't cause a diff.
+func preserveDigitalOceanSpacesRegions(d *schema.ResourceData, key string, elements []map[string]any) {
+	for _, sr := range d.Get(key).(*schema.Set).List() {
+		stateResource := sr.(map[string]any)
+		region, _ := stateResource["region"].(string)
+		if region == "" {
+			continue
+		}
+		for _, element := range elements {
+			if element["name"] != stateResource["name"] || element["domain"] != digitalOceanSpacesDomain(stateResource) {
+				continue
+			}
+			element["region"] = region
+			element["domain"] = stateResource["domain"]
+		}
+	}
+}
+
 // Delete deletes the resource.
 func (h *DigitalOceanServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -313,7 +399,7 @@ func (h *DigitalOceanServiceAttributeHandler) buildCreate(digitaloceanMap any, s
 		ServiceVersion:    serviceVersion,
 		Name:              df["name"].(string),
 		BucketName:        df["bucket_name"].(string),
-		Domain:            df["domain"].(string),
+		Domain:            digitalOceanSpacesDomain(df),
 		AccessKey:         df["access_key"].(string),
 		SecretKey:         df["secret_key"].(string),
 		PublicKey:         df["public_key"].(string),
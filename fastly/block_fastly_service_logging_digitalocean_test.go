@@ -70,6 +70,21 @@ func TestResourceFastlyFlattenDigitalOcean(t *testing.T) {
 	}
 }
 
+func TestDigitalOceanSpacesDomain(t *testing.T) {
+	for _, testcase := range []struct {
+		resource map[string]any
+		expected string
+	}{
+		{map[string]any{"domain": defaultDigitalOceanSpacesDomain, "region": ""}, "nyc3.digitaloceanspaces.com"},
+		{map[string]any{"domain": "ams3.digitaloceanspaces.com", "region": ""}, "ams3.digitaloceanspaces.com"},
+		{map[string]any{"domain": defaultDigitalOceanSpacesDomain, "region": "sfo3"}, "sfo3.digitaloceanspaces.com"},
+	} {
+		if actual := digitalOceanSpacesDomain(testcase.resource); actual != testcase.expected {
+			t.Errorf("expected %s for %v, got %s", testcase.expected, testcase.resource, actual)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_logging_digitalocean_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
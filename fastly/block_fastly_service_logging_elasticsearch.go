@@ -54,35 +54,37 @@ func (h *ElasticSearchServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The ID of the Elasticsearch ingest pipeline to apply pre-process transformations to before indexing",
 		},
 		"request_max_bytes": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Default:     0,
-			Description: "The maximum number of logs sent in one request. Defaults to `0` for unbounded",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          0,
+			Description:      RequestMaxBytesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
 		},
 		"request_max_entries": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Default:     0,
-			Description: "The maximum number of bytes sent in one request. Defaults to `0` for unbounded",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          0,
+			Description:      RequestMaxEntriesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
 		},
 		"tls_ca_cert": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "A secure certificate to authenticate the server with. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_cert": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "The client certificate used to make authenticated requests. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_key": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "The client private key used to make authenticated requests. Must be in PEM format",
 			Sensitive:        true,
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMPrivateKey()),
 		},
 		"tls_hostname": {
 			Type:        schema.TypeString,
@@ -108,6 +110,18 @@ func (h *ElasticSearchServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     "%h %l %u %t \"%r\" %>s %b",
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -141,7 +155,9 @@ func (h *ElasticSearchServiceAttributeHandler) GetSchema() *schema.Schema {
 func (h *ElasticSearchServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildCreate(resource, d.Id(), serviceVersion)
 
-	log.Printf("[DEBUG] Fastly Elasticsearch logging addition opts: %#v", opts)
+	logOpts := *opts
+	logOpts.TLSClientKey = redactSensitive(opts.TLSClientKey)
+	log.Printf("[DEBUG] Fastly Elasticsearch logging addition opts: %#v", logOpts)
 
 	return createElasticsearch(conn, opts)
 }
@@ -165,6 +181,7 @@ func (h *ElasticSearchServiceAttributeHandler) Read(_ context.Context, d *schema
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting Elasticsearch logging endpoints for (%s): %s", d.Id(), err)
@@ -176,6 +193,8 @@ func (h *ElasticSearchServiceAttributeHandler) Read(_ context.Context, d *schema
 
 // Update updates the resource.
 func (h *ElasticSearchServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateElasticsearchInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -241,6 +260,11 @@ func (h *ElasticSearchServiceAttributeHandler) Update(_ context.Context, d *sche
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *ElasticSearchServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *ElasticSearchServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -68,6 +68,44 @@ func TestResourceFastlyFlattenFTP(t *testing.T) {
 	}
 }
 
+func TestFTPBuildCreate(t *testing.T) {
+	h := &FTPServiceAttributeHandler{
+		&DefaultServiceAttributeHandler{
+			key:             "logging_ftp",
+			serviceMetadata: ServiceMetadata{ServiceTypeVCL},
+		},
+	}
+
+	opts := h.buildCreate(map[string]any{
+		"name":               "ftp-endpoint",
+		"address":            "ftp.example.com",
+		"user":               "user",
+		"password":           "p@ssw0rd",
+		"path":               "/path",
+		"port":               21,
+		"period":             3600,
+		"public_key":         pgpPublicKey(t),
+		"gzip_level":         0,
+		"timestamp_format":   "%Y-%m-%dT%H:%M:%S.000",
+		"message_type":       "blank",
+		"compression_codec":  "zstd",
+		"format":             "%h %l %u %t \"%r\" %>s %b",
+		"format_version":     2,
+		"placement":          "none",
+		"response_condition": "",
+	}, "service-id", 1)
+
+	if opts.PublicKey != pgpPublicKey(t) {
+		t.Errorf("expected public_key to be set, got %q", opts.PublicKey)
+	}
+	if opts.MessageType != "blank" {
+		t.Errorf("expected message_type %q, got %q", "blank", opts.MessageType)
+	}
+	if opts.CompressionCodec != "zstd" {
+		t.Errorf("expected compression_codec %q, got %q", "zstd", opts.CompressionCodec)
+	}
+}
+
 func TestAccFastlyServiceVCL_logging_ftp_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -106,7 +144,7 @@ func TestAccFastlyServiceVCL_logging_ftp_basic(t *testing.T) {
 		Format:          "%h %l %u %t \"%r\" %>s %b %T",
 		FormatVersion:   2,
 		Placement:       "waf_debug",
-		MessageType:     "classic",
+		MessageType:     "blank",
 	}
 
 	log2 := gofastly.FTP{
@@ -352,6 +390,7 @@ resource "fastly_service_vcl" "foo" {
     gzip_level = 4
     timestamp_format = "%%Y-%%m-%%dT%%H:%%M:%%S.000"
     placement = "waf_debug"
+    message_type = "blank"
   }
 
   logging_ftp {
@@ -100,6 +100,18 @@ func (h *GCSLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "Apache-style string or VCL variables to use for log formatting",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -178,6 +190,7 @@ func (h *GCSLoggingServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 		for _, element := range gcsl {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, gcsl)
 
 		if err := d.Set(h.GetKey(), gcsl); err != nil {
 			log.Printf("[WARN] Error setting gcs for (%s): %s", d.Id(), err)
@@ -189,6 +202,8 @@ func (h *GCSLoggingServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 
 // Update updates the resource.
 func (h *GCSLoggingServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateGCSInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -248,6 +263,11 @@ func (h *GCSLoggingServiceAttributeHandler) Update(_ context.Context, d *schema.
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *GCSLoggingServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *GCSLoggingServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteGCSInput{
@@ -2,8 +2,13 @@ package fastly
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +37,11 @@ func (h *GooglePubSubServiceAttributeHandler) Key() string {
 // GetSchema returns the resource schema.
 func (h *GooglePubSubServiceAttributeHandler) GetSchema() *schema.Schema {
 	blockAttributes := map[string]*schema.Schema{
+		"account_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The name of the Google Cloud Platform service account that Fastly impersonates to publish logs, instead of authenticating with `user` and `secret_key`. Fastly's service account must be granted the Service Account Token Creator role on it",
+		},
 		"name": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -44,8 +54,8 @@ func (h *GooglePubSubServiceAttributeHandler) GetSchema() *schema.Schema {
 		},
 		"secret_key": {
 			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Your Google Cloud Platform account secret key. The `private_key` field in your service account authentication JSON. You may optionally provide this secret via an environment variable, `FASTLY_GOOGLE_PUBSUB_SECRET_KEY`.",
+			Optional:    true,
+			Description: "Your Google Cloud Platform account secret key. The `private_key` field in your service account authentication JSON. You may optionally provide this secret via an environment variable, `FASTLY_GOOGLE_PUBSUB_SECRET_KEY`. Required unless `account_name` is set",
 			DefaultFunc: schema.EnvDefaultFunc("FASTLY_GOOGLE_PUBSUB_SECRET_KEY", ""),
 			Sensitive:   true,
 		},
@@ -56,8 +66,8 @@ func (h *GooglePubSubServiceAttributeHandler) GetSchema() *schema.Schema {
 		},
 		"user": {
 			Type:        schema.TypeString,
-			Required:    true,
-			Description: "Your Google Cloud Platform service account email address. The `client_email` field in your service account authentication JSON. You may optionally provide this via an environment variable, `FASTLY_GOOGLE_PUBSUB_EMAIL`.",
+			Optional:    true,
+			Description: "Your Google Cloud Platform service account email address. The `client_email` field in your service account authentication JSON. You may optionally provide this via an environment variable, `FASTLY_GOOGLE_PUBSUB_EMAIL`. Required unless `account_name` is set",
 			DefaultFunc: schema.EnvDefaultFunc("FASTLY_GOOGLE_PUBSUB_EMAIL", ""),
 		},
 	}
@@ -68,6 +78,18 @@ func (h *GooglePubSubServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -106,25 +128,37 @@ func (h *GooglePubSubServiceAttributeHandler) Create(_ context.Context, d *schem
 	return createGooglePubSub(conn, opts)
 }
 
+// createPubsubInput adds account_name, which go-fastly doesn't support, to the input for creating a Pub/Sub logging
+// endpoint.
+type createPubsubInput struct {
+	gofastly.CreatePubsubInput
+	AccountName string `url:"account_name,omitempty"`
+}
+
+// updatePubsubInput adds account_name, which go-fastly doesn't support, to the input for updating a Pub/Sub logging
+// endpoint.
+type updatePubsubInput struct {
+	gofastly.UpdatePubsubInput
+	AccountName *string `url:"account_name,omitempty"`
+}
+
 // Read refreshes the resource.
 func (h *GooglePubSubServiceAttributeHandler) Read(_ context.Context, d *schema.ResourceData, _ map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	resources := d.Get(h.GetKey()).(*schema.Set).List()
 
 	if len(resources) > 0 || d.Get("imported").(bool) {
 		log.Printf("[DEBUG] Refreshing Google Cloud Pub/Sub logging endpoints for (%s)", d.Id())
-		googlepubsubList, err := conn.ListPubsubs(&gofastly.ListPubsubsInput{
-			ServiceID:      d.Id(),
-			ServiceVersion: serviceVersion,
-		})
+		googlepubsubList, accountNames, err := listPubsubs(conn, d.Id(), serviceVersion)
 		if err != nil {
 			return fmt.Errorf("error looking up Google Cloud Pub/Sub logging endpoints for (%s), version (%v): %s", d.Id(), serviceVersion, err)
 		}
 
-		googlepubsubLogList := flattenGooglePubSub(googlepubsubList)
+		googlepubsubLogList := flattenGooglePubSub(googlepubsubList, accountNames)
 
 		for _, element := range googlepubsubLogList {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, googlepubsubLogList)
 
 		if err := d.Set(h.GetKey(), googlepubsubLogList); err != nil {
 			log.Printf("[WARN] Error setting Google Cloud Pub/Sublogging endpoints for (%s): %s", d.Id(), err)
@@ -136,10 +170,14 @@ func (h *GooglePubSubServiceAttributeHandler) Read(_ context.Context, d *schema.
 
 // Update updates the resource.
 func (h *GooglePubSubServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
-	opts := gofastly.UpdatePubsubInput{
-		ServiceID:      d.Id(),
-		ServiceVersion: serviceVersion,
-		Name:           resource["name"].(string),
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
+	opts := updatePubsubInput{
+		UpdatePubsubInput: gofastly.UpdatePubsubInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: serviceVersion,
+			Name:           resource["name"].(string),
+		},
 	}
 
 	// NOTE: where we transition between any we lose the ability to
@@ -147,6 +185,9 @@ func (h *GooglePubSubServiceAttributeHandler) Update(_ context.Context, d *schem
 	// materializes as a panic (yay) and so it's only at runtime we discover
 	// this and so we've updated the below code to convert the type asserted
 	// int into a uint before passing the value to gofastly.Uint().
+	if v, ok := modified["account_name"]; ok {
+		opts.AccountName = gofastly.String(v.(string))
+	}
 	if v, ok := modified["topic"]; ok {
 		opts.Topic = gofastly.String(v.(string))
 	}
@@ -173,10 +214,48 @@ func (h *GooglePubSubServiceAttributeHandler) Update(_ context.Context, d *schem
 	}
 
 	log.Printf("[DEBUG] Update Google Cloud Pub/Sub Opts: %#v", opts)
-	_, err := conn.UpdatePubsub(&opts)
+	path := fmt.Sprintf("/service/%s/version/%d/logging/pubsub/%s", opts.ServiceID, opts.ServiceVersion, url.PathEscape(opts.Name))
+	resp, err := conn.PutForm(path, &opts, nil)
 	if err != nil {
 		return err
 	}
+	return resp.Body.Close()
+}
+
+// CustomizeDiff validates the configuration of each endpoint, including that it either impersonates a service account
+// or has the credentials of one.
+func (h *GooglePubSubServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := h.validateLoggingEndpoints(d); err != nil {
+		return err
+	}
+
+	resources, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		if err := validatePubsubCredentials(resource); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], err))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// validatePubsubCredentials returns an error if an endpoint sets only one of user and secret_key without setting
+// account_name. Values that aren't known until apply are empty when planning, so an endpoint without any credentials
+// is left for the API to report.
+func validatePubsubCredentials(resource map[string]any) error {
+	accountName, _ := resource["account_name"].(string)
+	user, _ := resource["user"].(string)
+	secretKey, _ := resource["secret_key"].(string)
+	if accountName == "" && (user == "") != (secretKey == "") {
+		return fmt.Errorf("must set both user and secret_key, or set account_name to impersonate a service account")
+	}
 	return nil
 }
 
@@ -189,9 +268,61 @@ func (h *GooglePubSubServiceAttributeHandler) Delete(_ context.Context, d *schem
 	return deleteGooglePubSub(conn, opts)
 }
 
-func createGooglePubSub(conn *gofastly.Client, i *gofastly.CreatePubsubInput) error {
-	_, err := conn.CreatePubsub(i)
-	return err
+func createGooglePubSub(conn *gofastly.Client, i *createPubsubInput) error {
+	path := fmt.Sprintf("/service/%s/version/%d/logging/pubsub", i.ServiceID, i.ServiceVersion)
+	resp, err := conn.PostForm(path, i, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// listPubsubs returns the Pub/Sub logging endpoints of a service version, sorted by name, and the account_name of each
+// endpoint by name. go-fastly doesn't decode account_name, so the endpoints are decoded from the list response here
+// rather than listed a second time with ListPubsubs.
+func listPubsubs(conn *gofastly.Client, serviceID string, serviceVersion int) ([]*gofastly.Pubsub, map[string]string, error) {
+	resp, err := conn.Get(fmt.Sprintf("/service/%s/version/%d/logging/pubsub", serviceID, serviceVersion), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var endpoints []map[string]any
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&endpoints); err != nil {
+		return nil, nil, err
+	}
+
+	pubsubs := make([]*gofastly.Pubsub, 0, len(endpoints))
+	accountNames := make(map[string]string, len(endpoints))
+	for _, e := range endpoints {
+		field := func(key string) string {
+			v, _ := e[key].(string)
+			return v
+		}
+		// The API returns format_version as a number or as a string, depending on the endpoint.
+		formatVersion, _ := strconv.ParseUint(fmt.Sprint(e["format_version"]), 10, 0)
+		p := &gofastly.Pubsub{
+			ServiceID:         serviceID,
+			ServiceVersion:    serviceVersion,
+			Name:              field("name"),
+			Topic:             field("topic"),
+			User:              field("user"),
+			SecretKey:         field("secret_key"),
+			ProjectID:         field("project_id"),
+			Format:            field("format"),
+			FormatVersion:     uint(formatVersion),
+			ResponseCondition: field("response_condition"),
+			Placement:         field("placement"),
+		}
+		pubsubs = append(pubsubs, p)
+		accountNames[p.Name] = field("account_name")
+	}
+	sort.SliceStable(pubsubs, func(i, j int) bool {
+		return pubsubs[i].Name < pubsubs[j].Name
+	})
+	return pubsubs, accountNames, nil
 }
 
 func deleteGooglePubSub(conn *gofastly.Client, i *gofastly.DeletePubsubInput) error {
@@ -211,12 +342,13 @@ func deleteGooglePubSub(conn *gofastly.Client, i *gofastly.DeletePubsubInput) er
 	return nil
 }
 
-func flattenGooglePubSub(googlepubsubList []*gofastly.Pubsub) []map[string]any {
+func flattenGooglePubSub(googlepubsubList []*gofastly.Pubsub, accountNames map[string]string) []map[string]any {
 	var flattened []map[string]any
 	for _, s := range googlepubsubList {
 		// Convert logging to a map for saving to state.
 		flatGooglePubSub := map[string]any{
 			"name":               s.Name,
+			"account_name":       accountNames[s.Name],
 			"user":               s.User,
 			"secret_key":         s.SecretKey,
 			"project_id":         s.ProjectID,
@@ -240,22 +372,25 @@ func flattenGooglePubSub(googlepubsubList []*gofastly.Pubsub) []map[string]any {
 	return flattened
 }
 
-func (h *GooglePubSubServiceAttributeHandler) buildCreate(googlepubsubMap any, serviceID string, serviceVersion int) *gofastly.CreatePubsubInput {
+func (h *GooglePubSubServiceAttributeHandler) buildCreate(googlepubsubMap any, serviceID string, serviceVersion int) *createPubsubInput {
 	df := googlepubsubMap.(map[string]any)
 
 	vla := h.getVCLLoggingAttributes(df)
-	return &gofastly.CreatePubsubInput{
-		ServiceID:         serviceID,
-		ServiceVersion:    serviceVersion,
-		Name:              df["name"].(string),
-		User:              df["user"].(string),
-		SecretKey:         df["secret_key"].(string),
-		ProjectID:         df["project_id"].(string),
-		Topic:             df["topic"].(string),
-		Format:            vla.format,
-		FormatVersion:     uintOrDefault(vla.formatVersion),
-		Placement:         vla.placement,
-		ResponseCondition: vla.responseCondition,
+	return &createPubsubInput{
+		AccountName: df["account_name"].(string),
+		CreatePubsubInput: gofastly.CreatePubsubInput{
+			ServiceID:         serviceID,
+			ServiceVersion:    serviceVersion,
+			Name:              df["name"].(string),
+			User:              df["user"].(string),
+			SecretKey:         df["secret_key"].(string),
+			ProjectID:         df["project_id"].(string),
+			Topic:             df["topic"].(string),
+			Format:            vla.format,
+			FormatVersion:     uintOrDefault(vla.formatVersion),
+			Placement:         vla.placement,
+			ResponseCondition: vla.responseCondition,
+		},
 	}
 }
 
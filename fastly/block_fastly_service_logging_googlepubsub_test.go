@@ -1,8 +1,11 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"testing"
@@ -17,8 +20,9 @@ import (
 
 func TestResourceFastlyFlattenGooglePubSub(t *testing.T) {
 	cases := []struct {
-		remote []*gofastly.Pubsub
-		local  []map[string]any
+		remote       []*gofastly.Pubsub
+		accountNames map[string]string
+		local        []map[string]any
 	}{
 		{
 			remote: []*gofastly.Pubsub{
@@ -49,16 +53,116 @@ func TestResourceFastlyFlattenGooglePubSub(t *testing.T) {
 				},
 			},
 		},
+		{
+			remote: []*gofastly.Pubsub{
+				{
+					ServiceVersion: 1,
+					Name:           "googlepubsub-endpoint",
+					ProjectID:      "project-id",
+					Topic:          "topic",
+					FormatVersion:  2,
+					Placement:      "none",
+				},
+			},
+			accountNames: map[string]string{"googlepubsub-endpoint": "logger@project-id.iam.gserviceaccount.com"},
+			local: []map[string]any{
+				{
+					"name":           "googlepubsub-endpoint",
+					"account_name":   "logger@project-id.iam.gserviceaccount.com",
+					"project_id":     "project-id",
+					"topic":          "topic",
+					"placement":      "none",
+					"format_version": uint(2),
+				},
+			},
+		},
 	}
 
 	for _, c := range cases {
-		out := flattenGooglePubSub(c.remote)
+		out := flattenGooglePubSub(c.remote, c.accountNames)
 		if !reflect.DeepEqual(out, c.local) {
 			t.Fatalf("Error matching:\nexpected: %#v\n got: %#v", c.local, out)
 		}
 	}
 }
 
+func TestGooglePubSubAccountName(t *testing.T) {
+	var forms []url.Values
+	lists := 0
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("failed to parse form: %s", err)
+		}
+		forms = append(forms, r.PostForm)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			lists++
+			w.Write([]byte(`[
+				{"name": "other", "topic": "other", "format_version": 2},
+				{"name": "googlepubsub-endpoint", "account_name": "logger@project-id.iam.gserviceaccount.com", "topic": "topic", "format_version": "2"}
+			]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})).conn
+
+	h := &GooglePubSubServiceAttributeHandler{&DefaultServiceAttributeHandler{key: "logging_googlepubsub", serviceMetadata: ServiceMetadata{ServiceTypeCompute}}}
+	d := (&schema.Resource{Schema: map[string]*schema.Schema{}}).TestResourceData()
+	d.SetId("service-id")
+
+	resource := map[string]any{
+		"name":         "googlepubsub-endpoint",
+		"account_name": "logger@project-id.iam.gserviceaccount.com",
+		"user":         "",
+		"secret_key":   "",
+		"project_id":   "project-id",
+		"topic":        "topic",
+	}
+	if err := h.Create(context.Background(), d, resource, 1, conn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := h.Update(context.Background(), d, resource, map[string]any{"account_name": ""}, 1, conn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(forms))
+	}
+	if v := forms[0].Get("account_name"); v != "logger@project-id.iam.gserviceaccount.com" {
+		t.Errorf("expected the endpoint to be created with account_name, got %q", v)
+	}
+	if v, ok := forms[1]["account_name"]; !ok || v[0] != "" {
+		t.Errorf("expected the update to clear account_name, got %v", forms[1])
+	}
+
+	pubsubs, accountNames, err := listPubsubs(conn, "service-id", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if lists != 1 {
+		t.Errorf("expected the endpoints to be listed with 1 request, got %d", lists)
+	}
+	if len(pubsubs) != 2 || pubsubs[0].Name != "googlepubsub-endpoint" || pubsubs[0].Topic != "topic" || pubsubs[0].FormatVersion != 2 || pubsubs[1].FormatVersion != 2 {
+		t.Errorf("unexpected endpoints %#v", pubsubs)
+	}
+	if accountNames["googlepubsub-endpoint"] != "logger@project-id.iam.gserviceaccount.com" {
+		t.Errorf("unexpected account names %v", accountNames)
+	}
+
+	for name, testcase := range map[string]struct {
+		resource    map[string]any
+		expectError bool
+	}{
+		"service account key":   {resource: map[string]any{"user": "user", "secret_key": "key"}},
+		"impersonation":         {resource: map[string]any{"account_name": "logger", "user": "", "secret_key": ""}},
+		"user without key":      {resource: map[string]any{"user": "user", "secret_key": ""}, expectError: true},
+		"unknown until applied": {resource: map[string]any{"user": "", "secret_key": ""}},
+	} {
+		if err := validatePubsubCredentials(testcase.resource); (err != nil) != testcase.expectError {
+			t.Errorf("%s: unexpected error %v", name, err)
+		}
+	}
+}
+
 func TestUserEmailSchemaDefaultFunc(t *testing.T) {
 	computeAttributes := ServiceMetadata{ServiceTypeCompute}
 	v := NewServiceLoggingGooglePubSub(computeAttributes)
@@ -59,6 +59,18 @@ func (h *HerokuServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -116,6 +128,7 @@ func (h *HerokuServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting Heroku logging endpoints for (%s): %s", d.Id(), err)
@@ -127,6 +140,8 @@ func (h *HerokuServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 
 // Update updates the resource.
 func (h *HerokuServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateHerokuInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -165,6 +180,11 @@ func (h *HerokuServiceAttributeHandler) Update(_ context.Context, d *schema.Reso
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *HerokuServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *HerokuServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
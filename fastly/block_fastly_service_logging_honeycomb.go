@@ -35,7 +35,7 @@ func (h *HoneycombServiceAttributeHandler) GetSchema() *schema.Schema {
 		"dataset": {
 			Type:        schema.TypeString,
 			Required:    true,
-			Description: "The Honeycomb Dataset you want to log to",
+			Description: "The Honeycomb Dataset you want to log to. Logs are sent to Honeycomb's US region",
 		},
 		"name": {
 			Type:        schema.TypeString,
@@ -56,6 +56,18 @@ func (h *HoneycombServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting. Your log must produce valid JSON that Honeycomb can ingest.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -113,6 +125,7 @@ func (h *HoneycombServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting Honeycomb logging endpoints for (%s): %s", d.Id(), err)
@@ -124,6 +137,8 @@ func (h *HoneycombServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 
 // Update updates the resource.
 func (h *HoneycombServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateHoneycombInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -162,6 +177,11 @@ func (h *HoneycombServiceAttributeHandler) Update(_ context.Context, d *schema.R
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *HoneycombServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *HoneycombServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
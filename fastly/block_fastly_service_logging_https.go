@@ -2,8 +2,10 @@ package fastly
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -76,33 +78,37 @@ func (h *HTTPSLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The unique name of the HTTPS logging endpoint. It is important to note that changing this attribute will delete and recreate the resource",
 		},
 		"request_max_bytes": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Description: "The maximum number of bytes sent in one request",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Description:      RequestMaxBytesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
 		},
 		"request_max_entries": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Description: "The maximum number of logs sent in one request",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Description:      RequestMaxEntriesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
 		},
 		"tls_ca_cert": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "A secure certificate to authenticate the server with. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_cert": {
 			Type:             schema.TypeString,
 			Optional:         true,
-			Description:      "The client certificate used to make authenticated requests. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_HTTPS_CLIENT_CERT", ""),
+			Description:      "The client certificate used to make authenticated requests, for endpoints that require mutual TLS. Must be in PEM format, and match `tls_client_key`. Changing it, e.g. to rotate the certificate, updates the endpoint in place. You can provide this certificate via an environment variable, `FASTLY_HTTPS_CLIENT_CERT`",
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_key": {
 			Type:             schema.TypeString,
 			Optional:         true,
-			Description:      "The client private key used to make authenticated requests. Must be in PEM format",
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_HTTPS_CLIENT_KEY", ""),
+			Description:      "The client private key used to make authenticated requests, for endpoints that require mutual TLS. Must be in PEM format, and match `tls_client_cert`. Changing it, e.g. to rotate the key, updates the endpoint in place. You can provide this key via an environment variable, `FASTLY_HTTPS_CLIENT_KEY`",
 			Sensitive:        true,
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMPrivateKey()),
 		},
 		"tls_hostname": {
 			Type:        schema.TypeString,
@@ -123,6 +129,18 @@ func (h *HTTPSLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -156,7 +174,9 @@ func (h *HTTPSLoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 func (h *HTTPSLoggingServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildCreate(resource, d.Id(), serviceVersion)
 
-	log.Printf("[DEBUG] Fastly HTTPS logging addition opts: %#v", opts)
+	logOpts := *opts
+	logOpts.TLSClientKey = redactSensitive(opts.TLSClientKey)
+	log.Printf("[DEBUG] Fastly HTTPS logging addition opts: %#v", logOpts)
 
 	return createHTTPS(conn, opts)
 }
@@ -180,6 +200,7 @@ func (h *HTTPSLoggingServiceAttributeHandler) Read(_ context.Context, d *schema.
 		for _, element := range hll {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, hll)
 
 		if err := d.Set(h.GetKey(), hll); err != nil {
 			log.Printf("[WARN] Error setting HTTPS logging endpoints for (%s): %s", d.Id(), err)
@@ -191,6 +212,8 @@ func (h *HTTPSLoggingServiceAttributeHandler) Read(_ context.Context, d *schema.
 
 // Update updates the resource.
 func (h *HTTPSLoggingServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateHTTPSInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -262,6 +285,35 @@ func (h *HTTPSLoggingServiceAttributeHandler) Update(_ context.Context, d *schem
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *HTTPSLoggingServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := h.validateLoggingEndpoints(d); err != nil {
+		return err
+	}
+	return validateHTTPSClientCertificates(d, h.GetKey())
+}
+
+// validateHTTPSClientCertificates returns an error if the tls_client_cert and tls_client_key of an endpoint aren't a
+// key pair, e.g. when only one of them was rotated. Values that aren't known until the apply are empty, so endpoints
+// that don't have both set are skipped.
+func validateHTTPSClientCertificates(d *schema.ResourceDiff, key string) error {
+	var invalid []string
+	for _, r := range d.Get(key).(*schema.Set).List() {
+		resource := r.(map[string]any)
+		cert, clientKey := resource["tls_client_cert"].(string), resource["tls_client_key"].(string)
+		if cert == "" || clientKey == "" {
+			continue
+		}
+		if _, err := tls.X509KeyPair([]byte(cert), []byte(clientKey)); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q tls_client_cert and tls_client_key aren't a key pair (%s)", resource["name"], err))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", key, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
 // Delete deletes the resource.
 func (h *HTTPSLoggingServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -1,9 +1,11 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -52,6 +54,56 @@ func TestResourceFastlyFlattenHTTPS(t *testing.T) {
 	}
 }
 
+func TestHTTPSClientCertificatesValidation(t *testing.T) {
+	key, cert, err := generateKeyAndCert()
+	if err != nil {
+		t.Fatalf("failed to generate a key pair: %s", err)
+	}
+	otherKey, _, err := generateKeyAndCert()
+	if err != nil {
+		t.Fatalf("failed to generate a key pair: %s", err)
+	}
+
+	for name, testcase := range map[string]struct {
+		cert, key     string
+		expectedError string
+	}{
+		"key pair":              {cert: cert, key: key},
+		"no client certificate": {},
+		"key not known yet":     {cert: cert},
+		"rotated certificate only": {
+			cert:          cert,
+			key:           otherKey,
+			expectedError: `"mtls" tls_client_cert and tls_client_key aren't a key pair (tls: private key does not match public key)`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			endpoint := map[string]any{
+				"name": "mtls",
+				"url":  "https://logs.example.com",
+			}
+			if testcase.cert != "" {
+				endpoint["tls_client_cert"] = testcase.cert
+			}
+			if testcase.key != "" {
+				endpoint["tls_client_key"] = testcase.key
+			}
+			_, err := resourceServiceVCL().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]any{
+				"name":          "service",
+				"domain":        []any{map[string]any{"name": "example.com"}},
+				"logging_https": []any{endpoint},
+			}), nil)
+			if testcase.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.Contains(err.Error(), testcase.expectedError) {
+				t.Errorf("expected error %q, got %v", testcase.expectedError, err)
+			}
+		})
+	}
+}
+
 func TestAccFastlyServiceVCL_httpslogging_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
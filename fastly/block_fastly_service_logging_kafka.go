@@ -65,9 +65,10 @@ func (h *KafkaServiceAttributeHandler) GetSchema() *schema.Schema {
 			Sensitive:   true,
 		},
 		"request_max_bytes": {
-			Type:        schema.TypeInt,
-			Optional:    true,
-			Description: "Maximum size of log batch, if non-zero. Defaults to 0 for unbounded",
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Description:      RequestMaxBytesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
 		},
 		"required_acks": {
 			Type:        schema.TypeString,
@@ -78,20 +79,20 @@ func (h *KafkaServiceAttributeHandler) GetSchema() *schema.Schema {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "A secure certificate to authenticate the server with. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_cert": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "The client certificate used to make authenticated requests. Must be in PEM format",
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMCertificates()),
 		},
 		"tls_client_key": {
 			Type:             schema.TypeString,
 			Optional:         true,
 			Description:      "The client private key used to make authenticated requests. Must be in PEM format",
 			Sensitive:        true,
-			ValidateDiagFunc: validateStringTrimmed,
+			ValidateDiagFunc: validateAll(validateStringTrimmed, validatePEMPrivateKey()),
 		},
 		"tls_hostname": {
 			Type:        schema.TypeString,
@@ -122,6 +123,18 @@ func (h *KafkaServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -155,7 +168,9 @@ func (h *KafkaServiceAttributeHandler) GetSchema() *schema.Schema {
 func (h *KafkaServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildCreate(resource, d.Id(), serviceVersion)
 
-	log.Printf("[DEBUG] Fastly Kafka logging addition opts: %#v", opts)
+	logOpts := *opts
+	logOpts.TLSClientKey = redactSensitive(opts.TLSClientKey)
+	log.Printf("[DEBUG] Fastly Kafka logging addition opts: %#v", logOpts)
 
 	return createKafka(conn, opts)
 }
@@ -179,6 +194,7 @@ func (h *KafkaServiceAttributeHandler) Read(_ context.Context, d *schema.Resourc
 		for _, element := range kafkaLogList {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, kafkaLogList)
 
 		if err := d.Set(h.GetKey(), kafkaLogList); err != nil {
 			log.Printf("[WARN] Error setting Kafka logging endpoints for (%s): %s", d.Id(), err)
@@ -190,6 +206,8 @@ func (h *KafkaServiceAttributeHandler) Read(_ context.Context, d *schema.Resourc
 
 // Update updates the resource.
 func (h *KafkaServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateKafkaInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -264,6 +282,11 @@ func (h *KafkaServiceAttributeHandler) Update(_ context.Context, d *schema.Resou
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *KafkaServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *KafkaServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -63,6 +63,18 @@ func (h *LogentriesServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "Apache-style string or VCL variables to use for log formatting",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -136,6 +148,7 @@ func (h *LogentriesServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 		for _, element := range lel {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, lel)
 
 		if err := d.Set(h.GetKey(), lel); err != nil {
 			log.Printf("[WARN] Error setting Logentries for (%s): %s", d.Id(), err)
@@ -147,6 +160,8 @@ func (h *LogentriesServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 
 // Update updates the resource.
 func (h *LogentriesServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateLogentriesInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -191,6 +206,11 @@ func (h *LogentriesServiceAttributeHandler) Update(_ context.Context, d *schema.
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *LogentriesServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *LogentriesServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteLogentriesInput{
@@ -38,10 +38,11 @@ func (h *NewRelicServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The unique name of the New Relic logging endpoint. It is important to note that changing this attribute will delete and recreate the resource",
 		},
 		"region": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "US",
-			Description: "The region that log data will be sent to. Default: `US`",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "US",
+			Description:      "The region that log data will be sent to. One of `US` or `EU`. `EU` sends logs to New Relic's EU Log API endpoint (`log-api.eu.newrelic.com`), which EU accounts must use. Default: `US`",
+			ValidateDiagFunc: validateLoggingRegion(),
 		},
 		"token": {
 			Type:        schema.TypeString,
@@ -57,6 +58,18 @@ func (h *NewRelicServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting. Your log must produce valid JSON that New Relic Logs can ingest.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -114,6 +127,7 @@ func (h *NewRelicServiceAttributeHandler) Read(_ context.Context, d *schema.Reso
 		for _, element := range dll {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, dll)
 
 		if err := d.Set(h.GetKey(), dll); err != nil {
 			log.Printf("[WARN] Error setting New Relic logging endpoints for (%s): %s", d.Id(), err)
@@ -125,6 +139,8 @@ func (h *NewRelicServiceAttributeHandler) Read(_ context.Context, d *schema.Reso
 
 // Update updates the resource.
 func (h *NewRelicServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateNewRelicInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -163,6 +179,11 @@ func (h *NewRelicServiceAttributeHandler) Update(_ context.Context, d *schema.Re
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *NewRelicServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *NewRelicServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
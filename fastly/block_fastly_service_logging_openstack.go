@@ -108,6 +108,18 @@ func (h *OpenstackServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -165,6 +177,7 @@ func (h *OpenstackServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting OpenStack logging endpoints for (%s): %s", d.Id(), err)
@@ -176,6 +189,8 @@ func (h *OpenstackServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 
 // Update updates the resource.
 func (h *OpenstackServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateOpenstackInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -241,6 +256,11 @@ func (h *OpenstackServiceAttributeHandler) Update(_ context.Context, d *schema.R
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *OpenstackServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *OpenstackServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -56,6 +56,18 @@ func (h *PaperTrailServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "A Fastly [log format string](https://docs.fastly.com/en/guides/custom-log-formats)",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -129,6 +141,7 @@ func (h *PaperTrailServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 		for _, element := range pl {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, pl)
 
 		if err := d.Set(h.GetKey(), pl); err != nil {
 			log.Printf("[WARN] Error setting Papertrail for (%s): %s", d.Id(), err)
@@ -140,6 +153,8 @@ func (h *PaperTrailServiceAttributeHandler) Read(_ context.Context, d *schema.Re
 
 // Update updates the resource.
 func (h *PaperTrailServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdatePapertrailInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -178,6 +193,11 @@ func (h *PaperTrailServiceAttributeHandler) Update(_ context.Context, d *schema.
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *PaperTrailServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *PaperTrailServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeletePapertrailInput{
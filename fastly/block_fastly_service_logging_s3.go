@@ -45,7 +45,7 @@ func (h *S3LoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 				gofastly.S3AccessControlListBucketOwnerRead,
 				gofastly.S3AccessControlListBucketOwnerFullControl,
 			),
-			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
+			ValidateDiagFunc: allowEmpty(validation.ToDiagFunc(validation.StringInSlice(
 				[]string{
 					string(gofastly.S3AccessControlListPrivate),
 					string(gofastly.S3AccessControlListPublicRead),
@@ -56,7 +56,7 @@ func (h *S3LoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 					string(gofastly.S3AccessControlListBucketOwnerFullControl),
 				},
 				false,
-			)),
+			))),
 		},
 		"bucket_name": {
 			Type:        schema.TypeString,
@@ -122,7 +122,7 @@ func (h *S3LoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 				gofastly.S3RedundancyGlacierInstantRetrieval,
 				gofastly.S3RedundancyGlacierDeepArchive,
 				gofastly.S3RedundancyReduced),
-			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(
+			ValidateDiagFunc: allowEmpty(validation.ToDiagFunc(validation.StringInSlice(
 				[]string{
 					string(gofastly.S3RedundancyStandard),
 					string(gofastly.S3RedundancyIntelligentTiering),
@@ -134,7 +134,7 @@ func (h *S3LoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 					string(gofastly.S3RedundancyReduced),
 				},
 				false,
-			)),
+			))),
 		},
 		"s3_access_key": {
 			Type:        schema.TypeString,
@@ -183,6 +183,18 @@ func (h *S3LoggingServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -246,6 +258,7 @@ func (h *S3LoggingServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 		for _, element := range sl {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, sl)
 
 		if err := d.Set(h.GetKey(), sl); err != nil {
 			log.Printf("[WARN] Error setting S3 Logging for (%s): %s", d.Id(), err)
@@ -257,6 +270,8 @@ func (h *S3LoggingServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 
 // Update updates the resource.
 func (h *S3LoggingServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateS3Input{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -337,6 +352,11 @@ func (h *S3LoggingServiceAttributeHandler) Update(_ context.Context, d *schema.R
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *S3LoggingServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *S3LoggingServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -1,9 +1,11 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -133,6 +135,41 @@ func TestResourceFastlyFlattenS3(t *testing.T) {
 	}
 }
 
+func TestS3CompressionConflict(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		resource    *schema.Resource
+		codec       string
+		gzipLevel   int
+		expectError bool
+	}{
+		"codec":                    {resource: resourceServiceVCL(), codec: "zstd"},
+		"gzip level":               {resource: resourceServiceVCL(), gzipLevel: 5},
+		"codec and gzip level":     {resource: resourceServiceVCL(), codec: "gzip", gzipLevel: 5, expectError: true},
+		"compute, codec and level": {resource: resourceServiceCompute(), codec: "snappy", gzipLevel: 1, expectError: true},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"logging_s3": []any{
+				map[string]any{
+					"name":              "s3",
+					"bucket_name":       "bucket",
+					"compression_codec": testcase.codec,
+					"gzip_level":        testcase.gzipLevel,
+				},
+			},
+		})
+
+		_, err := testcase.resource.Diff(context.Background(), nil, config, nil)
+		if testcase.expectError && (err == nil || !strings.Contains(err.Error(), `invalid logging_s3: "s3" sets both compression_codec and gzip_level`)) {
+			t.Errorf("%s: expected a conflict error, got %v", name, err)
+		}
+		if !testcase.expectError && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_s3logging_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
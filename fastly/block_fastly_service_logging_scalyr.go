@@ -38,10 +38,11 @@ func (h *ScalyrServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The unique name of the Scalyr logging endpoint. It is important to note that changing this attribute will delete and recreate the resource",
 		},
 		"region": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			Default:     "US",
-			Description: "The region that log data will be sent to. One of `US` or `EU`. Defaults to `US` if undefined",
+			Type:             schema.TypeString,
+			Optional:         true,
+			Default:          "US",
+			Description:      "The region that log data will be sent to. One of `US` or `EU`. Scalyr is now DataSet, and `EU` sends logs to its EU ingest endpoint (`upload.eu.scalyr.com`). Defaults to `US` if undefined",
+			ValidateDiagFunc: validateLoggingRegion(),
 		},
 		"token": {
 			Type:        schema.TypeString,
@@ -57,6 +58,18 @@ func (h *ScalyrServiceAttributeHandler) GetSchema() *schema.Schema {
 			Optional:    true,
 			Description: "Apache style log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -114,6 +127,7 @@ func (h *ScalyrServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 		for _, element := range scalyrLogList {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, scalyrLogList)
 
 		if err := d.Set(h.GetKey(), scalyrLogList); err != nil {
 			log.Printf("[WARN] Error setting Scalyr logging endpoints for (%s): %s", d.Id(), err)
@@ -125,6 +139,8 @@ func (h *ScalyrServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 
 // Update updates the resource.
 func (h *ScalyrServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateScalyrInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -163,6 +179,11 @@ func (h *ScalyrServiceAttributeHandler) Update(_ context.Context, d *schema.Reso
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *ScalyrServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *ScalyrServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
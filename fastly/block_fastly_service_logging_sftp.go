@@ -2,11 +2,20 @@ package fastly
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
 )
 
 // SFTPServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
@@ -64,8 +73,9 @@ func (h *SFTPServiceAttributeHandler) GetSchema() *schema.Schema {
 		"password": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "The password for the server. If both `password` and `secret_key` are passed, `secret_key` will be preferred",
+			Description: "The password for the server. Either `password` or `secret_key` must be set. If both are set, `secret_key` will be preferred. It can also be set with the `FASTLY_SFTP_PASSWORD` environment variable",
 			Sensitive:   true,
+			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SFTP_PASSWORD", ""),
 		},
 		"path": {
 			Type:        schema.TypeString,
@@ -93,10 +103,18 @@ func (h *SFTPServiceAttributeHandler) GetSchema() *schema.Schema {
 		"secret_key": {
 			Type:             schema.TypeString,
 			Optional:         true,
-			Description:      "The SSH private key for the server. If both `password` and `secret_key` are passed, `secret_key` will be preferred",
+			Description:      "The SSH private key for the server. Either `password` or `secret_key` must be set. If both are set, `secret_key` will be preferred. It can also be set with the `FASTLY_SFTP_SECRET_KEY` environment variable",
 			Sensitive:        true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SFTP_SECRET_KEY", ""),
 			ValidateDiagFunc: validateStringTrimmed,
 		},
+		"secret_key_passphrase": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The passphrase protecting `secret_key`. Fastly can't use an encrypted key, so the key is decrypted locally and the decrypted, unencrypted key is sent to and stored by Fastly. The passphrase is never sent. It can also be set with the `FASTLY_SFTP_SECRET_KEY_PASSPHRASE` environment variable",
+			Sensitive:   true,
+			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SFTP_SECRET_KEY_PASSPHRASE", ""),
+		},
 		"ssh_known_hosts": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -122,6 +140,18 @@ func (h *SFTPServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     "%h %l %u %t \"%r\" %>s %b",
 			Description: "Apache-style string or VCL variables to use for log formatting.",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -153,7 +183,10 @@ func (h *SFTPServiceAttributeHandler) GetSchema() *schema.Schema {
 
 // Create creates the resource.
 func (h *SFTPServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
-	opts := h.buildCreate(resource, d.Id(), serviceVersion)
+	opts, err := h.buildCreate(resource, d.Id(), serviceVersion)
+	if err != nil {
+		return err
+	}
 
 	if opts.Password == "" && opts.SecretKey == "" {
 		return fmt.Errorf("either password or secret_key must be set")
@@ -183,6 +216,8 @@ func (h *SFTPServiceAttributeHandler) Read(_ context.Context, d *schema.Resource
 		for _, element := range ell {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, ell)
+		preserveSFTPSecretKeys(d, h.GetKey(), ell)
 
 		if err := d.Set(h.GetKey(), ell); err != nil {
 			log.Printf("[WARN] Error setting SFTP logging endpoints for (%s): %s", d.Id(), err)
@@ -194,6 +229,8 @@ func (h *SFTPServiceAttributeHandler) Read(_ context.Context, d *schema.Resource
 
 // Update updates the resource.
 func (h *SFTPServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateSFTPInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -214,8 +251,14 @@ func (h *SFTPServiceAttributeHandler) Update(_ context.Context, d *schema.Resour
 	if v, ok := modified["public_key"]; ok {
 		opts.PublicKey = gofastly.String(v.(string))
 	}
-	if v, ok := modified["secret_key"]; ok {
-		opts.SecretKey = gofastly.String(v.(string))
+	_, keyModified := modified["secret_key"]
+	_, passphraseModified := modified["secret_key_passphrase"]
+	if keyModified || passphraseModified {
+		secretKey, err := decryptSFTPSecretKey(resource["secret_key"].(string), resource["secret_key_passphrase"].(string))
+		if err != nil {
+			return fmt.Errorf("invalid secret_key of SFTP logging endpoint %q: %s", resource["name"], err)
+		}
+		opts.SecretKey = gofastly.String(secretKey)
 	}
 	if v, ok := modified["ssh_known_hosts"]; ok {
 		opts.SSHKnownHosts = gofastly.String(v.(string))
@@ -265,6 +308,132 @@ func (h *SFTPServiceAttributeHandler) Update(_ context.Context, d *schema.Resour
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint, including that it can authenticate with a password or a
+// secret key that can be decrypted.
+func (h *SFTPServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if err := h.validateLoggingEndpoints(d); err != nil {
+		return err
+	}
+
+	resources, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		if err := validateSFTPAuthentication(resource); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], err))
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// validateSFTPAuthentication returns an error if an endpoint has no way to authenticate, or its secret key can't be
+// decrypted with its passphrase. Values that aren't known until apply are empty when planning, so an endpoint whose
+// credentials are all empty is only reported by Create.
+func validateSFTPAuthentication(resource map[string]any) error {
+	secretKey, _ := resource["secret_key"].(string)
+	passphrase, _ := resource["secret_key_passphrase"].(string)
+	if secretKey == "" {
+		if passphrase != "" {
+			return fmt.Errorf("sets secret_key_passphrase without secret_key")
+		}
+		return nil
+	}
+	if _, err := decryptSFTPSecretKey(secretKey, passphrase); err != nil {
+		return fmt.Errorf("has an invalid secret_key: %s", err)
+	}
+	return nil
+}
+
+// decryptSFTPSecretKey returns secretKey decrypted with passphrase, in the PEM encoding that OpenSSH uses for keys
+// that aren't encrypted. A key is returned as it is when there is no passphrase.
+func decryptSFTPSecretKey(secretKey, passphrase string) (string, error) {
+	if passphrase == "" {
+		if _, err := ssh.ParseRawPrivateKey([]byte(secretKey)); err != nil {
+			var missing *ssh.PassphraseMissingError
+			if errors.As(err, &missing) {
+				return "", fmt.Errorf("the key is encrypted, set secret_key_passphrase")
+			}
+		}
+		return secretKey, nil
+	}
+
+	key, err := ssh.ParseRawPrivateKeyWithPassphrase([]byte(secretKey), []byte(passphrase))
+	if err != nil {
+		return "", err
+	}
+	var block *pem.Block
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case *ed25519.PrivateKey:
+		der, err := x509.MarshalPKCS8PrivateKey(*k)
+		if err != nil {
+			return "", err
+		}
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+	return strings.TrimSpace(string(pem.EncodeToMemory(block))), nil
+}
+
+// preserveSFTPSecretKeys keeps the encrypted secret_key and the secret_key_passphrase in the state of each block with
+// the same name, as the API only has the decrypted key and never has the passphrase.
+func preserveSFTPSecretKeys(d *schema.ResourceData, key string, elements []map[string]any) {
+	for _, sr := range d.Get(key).(*schema.Set).List() {
+		stateResource := sr.(map[string]any)
+		passphrase, _ := stateResource["secret_key_passphrase"].(string)
+		if passphrase == "" {
+			continue
+		}
+		for _, element := range elements {
+			if element["name"] != stateResource["name"] {
+				continue
+			}
+			element["secret_key_passphrase"] = passphrase
+			stateKey, _ := stateResource["secret_key"].(string)
+			apiKey, _ := element["secret_key"].(string)
+			if sameSFTPSecretKey(stateKey, passphrase, apiKey) {
+				element["secret_key"] = stateKey
+			}
+		}
+	}
+}
+
+// sameSFTPSecretKey reports whether apiKey is secretKey decrypted with passphrase. The keys are compared once parsed,
+// as the API may return the decrypted key in a different encoding than decryptSFTPSecretKey sent.
+func sameSFTPSecretKey(secretKey, passphrase, apiKey string) bool {
+	key, err := ssh.ParseRawPrivateKeyWithPassphrase([]byte(secretKey), []byte(passphrase))
+	if err != nil {
+		return false
+	}
+	other, err := ssh.ParseRawPrivateKey([]byte(apiKey))
+	if err != nil {
+		return false
+	}
+	// ssh returns ed25519 keys as pointers, which don't implement Equal.
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		key = *k
+	}
+	if k, ok := other.(*ed25519.PrivateKey); ok {
+		other = *k
+	}
+	k, ok := key.(interface{ Equal(crypto.PrivateKey) bool })
+	return ok && k.Equal(other)
+}
+
 // Delete deletes the resource.
 func (h *SFTPServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := h.buildDelete(resource, d.Id(), serviceVersion)
@@ -334,9 +503,14 @@ func flattenSFTP(sftpList []*gofastly.SFTP) []map[string]any {
 	return ssl
 }
 
-func (h *SFTPServiceAttributeHandler) buildCreate(sftpMap any, serviceID string, serviceVersion int) *gofastly.CreateSFTPInput {
+func (h *SFTPServiceAttributeHandler) buildCreate(sftpMap any, serviceID string, serviceVersion int) (*gofastly.CreateSFTPInput, error) {
 	df := sftpMap.(map[string]any)
 
+	secretKey, err := decryptSFTPSecretKey(df["secret_key"].(string), df["secret_key_passphrase"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret_key of SFTP logging endpoint %q: %s", df["name"], err)
+	}
+
 	vla := h.getVCLLoggingAttributes(df)
 	return &gofastly.CreateSFTPInput{
 		ServiceID:         serviceID,
@@ -346,7 +520,7 @@ func (h *SFTPServiceAttributeHandler) buildCreate(sftpMap any, serviceID string,
 		User:              df["user"].(string),
 		Path:              df["path"].(string),
 		PublicKey:         df["public_key"].(string),
-		SecretKey:         df["secret_key"].(string),
+		SecretKey:         secretKey,
 		SSHKnownHosts:     df["ssh_known_hosts"].(string),
 		Port:              uint(df["port"].(int)),
 		Password:          df["password"].(string),
@@ -358,7 +532,7 @@ func (h *SFTPServiceAttributeHandler) buildCreate(sftpMap any, serviceID string,
 		FormatVersion:     uintOrDefault(vla.formatVersion),
 		Placement:         vla.placement,
 		ResponseCondition: vla.responseCondition,
-	}
+	}, nil
 }
 
 func (h *SFTPServiceAttributeHandler) buildDelete(sftpMap any, serviceID string, serviceVersion int) *gofastly.DeleteSFTPInput {
@@ -37,24 +37,39 @@ func (h *SplunkServiceAttributeHandler) GetSchema() *schema.Schema {
 			Required:    true,
 			Description: "A unique name to identify the Splunk endpoint. It is important to note that changing this attribute will delete and recreate the resource",
 		},
+		"request_max_bytes": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Description:      RequestMaxBytesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
+		},
+		"request_max_entries": {
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Description:      RequestMaxEntriesDescription,
+			ValidateDiagFunc: validateLoggingRequestMax(),
+		},
 		"tls_ca_cert": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SPLUNK_CA_CERT", ""),
-			Description: "A secure certificate to authenticate the server with. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SPLUNK_CA_CERT`",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SPLUNK_CA_CERT", ""),
+			Description:      "A secure certificate to authenticate the server with. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SPLUNK_CA_CERT`",
+			ValidateDiagFunc: validatePEMCertificates(),
 		},
 		"tls_client_cert": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SPLUNK_CLIENT_CERT", ""),
-			Description: "The client certificate used to make authenticated requests. Must be in PEM format.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SPLUNK_CLIENT_CERT", ""),
+			Description:      "The client certificate used to make authenticated requests. Must be in PEM format.",
+			ValidateDiagFunc: validatePEMCertificates(),
 		},
 		"tls_client_key": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SPLUNK_CLIENT_KEY", ""),
-			Description: "The client private key used to make authenticated requests. Must be in PEM format.",
-			Sensitive:   true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SPLUNK_CLIENT_KEY", ""),
+			Description:      "The client private key used to make authenticated requests. Must be in PEM format.",
+			Sensitive:        true,
+			ValidateDiagFunc: validatePEMPrivateKey(),
 		},
 		"tls_hostname": {
 			Type:        schema.TypeString,
@@ -88,6 +103,18 @@ func (h *SplunkServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     "%h %l %u %t \"%r\" %>s %b",
 			Description: "Apache-style string or VCL variables to use for log formatting (default: `%h %l %u %t \"%r\" %>s %b`)",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -125,6 +152,8 @@ func (h *SplunkServiceAttributeHandler) Create(_ context.Context, d *schema.Reso
 		ServiceVersion:    serviceVersion,
 		Name:              resource["name"].(string),
 		URL:               resource["url"].(string),
+		RequestMaxEntries: uint(resource["request_max_entries"].(int)),
+		RequestMaxBytes:   uint(resource["request_max_bytes"].(int)),
 		Token:             resource["token"].(string),
 		TLSHostname:       resource["tls_hostname"].(string),
 		TLSCACert:         resource["tls_ca_cert"].(string),
@@ -137,7 +166,9 @@ func (h *SplunkServiceAttributeHandler) Create(_ context.Context, d *schema.Reso
 		Placement:         vla.placement,
 	}
 
-	log.Printf("[DEBUG] Splunk create opts: %#v", opts)
+	logOpts := opts
+	logOpts.TLSClientKey = redactSensitive(opts.TLSClientKey)
+	log.Printf("[DEBUG] Splunk create opts: %#v", logOpts)
 	_, err := conn.CreateSplunk(&opts)
 	if err != nil {
 		return err
@@ -164,6 +195,7 @@ func (h *SplunkServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 		for _, element := range spl {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, spl)
 
 		if err := d.Set(h.GetKey(), spl); err != nil {
 			log.Printf("[WARN] Error setting Splunks for (%s): %s", d.Id(), err)
@@ -175,6 +207,8 @@ func (h *SplunkServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 
 // Update updates the resource.
 func (h *SplunkServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateSplunkInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -234,6 +268,11 @@ func (h *SplunkServiceAttributeHandler) Update(_ context.Context, d *schema.Reso
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *SplunkServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *SplunkServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteSplunkInput{
@@ -259,18 +298,20 @@ func flattenSplunks(splunkList []*gofastly.Splunk) []map[string]any {
 	for _, s := range splunkList {
 		// Convert Splunk to a map for saving to state.
 		nbs := map[string]any{
-			"name":               s.Name,
-			"url":                s.URL,
-			"format":             s.Format,
-			"format_version":     s.FormatVersion,
-			"response_condition": s.ResponseCondition,
-			"placement":          s.Placement,
-			"token":              s.Token,
-			"use_tls":            s.UseTLS,
-			"tls_hostname":       s.TLSHostname,
-			"tls_ca_cert":        s.TLSCACert,
-			"tls_client_cert":    s.TLSClientCert,
-			"tls_client_key":     s.TLSClientKey,
+			"name":                s.Name,
+			"url":                 s.URL,
+			"request_max_entries": s.RequestMaxEntries,
+			"request_max_bytes":   s.RequestMaxBytes,
+			"format":              s.Format,
+			"format_version":      s.FormatVersion,
+			"response_condition":  s.ResponseCondition,
+			"placement":           s.Placement,
+			"token":               s.Token,
+			"use_tls":             s.UseTLS,
+			"tls_hostname":        s.TLSHostname,
+			"tls_ca_cert":         s.TLSCACert,
+			"tls_client_cert":     s.TLSClientCert,
+			"tls_client_key":      s.TLSClientKey,
 		}
 
 		// prune any empty values that come from the default string value in structs
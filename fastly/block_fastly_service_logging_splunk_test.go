@@ -28,6 +28,8 @@ func TestResourceFastlyFlattenSplunk(t *testing.T) {
 				{
 					Name:              "test-splunk",
 					URL:               "https://mysplunkendpoint.example.com/services/collector/event",
+					RequestMaxEntries: 100,
+					RequestMaxBytes:   1000,
 					Format:            "%h %l %u %t \"%r\" %>s %b",
 					FormatVersion:     1,
 					ResponseCondition: "error_response",
@@ -46,14 +48,16 @@ func TestResourceFastlyFlattenSplunk(t *testing.T) {
 			},
 			local: []map[string]any{
 				{
-					"name":               "test-splunk",
-					"url":                "https://mysplunkendpoint.example.com/services/collector/event",
-					"format":             "%h %l %u %t \"%r\" %>s %b",
-					"format_version":     uint(1),
-					"response_condition": "error_response",
-					"placement":          "waf_debug",
-					"token":              "test-token",
-					"tls_hostname":       "example.com",
+					"name":                "test-splunk",
+					"url":                 "https://mysplunkendpoint.example.com/services/collector/event",
+					"request_max_entries": uint(100),
+					"request_max_bytes":   uint(1000),
+					"format":              "%h %l %u %t \"%r\" %>s %b",
+					"format_version":      uint(1),
+					"response_condition":  "error_response",
+					"placement":           "waf_debug",
+					"token":               "test-token",
+					"tls_hostname":        "example.com",
 					// The same certificate is used here for
 					// TLSCACert and TLSClientCert, but this
 					// is strictly for testing. In practice
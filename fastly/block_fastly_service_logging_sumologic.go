@@ -58,6 +58,18 @@ func (h *SumologicServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "Apache-style string or VCL variables to use for log formatting",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -130,6 +142,7 @@ func (h *SumologicServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 		for _, element := range sul {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, sul)
 
 		if err := d.Set(h.GetKey(), sul); err != nil {
 			log.Printf("[WARN] Error setting Sumologic for (%s): %s", d.Id(), err)
@@ -141,6 +154,8 @@ func (h *SumologicServiceAttributeHandler) Read(_ context.Context, d *schema.Res
 
 // Update updates the resource.
 func (h *SumologicServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateSumologicInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -182,6 +197,11 @@ func (h *SumologicServiceAttributeHandler) Update(_ context.Context, d *schema.R
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *SumologicServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *SumologicServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteSumologicInput{
@@ -56,23 +56,26 @@ func (h *SyslogServiceAttributeHandler) GetSchema() *schema.Schema {
 			Description: "The port associated with the address where the Syslog endpoint can be accessed. Default `514`",
 		},
 		"tls_ca_cert": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SYSLOG_CA_CERT", ""),
-			Description: "A secure certificate to authenticate the server with. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SYSLOG_CA_CERT`",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SYSLOG_CA_CERT", ""),
+			Description:      "A secure certificate to authenticate the server with. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SYSLOG_CA_CERT`",
+			ValidateDiagFunc: validatePEMCertificates(),
 		},
 		"tls_client_cert": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SYSLOG_CLIENT_CERT", ""),
-			Description: "The client certificate used to make authenticated requests. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SYSLOG_CLIENT_CERT`",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SYSLOG_CLIENT_CERT", ""),
+			Description:      "The client certificate used to make authenticated requests. Must be in PEM format. You can provide this certificate via an environment variable, `FASTLY_SYSLOG_CLIENT_CERT`",
+			ValidateDiagFunc: validatePEMCertificates(),
 		},
 		"tls_client_key": {
-			Type:        schema.TypeString,
-			Optional:    true,
-			DefaultFunc: schema.EnvDefaultFunc("FASTLY_SYSLOG_CLIENT_KEY", ""),
-			Description: "The client private key used to make authenticated requests. Must be in PEM format. You can provide this key via an environment variable, `FASTLY_SYSLOG_CLIENT_KEY`",
-			Sensitive:   true,
+			Type:             schema.TypeString,
+			Optional:         true,
+			DefaultFunc:      schema.EnvDefaultFunc("FASTLY_SYSLOG_CLIENT_KEY", ""),
+			Description:      "The client private key used to make authenticated requests. Must be in PEM format. You can provide this key via an environment variable, `FASTLY_SYSLOG_CLIENT_KEY`",
+			Sensitive:        true,
+			ValidateDiagFunc: validatePEMPrivateKey(),
 		},
 		"tls_hostname": {
 			Type:        schema.TypeString,
@@ -101,6 +104,18 @@ func (h *SyslogServiceAttributeHandler) GetSchema() *schema.Schema {
 			Default:     `%h %l %u %t "%r" %>s %b`,
 			Description: "Apache-style string or VCL variables to use for log formatting",
 		}
+		blockAttributes["validate_format_variables"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: ValidateFormatVariablesDescription,
+		}
+		blockAttributes["format_is_json"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: FormatIsJSONDescription,
+		}
 		blockAttributes["format_version"] = &schema.Schema{
 			Type:             schema.TypeInt,
 			Optional:         true,
@@ -153,7 +168,9 @@ func (h *SyslogServiceAttributeHandler) Create(_ context.Context, d *schema.Reso
 		Placement:         vla.placement,
 	}
 
-	log.Printf("[DEBUG] Create Syslog Opts: %#v", opts)
+	logOpts := opts
+	logOpts.TLSClientKey = redactSensitive(opts.TLSClientKey)
+	log.Printf("[DEBUG] Create Syslog Opts: %#v", logOpts)
 	_, err := conn.CreateSyslog(&opts)
 	if err != nil {
 		return err
@@ -180,6 +197,7 @@ func (h *SyslogServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 		for _, element := range sll {
 			h.pruneVCLLoggingAttributes(element)
 		}
+		h.preserveVCLLoggingAttributes(d, sll)
 
 		if err := d.Set(h.GetKey(), sll); err != nil {
 			log.Printf("[WARN] Error setting Syslog for (%s): %s", d.Id(), err)
@@ -191,6 +209,8 @@ func (h *SyslogServiceAttributeHandler) Read(_ context.Context, d *schema.Resour
 
 // Update updates the resource.
 func (h *SyslogServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	h.canonicalizeModifiedLoggingFormat(resource, modified)
+
 	opts := gofastly.UpdateSyslogInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -256,6 +276,11 @@ func (h *SyslogServiceAttributeHandler) Update(_ context.Context, d *schema.Reso
 	return nil
 }
 
+// CustomizeDiff validates the configuration of each endpoint.
+func (h *SyslogServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	return h.validateLoggingEndpoints(d)
+}
+
 // Delete deletes the resource.
 func (h *SyslogServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteSyslogInput{
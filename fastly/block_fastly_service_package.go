@@ -2,8 +2,13 @@ package fastly
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,16 +33,36 @@ func NewServicePackage(sa ServiceMetadata) ServiceAttributeDefinition {
 func (h *PackageServiceAttributeHandler) Register(s *schema.Resource) error {
 	s.Schema[h.GetKey()] = &schema.Schema{
 		Type:        schema.TypeList,
-		Required:    true,
-		Description: "The `package` block supports uploading or modifying Wasm packages for use in a Fastly Compute@Edge service. See Fastly's documentation on [Compute@Edge](https://developer.fastly.com/learning/compute/)",
+		Optional:    true,
+		Computed:    true,
+		Description: "The `package` block supports uploading or modifying Wasm packages for use in a Fastly Compute@Edge service. See Fastly's documentation on [Compute@Edge](https://developer.fastly.com/learning/compute/). Omit it to deploy packages outside of Terraform, e.g. from a CI pipeline. The package of the active version is then kept in each new version, and a new version that has no package is left as a draft instead of being activated",
 		MaxItems:    1,
-		MinItems:    1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"filename": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"package.0.filename", "package.0.oci_ref"},
+					Description:  "The path to the Wasm deployment package within your local filesystem. Exactly one of `filename` or `oci_ref` must be set",
+				},
+				"oci_ref": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateOCIReference(),
+					Description:      "A reference to a Wasm deployment package stored as an artifact in an OCI registry, pinned by digest, e.g. `ghcr.io/org/app@sha256:<digest>`. The package is pulled from the registry and uploaded during the apply. The artifact's only layer, or its only gzipped tarball layer, is taken to be the package. Exactly one of `filename` or `oci_ref` must be set",
+				},
+				"oci_password": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "The path to the Wasm deployment package within your local filesystem",
+					Optional:    true,
+					Sensitive:   true,
+					DefaultFunc: schema.EnvDefaultFunc("FASTLY_OCI_PASSWORD", ""),
+					Description: "The password or token used to pull `oci_ref` from a registry that requires authentication. It can also be set with the `FASTLY_OCI_PASSWORD` environment variable",
+				},
+				"oci_username": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					DefaultFunc: schema.EnvDefaultFunc("FASTLY_OCI_USERNAME", ""),
+					Description: "The username used to pull `oci_ref` from a registry that requires authentication. It can also be set with the `FASTLY_OCI_USERNAME` environment variable",
 				},
 				// sha512 hash of the file
 				"source_code_hash": {
@@ -53,25 +78,72 @@ func (h *PackageServiceAttributeHandler) Register(s *schema.Resource) error {
 }
 
 // Process creates or updates the attribute against the Fastly API.
-func (h *PackageServiceAttributeHandler) Process(_ context.Context, d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
+func (h *PackageServiceAttributeHandler) Process(ctx context.Context, d *schema.ResourceData, latestVersion int, conn *gofastly.Client) error {
 	if v, ok := d.GetOk(h.GetKey()); ok {
 		// Schema guarantees one package block.
 		pkg := v.([]any)[0].(map[string]any)
 		packageFilename := pkg["filename"].(string)
 
-		err := updatePackage(conn, &gofastly.UpdatePackageInput{
+		if ref := pkg["oci_ref"].(string); ref != "" {
+			r, err := parseOCIReference(ref)
+			if err != nil {
+				return err
+			}
+			log.Printf("[DEBUG] Pulling package %s for (%s)", ref, d.Id())
+			packageFilename, err = newOCIClient(registryClientFromContext(ctx), pkg["oci_username"].(string), pkg["oci_password"].(string)).pullPackage(ctx, r)
+			if err != nil {
+				return fmt.Errorf("error pulling package %s: %s", ref, err)
+			}
+			defer os.Remove(packageFilename)
+		}
+
+		uploadConn := uploadConnFromContext(ctx, conn)
+		err := updatePackage(ctx, uploadConn, &gofastly.UpdatePackageInput{
 			ServiceID:      d.Id(),
 			ServiceVersion: latestVersion,
 			PackagePath:    packageFilename,
 		})
 		if err != nil {
-			return fmt.Errorf("error modifying package %s: %s", d.Id(), err)
+			return fmt.Errorf("error modifying package %s: %w", d.Id(), errorFromConn(uploadConn, err))
 		}
 	}
 
 	return nil
 }
 
+// CanActivate returns whether a version has a package to activate. When the package block is omitted, packages are
+// deployed outside of Terraform, so a new service has none until the first deployment.
+func (h *PackageServiceAttributeHandler) CanActivate(_ context.Context, d *schema.ResourceData, version int, conn *gofastly.Client) (bool, string, error) {
+	if len(d.Get(h.GetKey()).([]any)) > 0 {
+		return true, "", nil
+	}
+
+	_, err := conn.GetPackage(&gofastly.GetPackageInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: version,
+	})
+	if err != nil {
+		if err, ok := err.(*gofastly.HTTPError); ok && err.IsNotFound() {
+			return false, fmt.Sprintf("version (%d) has no package, deploy one to it outside of Terraform and activate it", version), nil
+		}
+		return false, "", fmt.Errorf("error looking up Package for (%s), version (%v): %v", d.Id(), version, err)
+	}
+	return true, "", nil
+}
+
+// CustomizeDiff checks the manifest of a package that is going to be uploaded, so that a package the API would reject
+// because of malformed metadata is reported when planning.
+func (h *PackageServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if !d.HasChange(h.GetKey()) || !d.NewValueKnown("package.0.filename") {
+		return nil
+	}
+	filename, ok := d.Get("package.0.filename").(string)
+	if !ok || filename == "" {
+		return nil
+	}
+	return checkPackageManifest(filename)
+}
+
 // Read refreshes the attribute state against the Fastly API.
 func (h *PackageServiceAttributeHandler) Read(_ context.Context, d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client) error {
 	resources := d.Get(h.key).([]any)
@@ -91,8 +163,11 @@ func (h *PackageServiceAttributeHandler) Read(_ context.Context, d *schema.Resou
 			return fmt.Errorf("error looking up Package for (%s), version (%v): %v", d.Id(), s.ActiveVersion.Number, err)
 		}
 
-		filename := d.Get("package.0.filename").(string)
-		wp := flattenPackage(pkg, filename)
+		var configured map[string]any
+		if len(resources) > 0 && resources[0] != nil {
+			configured = resources[0].(map[string]any)
+		}
+		wp := flattenPackage(pkg, configured)
 		if err := d.Set(h.GetKey(), wp); err != nil {
 			log.Printf("[WARN] Error setting Package for (%s): %s", d.Id(), err)
 		}
@@ -101,16 +176,60 @@ func (h *PackageServiceAttributeHandler) Read(_ context.Context, d *schema.Resou
 	return nil
 }
 
-func updatePackage(conn *gofastly.Client, i *gofastly.UpdatePackageInput) error {
-	_, err := conn.UpdatePackage(i)
-	return err
+// packageUploadAttempts is how many times uploading a package is attempted before giving up.
+const packageUploadAttempts = 5
+
+// packageUploadBackoff is how long to wait before retrying a failed package upload for the first time. The wait
+// doubles after each attempt.
+var packageUploadBackoff = 2 * time.Second
+
+// updatePackage uploads a package, retrying with exponential backoff when the upload fails because of a network error,
+// rate limiting or a server error. The API doesn't support resumable uploads, so each attempt uploads the whole
+// package.
+func updatePackage(ctx context.Context, conn *gofastly.Client, i *gofastly.UpdatePackageInput) error {
+	backoff := packageUploadBackoff
+	for attempt := 1; ; attempt++ {
+		_, err := conn.UpdatePackage(i)
+		if err == nil || attempt == packageUploadAttempts || !isRetryableUploadError(ctx, err) {
+			return err
+		}
+
+		log.Printf("[WARN] Uploading package for (%s), version (%d), failed on attempt %d of %d, retrying in %s: %s", i.ServiceID, i.ServiceVersion, attempt, packageUploadAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryableUploadError returns whether an upload that failed with err may succeed if it is attempted again.
+func isRetryableUploadError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var httpErr *gofastly.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	// The HTTP client returns a *url.Error when the request couldn't be made or the response couldn't be read. Other
+	// errors, e.g. reading the package file, won't go away by trying again.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
 }
 
-func flattenPackage(pkg *gofastly.Package, filename string) []map[string]any {
+// flattenPackage converts a package to state. The API doesn't return where the package came from, so the attributes
+// saying so are kept from the configured package block.
+func flattenPackage(pkg *gofastly.Package, configured map[string]any) []map[string]any {
 	var pa []map[string]any
 	p := map[string]any{
 		"source_code_hash": pkg.Metadata.HashSum,
-		"filename":         filename,
+	}
+	for _, k := range []string{"filename", "oci_ref", "oci_username", "oci_password"} {
+		if v, ok := configured[k]; ok {
+			p[k] = v
+		}
 	}
 
 	// Convert Package to a map for saving to state.
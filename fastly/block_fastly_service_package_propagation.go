@@ -0,0 +1,145 @@
+package fastly
+
+import (
+	"context"
+	"crypto/sha512"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// PackagePropagationServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
+type PackagePropagationServiceAttributeHandler struct{}
+
+// NewServicePackagePropagation returns a new resource.
+func NewServicePackagePropagation() ServiceAttributeDefinition {
+	return &PackagePropagationServiceAttributeHandler{}
+}
+
+// Process creates or updates the attribute against the Fastly API.
+//
+// Waiting for the package to propagate only happens after activation, so there is nothing to process.
+func (h *PackagePropagationServiceAttributeHandler) Process(_ context.Context, _ *schema.ResourceData, _ int, _ *gofastly.Client) error {
+	return nil
+}
+
+// Read refreshes the attribute state against the Fastly API.
+//
+// The attributes only configure the provider, so there is nothing to read.
+func (h *PackagePropagationServiceAttributeHandler) Read(_ context.Context, _ *schema.ResourceData, _ *gofastly.ServiceDetail, _ *gofastly.Client) error {
+	return nil
+}
+
+// HasChange returns whether the state of the attribute has changed against Terraform stored state.
+//
+// The attributes don't change the service, so never require a new version.
+func (h *PackagePropagationServiceAttributeHandler) HasChange(_ *schema.ResourceData) bool {
+	return false
+}
+
+// MustProcess returns whether we must process the resource.
+func (h *PackagePropagationServiceAttributeHandler) MustProcess(d *schema.ResourceData, _ bool) bool {
+	return h.HasChange(d)
+}
+
+// AfterActivation waits, if wait_for_package_propagation is set, until the activated version is serving the package
+// from the configuration. When package_propagation_check_url is set, this also waits until every POP returns the same
+// successful response for the URL.
+func (h *PackagePropagationServiceAttributeHandler) AfterActivation(ctx context.Context, d *schema.ResourceData, activatedVersion int, conn *gofastly.Client) error {
+	if !d.Get("wait_for_package_propagation").(bool) {
+		return nil
+	}
+
+	var expectedHashSum string
+	if filename, ok := d.GetOk("package.0.filename"); ok {
+		b, err := os.ReadFile(filepath.Clean(filename.(string)))
+		if err != nil {
+			return fmt.Errorf("error reading package %s: %s", filename, err)
+		}
+		expectedHashSum = fmt.Sprintf("%x", sha512.Sum512(b))
+	}
+	checkURL := d.Get("package_propagation_check_url").(string)
+	timeout := time.Duration(d.Get("package_propagation_timeout").(int)) * time.Second
+
+	log.Printf("[DEBUG] Waiting for the package of Fastly Service (%s), version (%d), to propagate", d.Id(), activatedVersion)
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		pkg, err := conn.GetPackage(&gofastly.GetPackageInput{
+			ServiceID:      d.Id(),
+			ServiceVersion: activatedVersion,
+		})
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if expectedHashSum != "" && pkg.Metadata.HashSum != expectedHashSum {
+			return resource.RetryableError(fmt.Errorf("expected package with hash %s but it was %s", expectedHashSum, pkg.Metadata.HashSum))
+		}
+
+		if checkURL != "" {
+			checks, err := conn.EdgeCheck(&gofastly.EdgeCheckInput{
+				URL: checkURL,
+			})
+			if err != nil {
+				return resource.RetryableError(err)
+			}
+			if err := edgeCheckConsistent(checks); err != nil {
+				return resource.RetryableError(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error waiting for the package of Fastly Service (%s), version (%d), to propagate: %s", d.Id(), activatedVersion, err)
+	}
+	return nil
+}
+
+// edgeCheckConsistent returns an error unless every POP returned the same successful response.
+func edgeCheckConsistent(checks []*gofastly.EdgeCheck) error {
+	if len(checks) == 0 {
+		return fmt.Errorf("no POPs returned a response")
+	}
+	for _, c := range checks {
+		if c.Response == nil || c.Response.Status >= 500 {
+			var status uint
+			if c.Response != nil {
+				status = c.Response.Status
+			}
+			return fmt.Errorf("%s returned status %d", c.Server, status)
+		}
+		if c.Hash != checks[0].Hash {
+			return fmt.Errorf("%s returned a different response to %s", c.Server, checks[0].Server)
+		}
+	}
+	return nil
+}
+
+// Register add the attribute to the resource schema.
+func (h *PackagePropagationServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema["wait_for_package_propagation"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to wait, after activating a new version, until the version's package is live. Default `false`",
+	}
+	s.Schema["package_propagation_timeout"] = &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          300,
+		Description:      "How long to wait for the package to be live, in seconds, when `wait_for_package_propagation` is `true`. Default `300`",
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+	}
+	s.Schema["package_propagation_check_url"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "A URL served by the service. When `wait_for_package_propagation` is `true`, Fastly requests the URL from every POP until they all return the same successful response",
+	}
+	return nil
+}
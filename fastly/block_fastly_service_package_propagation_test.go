@@ -0,0 +1,112 @@
+package fastly
+
+import (
+	"context"
+	"crypto/sha512"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestEdgeCheckConsistent(t *testing.T) {
+	ok := &gofastly.EdgeCheckResponse{Status: 200}
+
+	for name, testcase := range map[string]struct {
+		checks      []*gofastly.EdgeCheck
+		expectError bool
+	}{
+		"consistent": {
+			checks: []*gofastly.EdgeCheck{
+				{Server: "cache-lhr1", Hash: "a", Response: ok},
+				{Server: "cache-jfk1", Hash: "a", Response: ok},
+			},
+		},
+		"different responses": {
+			checks: []*gofastly.EdgeCheck{
+				{Server: "cache-lhr1", Hash: "a", Response: ok},
+				{Server: "cache-jfk1", Hash: "b", Response: ok},
+			},
+			expectError: true,
+		},
+		"server error": {
+			checks: []*gofastly.EdgeCheck{
+				{Server: "cache-lhr1", Hash: "a", Response: &gofastly.EdgeCheckResponse{Status: 503}},
+			},
+			expectError: true,
+		},
+		"no response": {
+			checks: []*gofastly.EdgeCheck{
+				{Server: "cache-lhr1", Hash: "a"},
+			},
+			expectError: true,
+		},
+		"no POPs": {
+			expectError: true,
+		},
+	} {
+		err := edgeCheckConsistent(testcase.checks)
+		if testcase.expectError && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if !testcase.expectError && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
+func TestPackagePropagationAfterActivation(t *testing.T) {
+	packageFile := filepath.Join(t.TempDir(), "package.tar.gz")
+	if err := os.WriteFile(packageFile, []byte("package"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	hashSum := fmt.Sprintf("%x", sha512.Sum512([]byte("package")))
+
+	edgeChecks := 0
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/service/service-id/version/2/package":
+			fmt.Fprintf(w, `{"service_id": "service-id", "version": 2, "metadata": {"hashsum": "%s"}}`, hashSum)
+		case r.URL.Path == "/content/edge_check":
+			edgeChecks++
+			// The first POP is still serving the previous version the first time the URL is checked.
+			hash := "new"
+			if edgeChecks == 1 {
+				hash = "old"
+			}
+			fmt.Fprintf(w, `[{"server": "cache-lhr1", "hash": "%s", "response": {"status": 200}}, {"server": "cache-jfk1", "hash": "new", "response": {"status": 200}}]`, hash)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})).conn
+
+	d := schema.TestResourceDataRaw(t, resourceServiceCompute().Schema, map[string]any{
+		"name": "service",
+		"package": []any{
+			map[string]any{"filename": packageFile},
+		},
+		"wait_for_package_propagation":  true,
+		"package_propagation_timeout":   30,
+		"package_propagation_check_url": "https://example.com/",
+	})
+	d.SetId("service-id")
+
+	h := &PackagePropagationServiceAttributeHandler{}
+	if err := h.AfterActivation(context.Background(), d, 2, conn); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if edgeChecks != 2 {
+		t.Errorf("expected the URL to be checked until every POP returned the same response, got %d checks", edgeChecks)
+	}
+
+	// The activated version doesn't have the package from the configuration.
+	if err := h.AfterActivation(context.Background(), d, 3, conn); err == nil || !strings.Contains(err.Error(), "to propagate") {
+		t.Errorf("expected an error waiting for the package to propagate, got %v", err)
+	}
+}
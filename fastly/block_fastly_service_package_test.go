@@ -1,8 +1,13 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -10,6 +15,87 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestUpdatePackageRetries(t *testing.T) {
+	defer func(backoff time.Duration) { packageUploadBackoff = backoff }(packageUploadBackoff)
+	packageUploadBackoff = time.Millisecond
+
+	packageFile := filepath.Join(t.TempDir(), "package.tar.gz")
+	if err := os.WriteFile(packageFile, []byte("package"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, testcase := range map[string]struct {
+		statuses       []int
+		expectAttempts int
+		expectError    bool
+	}{
+		"succeeds":                {statuses: []int{http.StatusOK}, expectAttempts: 1},
+		"retries server errors":   {statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusOK}, expectAttempts: 3},
+		"doesn't retry bad input": {statuses: []int{http.StatusBadRequest}, expectAttempts: 1, expectError: true},
+		"gives up after attempts": {statuses: []int{http.StatusServiceUnavailable}, expectAttempts: packageUploadAttempts, expectError: true},
+	} {
+		attempts := 0
+		conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := testcase.statuses[len(testcase.statuses)-1]
+			if attempts < len(testcase.statuses) {
+				status = testcase.statuses[attempts]
+			}
+			attempts++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			w.Write([]byte(`{"service_id": "service-id", "version": 1}`))
+		})).conn
+
+		err := updatePackage(context.Background(), conn, &gofastly.UpdatePackageInput{
+			ServiceID:      "service-id",
+			ServiceVersion: 1,
+			PackagePath:    packageFile,
+		})
+
+		if testcase.expectError && err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+		if !testcase.expectError && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if attempts != testcase.expectAttempts {
+			t.Errorf("%s: expected %d attempts, got %d", name, testcase.expectAttempts, attempts)
+		}
+	}
+}
+
+func TestPackageCanActivate(t *testing.T) {
+	requests := 0
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/service/service-id/version/2/package" {
+			w.Write([]byte(`{"service_id": "service-id", "version": 2, "metadata": {"hashsum": "abc"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"msg": "Not found"}`))
+	})).conn
+
+	h := &PackageServiceAttributeHandler{&DefaultServiceAttributeHandler{key: "package"}}
+
+	d := resourceServiceCompute().Data(&terraform.InstanceState{ID: "service-id"})
+	if ok, reason, err := h.CanActivate(context.Background(), d, 1, conn); err != nil || ok || reason == "" {
+		t.Errorf("expected a version without a package not to be activated, got %t, %q, %v", ok, reason, err)
+	}
+	if ok, _, err := h.CanActivate(context.Background(), d, 2, conn); err != nil || !ok {
+		t.Errorf("expected a version with a package deployed outside of Terraform to be activated, got %t, %v", ok, err)
+	}
+
+	requests = 0
+	if err := d.Set("package", []any{map[string]any{"filename": "package.tar.gz"}}); err != nil {
+		t.Fatal(err)
+	}
+	if ok, _, err := h.CanActivate(context.Background(), d, 1, conn); err != nil || !ok || requests != 0 {
+		t.Errorf("expected a version with a configured package to be activated without looking it up, got %t, %v, %d requests", ok, err, requests)
+	}
+}
+
 func TestAccFastlyServiceVCL_package_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name01 := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"strings"
+	"unicode/utf8"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -46,7 +49,7 @@ func (h *ResponseObjectServiceAttributeHandler) GetSchema() *schema.Schema {
 					Type:        schema.TypeString,
 					Optional:    true,
 					Default:     "",
-					Description: "The content to deliver for the response object",
+					Description: "The content to deliver for the response object. Conflicts with `source_file`",
 				},
 				"content_type": {
 					Type:        schema.TypeString,
@@ -71,6 +74,19 @@ func (h *ResponseObjectServiceAttributeHandler) GetSchema() *schema.Schema {
 					Default:     "OK",
 					Description: "The HTTP Response. Default `OK`",
 				},
+				"source_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Default:     "",
+					Description: "The path of a file to read the content to deliver from when applying, instead of setting `content`, e.g. `\"${path.module}/maintenance.html\"`. The file must be UTF-8 text",
+				},
+				"source_sha256": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Default:          "",
+					Description:      "The hex encoded SHA-256 checksum the content read from `source_file` must have, e.g. `filesha256(\"${path.module}/maintenance.html\")`. A change to the checksum updates the response object. Required when `source_file` is set",
+					ValidateDiagFunc: validateSHA256(),
+				},
 				"status": {
 					Type:        schema.TypeInt,
 					Optional:    true,
@@ -84,13 +100,21 @@ func (h *ResponseObjectServiceAttributeHandler) GetSchema() *schema.Schema {
 
 // Create creates the resource.
 func (h *ResponseObjectServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	content := resource["content"].(string)
+	if path := resource["source_file"].(string); path != "" {
+		var err error
+		if content, err = readResponseObjectSource(path, resource["source_sha256"].(string)); err != nil {
+			return fmt.Errorf("error reading the content of Response Object (%s): %w", resource["name"], err)
+		}
+	}
+
 	opts := gofastly.CreateResponseObjectInput{
 		ServiceID:        d.Id(),
 		ServiceVersion:   serviceVersion,
 		Name:             resource["name"].(string),
 		Status:           gofastly.Uint(uint(resource["status"].(int))),
 		Response:         resource["response"].(string),
-		Content:          resource["content"].(string),
+		Content:          content,
 		ContentType:      resource["content_type"].(string),
 		RequestCondition: resource["request_condition"].(string),
 		CacheCondition:   resource["cache_condition"].(string),
@@ -119,6 +143,7 @@ func (h *ResponseObjectServiceAttributeHandler) Read(_ context.Context, d *schem
 		}
 
 		rol := flattenResponseObjects(responseObjectList)
+		preserveContentSources(resources, rol, "source_file")
 
 		if err := d.Set(h.GetKey(), rol); err != nil {
 			log.Printf("[WARN] Error setting Response Object for (%s): %s", d.Id(), err)
@@ -150,6 +175,15 @@ func (h *ResponseObjectServiceAttributeHandler) Update(_ context.Context, d *sch
 	if v, ok := modified["content"]; ok {
 		opts.Content = gofastly.String(v.(string))
 	}
+	_, fileModified := modified["source_file"]
+	_, sha256Modified := modified["source_sha256"]
+	if path := resource["source_file"].(string); path != "" && (fileModified || sha256Modified || opts.Content != nil) {
+		content, err := readResponseObjectSource(path, resource["source_sha256"].(string))
+		if err != nil {
+			return fmt.Errorf("error reading the content of Response Object (%s): %w", resource["name"], err)
+		}
+		opts.Content = gofastly.String(content)
+	}
 	if v, ok := modified["content_type"]; ok {
 		opts.ContentType = gofastly.String(v.(string))
 	}
@@ -168,6 +202,52 @@ func (h *ResponseObjectServiceAttributeHandler) Update(_ context.Context, d *sch
 	return nil
 }
 
+// CustomizeDiff checks that each response object reads its content from a file only when it sets a checksum for it.
+func (h *ResponseObjectServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	responseObjects, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, v := range responseObjects.List() {
+		ro := v.(map[string]any)
+		content, path, sum := ro["content"].(string), ro["source_file"].(string), ro["source_sha256"].(string)
+		switch {
+		case content != "" && path != "":
+			invalid = append(invalid, fmt.Sprintf("%q sets both content and source_file", ro["name"]))
+		case path != "" && sum == "":
+			invalid = append(invalid, fmt.Sprintf("%q sets source_file without source_sha256", ro["name"]))
+		case path == "" && sum != "":
+			invalid = append(invalid, fmt.Sprintf("%q sets source_sha256 without source_file", ro["name"]))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// readResponseObjectSource returns the content of the file at path, provided it has the expected SHA-256 checksum.
+// Fastly delivers the content of a response object from a VCL string, so the content must be UTF-8 text.
+func readResponseObjectSource(path, expectedSHA256 string) (string, error) {
+	log.Printf("[DEBUG] Reading Response Object content from %s", path)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	content := string(b)
+
+	if sum := sha256Hex(content); !strings.EqualFold(sum, expectedSHA256) {
+		return "", fmt.Errorf("content of %s has SHA-256 checksum %s, expected %s", path, sum, expectedSHA256)
+	}
+	if !utf8.ValidString(content) {
+		return "", fmt.Errorf("%s isn't UTF-8 text. Binary content, e.g. an image, can be delivered by a snippet that sets synthetic.base64 to the content encoded with filebase64 instead", path)
+	}
+	return content, nil
+}
+
 // Delete deletes the resource.
 func (h *ResponseObjectServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteResponseObjectInput{
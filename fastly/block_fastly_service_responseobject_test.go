@@ -1,8 +1,12 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -51,6 +55,70 @@ func TestResourceFastlyFlattenResponseObjects(t *testing.T) {
 	}
 }
 
+func TestReadResponseObjectSource(t *testing.T) {
+	dir := t.TempDir()
+	content := "<html><body>Down for maintenance</body></html>\n"
+	page := filepath.Join(dir, "maintenance.html")
+	if err := os.WriteFile(page, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	image := filepath.Join(dir, "pixel.gif")
+	if err := os.WriteFile(image, []byte("GIF89a\x01\x00\x01\x00\x80\xff\x00"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readResponseObjectSource(page, strings.ToUpper(sha256Hex(content)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != content {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+
+	if _, err := readResponseObjectSource(page, sha256Hex("other")); err == nil || !strings.Contains(err.Error(), "expected "+sha256Hex("other")) {
+		t.Errorf("expected a checksum mismatch error, got %v", err)
+	}
+	if _, err := readResponseObjectSource(filepath.Join(dir, "missing.html"), sha256Hex(content)); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	b, _ := os.ReadFile(image)
+	if _, err := readResponseObjectSource(image, sha256Hex(string(b))); err == nil || !strings.Contains(err.Error(), "isn't UTF-8 text") {
+		t.Errorf("expected an error for binary content, got %v", err)
+	}
+}
+
+func TestResponseObjectSourceValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		responseObject map[string]any
+		expectError    string
+	}{
+		"content":            {responseObject: map[string]any{"content": "OK"}},
+		"no content":         {responseObject: map[string]any{}},
+		"source":             {responseObject: map[string]any{"source_file": "maintenance.html", "source_sha256": sha256Hex("")}},
+		"content and source": {responseObject: map[string]any{"content": "OK", "source_file": "maintenance.html", "source_sha256": sha256Hex("")}, expectError: `"maintenance" sets both content and source_file`},
+		"source without sum": {responseObject: map[string]any{"source_file": "maintenance.html"}, expectError: `"maintenance" sets source_file without source_sha256`},
+		"sum without source": {responseObject: map[string]any{"source_sha256": sha256Hex("")}, expectError: `"maintenance" sets source_sha256 without source_file`},
+	} {
+		responseObject := map[string]any{"name": "maintenance"}
+		for k, v := range testcase.responseObject {
+			responseObject[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":            "service",
+			"domain":          []any{map[string]any{"name": "example.com"}},
+			"response_object": []any{responseObject},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_response_object_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -30,6 +30,34 @@ func (h *SnippetServiceAttributeHandler) Key() string {
 	return h.key
 }
 
+// CustomizeDiff lints the content of each snippet, as the body of the subroutine its type places it in.
+func (h *SnippetServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	snippets, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, s := range snippets.List() {
+		snippet := s.(map[string]any)
+		content, _ := snippet["content"].(string)
+		stype, _ := snippet["type"].(string)
+
+		var subroutine string
+		if stype != "init" && stype != "none" {
+			subroutine = "vcl_" + stype
+		}
+		for _, problem := range lintVCL(content, subroutine) {
+			invalid = append(invalid, fmt.Sprintf("%q %s", snippet["name"], problem))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
 // GetSchema returns the resource schema.
 func (h *SnippetServiceAttributeHandler) GetSchema() *schema.Schema {
 	return &schema.Schema{
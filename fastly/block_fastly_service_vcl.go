@@ -2,17 +2,28 @@ package fastly
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"net/http"
+	"strings"
+	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// vclSourceRequestTimeout is the longest fetching the content of a VCL block from its source_url may take.
+const vclSourceRequestTimeout = 60 * time.Second
+
 // VCLServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
 type VCLServiceAttributeHandler struct {
 	*DefaultServiceAttributeHandler
+	client *http.Client
 }
 
 // NewServiceVCL returns a new resource.
@@ -22,6 +33,7 @@ func NewServiceVCL(sa ServiceMetadata) ServiceAttributeDefinition {
 			key:             "vcl",
 			serviceMetadata: sa,
 		},
+		&http.Client{Timeout: vclSourceRequestTimeout},
 	})
 }
 
@@ -39,8 +51,8 @@ func (h *VCLServiceAttributeHandler) GetSchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"content": {
 					Type:        schema.TypeString,
-					Required:    true,
-					Description: "The custom VCL code to upload",
+					Optional:    true,
+					Description: "The custom VCL code to upload. Exactly one of `content` or `source_url` must be set",
 				},
 				"main": {
 					Type:        schema.TypeBool,
@@ -53,25 +65,46 @@ func (h *VCLServiceAttributeHandler) GetSchema() *schema.Schema {
 					Required:    true,
 					Description: "A unique name for this configuration block. It is important to note that changing this attribute will delete and recreate the resource",
 				},
+				"source_sha256": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "The hex encoded SHA-256 checksum the content fetched from `source_url` must have. Required when `source_url` is set",
+					ValidateDiagFunc: validateSHA256(),
+				},
+				"source_url": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "An HTTP(S) URL to fetch the custom VCL code from when applying, instead of setting `content`. To use a file from a git repository, use the URL of the raw file at a tag or commit, e.g. `https://raw.githubusercontent.com/example/vcl/v1.2.0/main.vcl`",
+					ValidateDiagFunc: allowEmpty(validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS)),
+				},
 			},
 		},
 	}
 }
 
 // Create creates the resource.
-func (h *VCLServiceAttributeHandler) Create(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
+func (h *VCLServiceAttributeHandler) Create(ctx context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
+	content := resource["content"].(string)
+	if url := resource["source_url"].(string); url != "" {
+		var err error
+		if content, err = h.fetchSource(ctx, url, resource["source_sha256"].(string)); err != nil {
+			return fmt.Errorf("error fetching VCL (%s): %w", resource["name"], err)
+		}
+	}
+
 	opts := gofastly.CreateVCLInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
 		Name:           resource["name"].(string),
-		Content:        resource["content"].(string),
+		Content:        content,
 		Main:           resource["main"].(bool),
 	}
 
 	log.Printf("[DEBUG] Fastly VCL Addition opts: %#v", opts)
-	_, err := conn.CreateVCL(&opts)
+	uploadConn := uploadConnFromContext(ctx, conn)
+	_, err := uploadConn.CreateVCL(&opts)
 	if err != nil {
-		return err
+		return errorFromConn(uploadConn, err)
 	}
 	return nil
 }
@@ -91,6 +124,7 @@ func (h *VCLServiceAttributeHandler) Read(_ context.Context, d *schema.ResourceD
 		}
 
 		vl := flattenVCLs(vclList)
+		preserveContentSources(resources, vl, "source_url")
 
 		if err := d.Set(h.GetKey(), vl); err != nil {
 			log.Printf("[WARN] Error setting VCLs for (%s): %s", d.Id(), err)
@@ -101,7 +135,7 @@ func (h *VCLServiceAttributeHandler) Read(_ context.Context, d *schema.ResourceD
 }
 
 // Update updates the resource.
-func (h *VCLServiceAttributeHandler) Update(_ context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
+func (h *VCLServiceAttributeHandler) Update(ctx context.Context, d *schema.ResourceData, resource, modified map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.UpdateVCLInput{
 		ServiceID:      d.Id(),
 		ServiceVersion: serviceVersion,
@@ -111,15 +145,122 @@ func (h *VCLServiceAttributeHandler) Update(_ context.Context, d *schema.Resourc
 	if v, ok := modified["content"]; ok {
 		opts.Content = gofastly.String(v.(string))
 	}
+	_, urlModified := modified["source_url"]
+	_, sha256Modified := modified["source_sha256"]
+	if url := resource["source_url"].(string); url != "" && (urlModified || sha256Modified || opts.Content != nil) {
+		content, err := h.fetchSource(ctx, url, resource["source_sha256"].(string))
+		if err != nil {
+			return fmt.Errorf("error fetching VCL (%s): %w", resource["name"], err)
+		}
+		opts.Content = gofastly.String(content)
+	}
 
 	log.Printf("[DEBUG] Update VCL Opts: %#v", opts)
-	_, err := conn.UpdateVCL(&opts)
+	uploadConn := uploadConnFromContext(ctx, conn)
+	_, err := uploadConn.UpdateVCL(&opts)
 	if err != nil {
-		return err
+		return errorFromConn(uploadConn, err)
+	}
+	return nil
+}
+
+// CustomizeDiff checks that each VCL block sets its content in exactly one way, and lints content set in the
+// configuration.
+func (h *VCLServiceAttributeHandler) CustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	vcls, ok := d.Get(h.GetKey()).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, v := range vcls.List() {
+		vcl := v.(map[string]any)
+		content, url, sum := vcl["content"].(string), vcl["source_url"].(string), vcl["source_sha256"].(string)
+		switch {
+		case content != "" && url != "":
+			invalid = append(invalid, fmt.Sprintf("%q sets both content and source_url", vcl["name"]))
+		case content == "" && url == "":
+			invalid = append(invalid, fmt.Sprintf("%q sets neither content nor source_url", vcl["name"]))
+		case url != "" && sum == "":
+			invalid = append(invalid, fmt.Sprintf("%q sets source_url without source_sha256", vcl["name"]))
+		case url == "" && sum != "":
+			invalid = append(invalid, fmt.Sprintf("%q sets source_sha256 without source_url", vcl["name"]))
+		}
+		for _, problem := range lintVCL(content, "") {
+			invalid = append(invalid, fmt.Sprintf("%q %s", vcl["name"], problem))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.GetKey(), strings.Join(invalid, ", "))
 	}
 	return nil
 }
 
+// fetchSource returns the content at url, provided it has the expected SHA-256 checksum.
+func (h *VCLServiceAttributeHandler) fetchSource(ctx context.Context, url, expectedSHA256 string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[DEBUG] Fetching VCL from %s", url)
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %w", url, err)
+	}
+
+	if sum := sha256Hex(string(body)); !strings.EqualFold(sum, expectedSHA256) {
+		return "", fmt.Errorf("content of %s has SHA-256 checksum %s, expected %s", url, sum, expectedSHA256)
+	}
+	return string(body), nil
+}
+
+// preserveContentSources keeps sourceKey and source_sha256 from state for the blocks whose content is read from a
+// source when applying, such as a URL or a file.
+//
+// The content of those blocks isn't in the configuration, so it's removed from the refreshed state. If the content in
+// the service no longer has the checksum in state, source_sha256 is set to the checksum of that content instead, so
+// that the next plan updates the block.
+func preserveContentSources(state []any, elements []map[string]any, sourceKey string) {
+	sources := map[string]map[string]any{}
+	for _, s := range state {
+		element := s.(map[string]any)
+		if source, _ := element[sourceKey].(string); source != "" {
+			sources[element["name"].(string)] = element
+		}
+	}
+
+	for _, element := range elements {
+		source, ok := sources[element["name"].(string)]
+		if !ok {
+			continue
+		}
+		content, _ := element["content"].(string)
+		sum := source["source_sha256"].(string)
+		if actual := sha256Hex(content); !strings.EqualFold(actual, sum) {
+			sum = actual
+		}
+		delete(element, "content")
+		element[sourceKey] = source[sourceKey]
+		element["source_sha256"] = sum
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
 // Delete deletes the resource.
 func (h *VCLServiceAttributeHandler) Delete(_ context.Context, d *schema.ResourceData, resource map[string]any, serviceVersion int, conn *gofastly.Client) error {
 	opts := gofastly.DeleteVCLInput{
@@ -1,8 +1,12 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -42,6 +46,92 @@ func TestResourceFastlyFlattenVCLs(t *testing.T) {
 	}
 }
 
+func TestVCLFetchSource(t *testing.T) {
+	content := "sub vcl_recv {\n#FASTLY recv\n}\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/main.vcl" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	h := &VCLServiceAttributeHandler{client: server.Client()}
+
+	got, err := h.fetchSource(context.Background(), server.URL+"/main.vcl", strings.ToUpper(sha256Hex(content)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != content {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+
+	if _, err := h.fetchSource(context.Background(), server.URL+"/main.vcl", sha256Hex("other")); err == nil || !strings.Contains(err.Error(), "expected "+sha256Hex("other")) {
+		t.Errorf("expected a checksum mismatch error, got %v", err)
+	}
+	if _, err := h.fetchSource(context.Background(), server.URL+"/missing.vcl", sha256Hex(content)); err == nil || !strings.Contains(err.Error(), "returned status 404") {
+		t.Errorf("expected a status error, got %v", err)
+	}
+}
+
+func TestPreserveVCLSources(t *testing.T) {
+	content := "sub vcl_recv {}"
+	state := []any{
+		map[string]any{"name": "fetched", "content": "", "main": true, "source_url": "https://example.com/main.vcl", "source_sha256": sha256Hex(content)},
+		map[string]any{"name": "drifted", "content": "", "main": false, "source_url": "https://example.com/lib.vcl", "source_sha256": sha256Hex(content)},
+		map[string]any{"name": "inline", "content": content, "main": false, "source_url": "", "source_sha256": ""},
+	}
+	vl := []map[string]any{
+		{"name": "fetched", "content": content, "main": true},
+		{"name": "drifted", "content": "changed in the UI", "main": false},
+		{"name": "inline", "content": content, "main": false},
+	}
+
+	preserveContentSources(state, vl, "source_url")
+
+	expected := []map[string]any{
+		{"name": "fetched", "main": true, "source_url": "https://example.com/main.vcl", "source_sha256": sha256Hex(content)},
+		{"name": "drifted", "main": false, "source_url": "https://example.com/lib.vcl", "source_sha256": sha256Hex("changed in the UI")},
+		{"name": "inline", "content": content, "main": false},
+	}
+	if !reflect.DeepEqual(vl, expected) {
+		t.Errorf("Error matching:\nexpected: %#v\n     got: %#v", expected, vl)
+	}
+}
+
+func TestVCLSourceValidation(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		vcl         map[string]any
+		expectError string
+	}{
+		"content":            {vcl: map[string]any{"content": "sub vcl_recv {}"}},
+		"source":             {vcl: map[string]any{"source_url": "https://example.com/main.vcl", "source_sha256": sha256Hex("")}},
+		"content and source": {vcl: map[string]any{"content": "sub vcl_recv {}", "source_url": "https://example.com/main.vcl", "source_sha256": sha256Hex("")}, expectError: `"main" sets both content and source_url`},
+		"neither":            {vcl: map[string]any{}, expectError: `"main" sets neither content nor source_url`},
+		"source without sum": {vcl: map[string]any{"source_url": "https://example.com/main.vcl"}, expectError: `"main" sets source_url without source_sha256`},
+		"sum without source": {vcl: map[string]any{"content": "sub vcl_recv {}", "source_sha256": sha256Hex("")}, expectError: `"main" sets source_sha256 without source_url`},
+	} {
+		vcl := map[string]any{"name": "main", "main": true}
+		for k, v := range testcase.vcl {
+			vcl[k] = v
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"vcl":    []any{vcl},
+		})
+
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, config, nil)
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
 func TestAccFastlyServiceVCL_VCL_basic(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
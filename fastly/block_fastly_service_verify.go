@@ -0,0 +1,176 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// verifyRequestTimeout is the longest a single verification request may take.
+const verifyRequestTimeout = 10 * time.Second
+
+// VerifyServiceAttributeHandler provides a base implementation for ServiceAttributeDefinition.
+type VerifyServiceAttributeHandler struct {
+	client *http.Client
+}
+
+// NewServiceVerify returns a new resource.
+func NewServiceVerify() ServiceAttributeDefinition {
+	return &VerifyServiceAttributeHandler{
+		client: &http.Client{Timeout: verifyRequestTimeout},
+	}
+}
+
+// Process creates or updates the attribute against the Fastly API.
+//
+// Verification only happens after activation, so there is nothing to process.
+func (h *VerifyServiceAttributeHandler) Process(_ context.Context, _ *schema.ResourceData, _ int, _ *gofastly.Client) error {
+	return nil
+}
+
+// Read refreshes the attribute state against the Fastly API.
+//
+// The block only configures the provider, so there is nothing to read.
+func (h *VerifyServiceAttributeHandler) Read(_ context.Context, _ *schema.ResourceData, _ *gofastly.ServiceDetail, _ *gofastly.Client) error {
+	return nil
+}
+
+// HasChange returns whether the state of the attribute has changed against Terraform stored state.
+//
+// The block doesn't change the service, so never requires a new version.
+func (h *VerifyServiceAttributeHandler) HasChange(_ *schema.ResourceData) bool {
+	return false
+}
+
+// MustProcess returns whether we must process the resource.
+func (h *VerifyServiceAttributeHandler) MustProcess(d *schema.ResourceData, _ bool) bool {
+	return h.HasChange(d)
+}
+
+// AfterActivation requests the verify URL until it returns the expected status. If it doesn't within the timeout, the
+// apply fails and, when rollback is set, the previously active version is activated again.
+func (h *VerifyServiceAttributeHandler) AfterActivation(ctx context.Context, d *schema.ResourceData, activatedVersion int, conn *gofastly.Client) error {
+	if _, ok := d.GetOk("verify"); !ok {
+		return nil
+	}
+
+	url := d.Get("verify.0.url").(string)
+	if url == "" {
+		url = defaultVerifyURL(d)
+	}
+	if url == "" {
+		return fmt.Errorf("error verifying Fastly Service (%s), version (%d): no url set and the service has no domains", d.Id(), activatedVersion)
+	}
+	expectedStatus := d.Get("verify.0.expected_status").(int)
+	timeout := time.Duration(d.Get("verify.0.timeout").(int)) * time.Second
+
+	log.Printf("[DEBUG] Verifying Fastly Service (%s), version (%d), responds to %s with status %d", d.Id(), activatedVersion, url, expectedStatus)
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return resource.RetryableError(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != expectedStatus {
+			return resource.RetryableError(fmt.Errorf("%s returned status %d, expected %d", url, resp.StatusCode, expectedStatus))
+		}
+		return nil
+	})
+	if err == nil {
+		return nil
+	}
+	err = fmt.Errorf("error verifying Fastly Service (%s), version (%d): %s", d.Id(), activatedVersion, err)
+
+	if !d.Get("verify.0.rollback").(bool) {
+		return err
+	}
+	previousVersion, _ := d.GetChange("active_version")
+	if previousVersion.(int) == 0 {
+		return fmt.Errorf("%s; there is no previously active version to roll back to", err)
+	}
+
+	log.Printf("[INFO] Rolling back Fastly Service (%s) to version (%d)", d.Id(), previousVersion)
+	_, rerr := conn.ActivateVersion(&gofastly.ActivateVersionInput{
+		ServiceID:      d.Id(),
+		ServiceVersion: previousVersion.(int),
+	})
+	if rerr != nil {
+		return fmt.Errorf("%s; error rolling back to version (%d): %s", err, previousVersion, rerr)
+	}
+	// The failed version must not be cloned or activated again by the next apply, so the state is pointed back at the
+	// version that is active again.
+	for _, k := range []string{"active_version", "cloned_version"} {
+		if serr := d.Set(k, previousVersion); serr != nil {
+			return serr
+		}
+	}
+	return fmt.Errorf("%s; rolled back to version (%d)", err, previousVersion)
+}
+
+// defaultVerifyURL returns the root URL of the service's first domain, in alphabetical order, or an empty string if
+// the service has no domains.
+func defaultVerifyURL(d *schema.ResourceData) string {
+	var names []string
+	if domains, ok := d.GetOk("domain"); ok {
+		for _, domain := range domains.(*schema.Set).List() {
+			names = append(names, domain.(map[string]any)["name"].(string))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return "https://" + names[0] + "/"
+}
+
+// Register add the attribute to the resource schema.
+func (h *VerifyServiceAttributeHandler) Register(s *schema.Resource) error {
+	s.Schema["verify"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Checks that the service responds correctly after a new version is activated. The apply fails if it doesn't",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The URL to request. Defaults to the root of the service's first domain, in alphabetical order, over HTTPS",
+				},
+				"expected_status": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          200,
+					Description:      "The HTTP status code the URL must return. Default `200`",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(100, 599)),
+				},
+				"timeout": {
+					Type:             schema.TypeInt,
+					Optional:         true,
+					Default:          60,
+					Description:      "How long to keep requesting the URL until it returns `expected_status`, in seconds. Default `60`",
+					ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				},
+				"rollback": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Whether to activate the previously active version again if the check fails. Default `false`",
+				},
+			},
+		},
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package fastly
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestVerifyAfterActivation(t *testing.T) {
+	requests := 0
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Path == "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case requests == 1:
+			// The edge is still serving an error from the previous version the first time the URL is requested.
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer site.Close()
+
+	var activated string
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/activate") {
+			activated = r.URL.Path
+			w.Write([]byte(`{"service_id": "service-id", "number": 1, "active": true}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})).conn
+
+	for name, testcase := range map[string]struct {
+		path          string
+		rollback      bool
+		expectError   string
+		expectRequest string
+	}{
+		"succeeds after retrying": {
+			path: "/",
+		},
+		"fails": {
+			path:        "/broken",
+			expectError: "returned status 500, expected 200",
+		},
+		"rolls back": {
+			path:          "/broken",
+			rollback:      true,
+			expectError:   "rolled back to version (1)",
+			expectRequest: "/service/service-id/version/1/activate",
+		},
+	} {
+		requests = 0
+		activated = ""
+
+		r := resourceServiceVCL()
+		d := r.Data(nil)
+		d.SetId("service-id")
+		for k, s := range r.Schema {
+			if s.Default != nil {
+				if err := d.Set(k, s.Default); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		verify := map[string]any{
+			"url":             site.URL + testcase.path,
+			"expected_status": 200,
+			"timeout":         2,
+			"rollback":        testcase.rollback,
+		}
+		for k, v := range map[string]any{
+			"name":           "service",
+			"domain":         []map[string]any{{"name": "example.com", "comment": ""}},
+			"verify":         []any{verify},
+			"active_version": 1,
+			"cloned_version": 1,
+			"latest_version": 2,
+		} {
+			if err := d.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// Apply the activation of version 2 on top of the state where version 1 is active, as the update does.
+		d = r.Data(d.State())
+		for k, v := range map[string]any{"active_version": 2, "cloned_version": 2} {
+			if err := d.Set(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		h := &VerifyServiceAttributeHandler{client: site.Client()}
+		err := h.AfterActivation(context.Background(), d, 2, conn)
+		if testcase.expectError == "" && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+		if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+			t.Errorf("%s: expected error containing %q, got %v", name, testcase.expectError, err)
+		}
+		if activated != testcase.expectRequest {
+			t.Errorf("%s: expected activation request %q, got %q", name, testcase.expectRequest, activated)
+		}
+		if !testcase.rollback {
+			continue
+		}
+		for _, k := range []string{"active_version", "cloned_version"} {
+			if v := d.Get(k).(int); v != 1 {
+				t.Errorf("%s: expected %s to be rolled back to 1, got %d", name, k, v)
+			}
+		}
+
+		// The next plan mustn't activate the version that failed verification again.
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"verify": []any{verify},
+		})
+		diff, err := r.Diff(context.Background(), d.State(), config, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if diff != nil {
+			for _, k := range []string{"active_version", "cloned_version"} {
+				if a := diff.Attributes[k]; a != nil {
+					t.Errorf("%s: expected %s not to change after the rollback, got %#v", name, k, a)
+				}
+			}
+		}
+	}
+}
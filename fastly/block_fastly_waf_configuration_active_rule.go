@@ -1,13 +1,21 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// latestRuleRevision is the revision of a rule that tracks the latest revision of the rule.
+const latestRuleRevision = "latest"
+
 var activeRule = &schema.Schema{
 	Type:     schema.TypeSet,
 	Optional: true,
@@ -19,10 +27,11 @@ var activeRule = &schema.Schema{
 				Description: "The Web Application Firewall rule's modsecurity ID",
 			},
 			"revision": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Computed:    true,
-				Description: "The Web Application Firewall rule's revision. The latest revision will be used if this is not provided",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The Web Application Firewall rule's revision. The latest revision will be used if this is not provided. Set to `latest` to track the latest revision: the rule is updated whenever a newer revision is published, and `latest_rule_revisions` shows the revision in use",
+				ValidateDiagFunc: validateWAFRuleRevision(),
 			},
 			"status": {
 				Type:             schema.TypeString,
@@ -34,8 +43,8 @@ var activeRule = &schema.Schema{
 	},
 }
 
-func updateRules(d *schema.ResourceData, meta any, wafID string, number int) error {
-	conn := meta.(*APIClient).conn
+func updateRules(ctx context.Context, d *schema.ResourceData, meta any, wafID string, number int) error {
+	conn := meta.(*APIClient).connWithContext(ctx)
 	os, ns := d.GetChange("rule")
 
 	if os == nil {
@@ -61,6 +70,7 @@ func updateRules(d *schema.ResourceData, meta any, wafID string, number int) err
 	if err != nil {
 		return err
 	}
+	diffResult.Modified = append(diffResult.Modified, outdatedLatestRules(d, newSet, diffResult)...)
 
 	// NOTE: Fastly WAF (WAF 2020) API doesn't have a proper batch update endpoint.
 	// go-fastly uses the below endpoint for UpsertBatchOperation:
@@ -98,8 +108,8 @@ func updateRules(d *schema.ResourceData, meta any, wafID string, number int) err
 	return nil
 }
 
-func readWAFRules(meta any, d *schema.ResourceData, v int) error {
-	conn := meta.(*APIClient).conn
+func readWAFRules(ctx context.Context, meta any, d *schema.ResourceData, v int) error {
+	conn := meta.(*APIClient).connWithContext(ctx)
 	wafID := d.Get("waf_id").(string)
 
 	log.Printf("[INFO] retrieving active rules for WAF: %s", wafID)
@@ -113,20 +123,142 @@ func readWAFRules(meta any, d *schema.ResourceData, v int) error {
 
 	rules := flattenWAFActiveRules(resp.Items)
 
+	// Rules configured to track the latest revision keep "latest" as their revision, and the revision in use is
+	// recorded in latest_rule_revisions, so that a newer revision shows up in the plan.
+	tracking := latestRuleIDs(d.Get("rule").(*schema.Set))
+	latestRevisions := make(map[string]any)
+	for _, r := range rules {
+		id := r["modsec_rule_id"].(int)
+		if tracking[id] {
+			latestRevisions[strconv.Itoa(id)], _ = strconv.Atoi(r["revision"].(string))
+			r["revision"] = latestRuleRevision
+		}
+	}
+
 	if err := d.Set("rule", rules); err != nil {
 		log.Printf("[WARN] Error setting WAF rules for (%s): %s", d.Id(), err)
 	}
+	if err := d.Set("latest_rule_revisions", latestRevisions); err != nil {
+		log.Printf("[WARN] Error setting latest WAF rule revisions for (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+// latestRuleIDs returns the modsecurity IDs of the rules in rules that track the latest revision.
+func latestRuleIDs(rules *schema.Set) map[int]bool {
+	ids := make(map[int]bool)
+	for _, r := range rules.List() {
+		rf := r.(map[string]any)
+		if id := rf["modsec_rule_id"].(int); id != 0 && rf["revision"].(string) == latestRuleRevision {
+			ids[id] = true
+		}
+	}
+	return ids
+}
+
+// outdatedLatestRules returns the rules in newSet that track the latest revision and that a newer revision has been
+// published for, according to latest_rule_revisions, unless they are already added or modified by diffResult.
+func outdatedLatestRules(d *schema.ResourceData, newSet *schema.Set, diffResult *DiffResult) []any {
+	o, n := d.GetChange("latest_rule_revisions")
+	oldRevisions, newRevisions := o.(map[string]any), n.(map[string]any)
+
+	changing := make(map[int]bool)
+	for _, r := range append(append([]any{}, diffResult.Added...), diffResult.Modified...) {
+		changing[r.(map[string]any)["modsec_rule_id"].(int)] = true
+	}
+
+	var outdated []any
+	for _, r := range newSet.List() {
+		rf := r.(map[string]any)
+		id := rf["modsec_rule_id"].(int)
+		if rf["revision"].(string) != latestRuleRevision || changing[id] {
+			continue
+		}
+		revision, ok := newRevisions[strconv.Itoa(id)]
+		if ok && oldRevisions[strconv.Itoa(id)] != revision {
+			log.Printf("[DEBUG] WAF rule %d is updated to its latest revision %v", id, revision)
+			outdated = append(outdated, r)
+		}
+	}
+	return outdated
+}
+
+// customizeLatestRuleRevisions looks up the latest revisions of the rules that track the latest revision, so that the
+// plan shows the revisions they will use in latest_rule_revisions, and updates them when a newer revision is published.
+func customizeLatestRuleRevisions(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if !d.NewValueKnown("rule") {
+		return d.SetNewComputed("latest_rule_revisions")
+	}
+	ids := latestRuleIDs(d.Get("rule").(*schema.Set))
+	if len(ids) == 0 {
+		if len(d.Get("latest_rule_revisions").(map[string]any)) > 0 {
+			return d.SetNew("latest_rule_revisions", map[string]any{})
+		}
+		return nil
+	}
+
+	client, ok := meta.(*APIClient)
+	if !ok || client.offline {
+		if d.HasChange("rule") {
+			return d.SetNewComputed("latest_rule_revisions")
+		}
+		return nil
+	}
+
+	input := &gofastly.ListAllWAFRulesInput{
+		Include: "waf_rule_revisions",
+	}
+	for id := range ids {
+		input.FilterModSecIDs = append(input.FilterModSecIDs, id)
+	}
+	sort.Ints(input.FilterModSecIDs)
+	log.Printf("[DEBUG] Looking up the latest revisions of %d WAF rules", len(input.FilterModSecIDs))
+	resp, err := client.connWithContext(ctx).ListAllWAFRules(input)
+	if err != nil {
+		return fmt.Errorf("error looking up the latest revisions of WAF rules: %s", err)
+	}
+
+	latestRevisions, err := latestWAFRuleRevisions(input.FilterModSecIDs, resp.Items)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(d.Get("latest_rule_revisions").(map[string]any), latestRevisions) {
+		return d.SetNew("latest_rule_revisions", latestRevisions)
+	}
 	return nil
 }
 
+// latestWAFRuleRevisions returns the latest revision of each rule with a modsecurity ID in ids, keyed by the ID.
+func latestWAFRuleRevisions(ids []int, rules []*gofastly.WAFRule) (map[string]any, error) {
+	latestRevisions := make(map[string]any)
+	for _, r := range rules {
+		if latest, err := determineLatestRuleRevision(r.Revisions); err == nil {
+			latestRevisions[strconv.Itoa(r.ModSecID)] = latest.Revision
+		}
+	}
+
+	var missing []string
+	for _, id := range ids {
+		if _, ok := latestRevisions[strconv.Itoa(id)]; !ok {
+			missing = append(missing, strconv.Itoa(id))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no revisions were found for the WAF rules %s, which have their revision set to %q", strings.Join(missing, ", "), latestRuleRevision)
+	}
+	return latestRevisions, nil
+}
+
 func buildBatchCreateWAFActiveRulesInput(items []any, wafID string, wafVersionNumber int) gofastly.BatchModificationWAFActiveRulesInput {
 	rules := make([]*gofastly.WAFActiveRule, len(items))
 	for i, rRaw := range items {
 		rf := rRaw.(map[string]any)
 
+		// The API uses the latest revision when the revision is omitted.
+		revision, _ := strconv.Atoi(rf["revision"].(string))
 		rules[i] = &gofastly.WAFActiveRule{
 			ModSecID: rf["modsec_rule_id"].(int),
-			Revision: rf["revision"].(int),
+			Revision: revision,
 			Status:   rf["status"].(string),
 		}
 	}
@@ -186,7 +318,7 @@ func flattenWAFActiveRules(rules []*gofastly.WAFActiveRule) []map[string]any {
 	for i, r := range rules {
 		ruleMapString := map[string]any{
 			"modsec_rule_id": r.ModSecID,
-			"revision":       r.Revision,
+			"revision":       strconv.Itoa(r.Revision),
 			"status":         r.Status,
 		}
 
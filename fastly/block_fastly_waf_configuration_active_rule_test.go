@@ -1,7 +1,9 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"reflect"
 	"sort"
 	"testing"
@@ -28,7 +30,7 @@ func TestAccFastlyServiceWAFVersionV1_FlattenWAFActiveRules(t *testing.T) {
 			local: []map[string]any{
 				{
 					"modsec_rule_id": 1110111,
-					"revision":       1,
+					"revision":       "1",
 					"status":         "log",
 				},
 			},
@@ -42,6 +44,55 @@ func TestAccFastlyServiceWAFVersionV1_FlattenWAFActiveRules(t *testing.T) {
 	}
 }
 
+func TestLatestRuleRevisionsDiff(t *testing.T) {
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if expected, actual := "1010090,2029718", r.URL.Query().Get("filter[modsec_rule_id][in]"); actual != expected {
+			t.Errorf("expected the rules %s to be looked up, got %s", expected, actual)
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{
+			"data": [
+				{"type": "waf_rule", "id": "a", "attributes": {"modsec_rule_id": 1010090}, "relationships": {"waf_rule_revisions": {"data": [{"type": "waf_rule_revision", "id": "a1"}, {"type": "waf_rule_revision", "id": "a3"}]}}},
+				{"type": "waf_rule", "id": "b", "attributes": {"modsec_rule_id": 2029718}, "relationships": {"waf_rule_revisions": {"data": [{"type": "waf_rule_revision", "id": "b1"}]}}}
+			],
+			"included": [
+				{"type": "waf_rule_revision", "id": "a1", "attributes": {"revision": 1}},
+				{"type": "waf_rule_revision", "id": "a3", "attributes": {"revision": 3}},
+				{"type": "waf_rule_revision", "id": "b1", "attributes": {"revision": 1}}
+			],
+			"links": {}
+		}`)
+	}))
+
+	diff, err := resourceServiceWAFConfiguration().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]any{
+		"waf_id": "waf-id",
+		"rule": []any{
+			map[string]any{"modsec_rule_id": 1010090, "revision": "latest", "status": "log"},
+			map[string]any{"modsec_rule_id": 2029718, "revision": "latest", "status": "block"},
+			map[string]any{"modsec_rule_id": 910100, "revision": "2", "status": "score"},
+		},
+	}), meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for key, expected := range map[string]string{
+		"latest_rule_revisions.%":       "2",
+		"latest_rule_revisions.1010090": "3",
+		"latest_rule_revisions.2029718": "1",
+	} {
+		if attr, ok := diff.Attributes[key]; !ok || attr.New != expected {
+			t.Errorf("expected %s to be planned as %q, got %#v", key, expected, attr)
+		}
+	}
+
+	_, err = latestWAFRuleRevisions([]int{1010090, 2029718}, []*gofastly.WAFRule{
+		{ModSecID: 1010090, Revisions: []*gofastly.WAFRuleRevision{{Revision: 1}}},
+	})
+	if expected := `no revisions were found for the WAF rules 2029718, which have their revision set to "latest"`; err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+}
+
 func TestAccFastlyServiceWAFVersionV1_AddUpdateDeleteRules(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -1,6 +1,7 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
@@ -46,8 +47,8 @@ var wafRuleExclusion = &schema.Schema{
 	},
 }
 
-func readWAFRuleExclusions(meta any, d *schema.ResourceData, wafVersionNumber int) error {
-	conn := meta.(*APIClient).conn
+func readWAFRuleExclusions(ctx context.Context, meta any, d *schema.ResourceData, wafVersionNumber int) error {
+	conn := meta.(*APIClient).connWithContext(ctx)
 	wafID := d.Get("waf_id").(string)
 
 	resp, e := conn.ListAllWAFRuleExclusions(&gofastly.ListAllWAFRuleExclusionsInput{
@@ -99,7 +100,7 @@ func flattenWAFRuleExclusions(exclusions []*gofastly.WAFRuleExclusion) []map[str
 	return result
 }
 
-func updateWAFRuleExclusions(d *schema.ResourceData, meta any, wafID string, wafVersionNumber int) error {
+func updateWAFRuleExclusions(ctx context.Context, d *schema.ResourceData, meta any, wafID string, wafVersionNumber int) error {
 	os, ns := d.GetChange("rule_exclusion")
 
 	if os == nil {
@@ -117,12 +118,12 @@ func updateWAFRuleExclusions(d *schema.ResourceData, meta any, wafID string, waf
 
 	var err error
 
-	err = deleteWAFRuleExclusion(remove, meta, wafID, wafVersionNumber)
+	err = deleteWAFRuleExclusion(ctx, remove, meta, wafID, wafVersionNumber)
 	if err != nil {
 		return err
 	}
 
-	err = createWAFRuleExclusion(add, meta, wafID, wafVersionNumber)
+	err = createWAFRuleExclusion(ctx, add, meta, wafID, wafVersionNumber)
 	if err != nil {
 		return err
 	}
@@ -130,8 +131,8 @@ func updateWAFRuleExclusions(d *schema.ResourceData, meta any, wafID string, waf
 	return nil
 }
 
-func deleteWAFRuleExclusion(remove []any, meta any, wafID string, wafVersionNumber int) error {
-	conn := meta.(*APIClient).conn
+func deleteWAFRuleExclusion(ctx context.Context, remove []any, meta any, wafID string, wafVersionNumber int) error {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	for _, aRaw := range remove {
 		a := aRaw.(map[string]any)
@@ -149,8 +150,8 @@ func deleteWAFRuleExclusion(remove []any, meta any, wafID string, wafVersionNumb
 	return nil
 }
 
-func createWAFRuleExclusion(add []any, meta any, wafID string, wafVersionNumber int) error {
-	conn := meta.(*APIClient).conn
+func createWAFRuleExclusion(ctx context.Context, add []any, meta any, wafID string, wafVersionNumber int) error {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	for _, aRaw := range add {
 		a := aRaw.(map[string]any)
@@ -0,0 +1,54 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// cloneServiceBlocks copies the blocks of the service version in clone_from into d, for each type of block the
+// configuration doesn't have, so that creating the service creates them in its first version. The types that are
+// copied are recorded in cloned_blocks.
+//
+// The API can only clone a version within its own service, so the blocks are read from the other service and created
+// like configured blocks are.
+func cloneServiceBlocks(ctx context.Context, d *schema.ResourceData, conn *gofastly.Client, handlers []ServiceAttributeDefinition) error {
+	v, ok := d.GetOk("clone_from")
+	if !ok {
+		return nil
+	}
+	from := v.([]any)[0].(map[string]any)
+	s := &gofastly.ServiceDetail{
+		ID:            from["service_id"].(string),
+		ActiveVersion: gofastly.Version{Number: from["version"].(int)},
+	}
+	log.Printf("[DEBUG] Copying the blocks of Fastly Service (%s), version (%d) into Fastly Service (%s)", s.ID, s.ActiveVersion.Number, d.Id())
+
+	cloned := []string{}
+	for _, a := range handlers {
+		h, ok := a.(*blockSetAttributeHandler)
+		if !ok {
+			continue
+		}
+		key := h.GetKey()
+		if set, ok := d.Get(key).(*schema.Set); !ok || set.Len() > 0 {
+			continue
+		}
+
+		blocks, err := readDetachedBlocks(ctx, h, s, conn)
+		if err != nil {
+			return fmt.Errorf("error copying the %s blocks of Fastly Service (%s), version (%d): %s", key, s.ID, s.ActiveVersion.Number, err)
+		}
+		if blocks.Len() == 0 {
+			continue
+		}
+		if err := d.Set(key, blocks); err != nil {
+			return err
+		}
+		cloned = append(cloned, key)
+	}
+	return d.Set("cloned_blocks", cloned)
+}
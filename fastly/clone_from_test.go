@@ -0,0 +1,68 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCloneServiceBlocks(t *testing.T) {
+	var requested []string
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := "/service/source-id/version/2/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		endpoint := strings.TrimPrefix(r.URL.Path, prefix)
+		requested = append(requested, endpoint)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch endpoint {
+		case "backend":
+			fmt.Fprint(w, `[{"name": "origin", "address": "origin.example.com", "port": 443}]`)
+		case "header":
+			fmt.Fprint(w, `[{"name": "production header", "action": "set", "type": "response", "dst": "http.X-Production"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})).conn
+
+	d := schema.TestResourceDataRaw(t, resourceServiceVCL().Schema, map[string]any{
+		"name":       "staging",
+		"domain":     []any{map[string]any{"name": "staging.example.com"}},
+		"clone_from": []any{map[string]any{"service_id": "source-id", "version": 2}},
+		"header": []any{map[string]any{
+			"name":        "staging header",
+			"action":      "set",
+			"type":        "response",
+			"destination": "http.X-Staging",
+		}},
+	})
+	d.SetId("service-id")
+
+	if err := cloneServiceBlocks(context.Background(), d, conn, vclService.GetAttributeHandler()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if expected, actual := []any{"backend"}, d.Get("cloned_blocks"); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected cloned_blocks %v, got %v", expected, actual)
+	}
+	backends := d.Get("backend").(*schema.Set).List()
+	if len(backends) != 1 || backends[0].(map[string]any)["address"] != "origin.example.com" {
+		t.Errorf("expected the backend of the source service, got %v", backends)
+	}
+	headers := d.Get("header").(*schema.Set).List()
+	if len(headers) != 1 || headers[0].(map[string]any)["name"] != "staging header" {
+		t.Errorf("expected the configured header, got %v", headers)
+	}
+	for _, endpoint := range requested {
+		if endpoint == "header" || endpoint == "domain" {
+			t.Errorf("expected the configured %s blocks not to be read", endpoint)
+		}
+	}
+}
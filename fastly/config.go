@@ -1,9 +1,12 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -16,23 +19,68 @@ import (
 //
 // NOTE: The fields correlate to the root TCL schema.
 type Config struct {
-	APIKey     string
-	BaseURL    string
-	UserAgent  string
-	NoAuth     bool
-	ForceHTTP2 bool
+	APIKey            string
+	APIKeySecondary   string
+	AuditLogPath      string
+	BaseURL           string
+	UserAgent         string
+	NoAuth            bool
+	Offline           bool
+	ForceHTTP2        bool
+	ProxyURL          string
+	ForbidNewVersions bool
+	MaxRetries        int
+	RetryPOSTs        bool
+	RequestTimeout    time.Duration
+	UploadTimeout     time.Duration
+	ValidateShields   bool
 }
 
 // APIClient is a HTTP API Client.
 type APIClient struct {
 	conn *gofastly.Client
+
+	// apiKey is used to create the clients returned by connWithContext.
+	apiKey string
+
+	// forbidNewVersions causes service updates that would clone or activate a
+	// version to fail instead.
+	forbidNewVersions bool
+
+	// offline causes resources to keep their state instead of being
+	// refreshed, and to fail instead of being changed, so that configurations
+	// can be validated and planned without credentials.
+	offline bool
+
+	// validateShields causes the shields of backends and directors to be
+	// checked against the datacenters API when planning.
+	validateShields bool
+
+	// shields are the valid shield codes, fetched by shieldCodes the first
+	// time the datacenters API can be reached.
+	shieldsMu sync.Mutex
+	shields   []string
+
+	// uploadConn is used to upload Compute packages and custom VCL, which can
+	// take longer than other API requests.
+	uploadConn *gofastly.Client
+
+	// registryClient is used to pull Compute packages from OCI registries. It
+	// uses the proxy of the API clients and the timeout of uploads, but not the
+	// transports that only apply to the Fastly API, e.g. its retries.
+	registryClient *http.Client
+
+	// updateLocks holds a *sync.Mutex for each of the API clients above, which
+	// serializes the mutating requests made with the copies of the client
+	// returned by clientWithContext, as go-fastly does for the client itself.
+	updateLocks sync.Map
 }
 
 // Client returns a FastlyClient.
 func (c *Config) Client() (*APIClient, diag.Diagnostics) {
 	var client APIClient
 
-	if !c.NoAuth && c.APIKey == "" {
+	if !c.NoAuth && !c.Offline && c.APIKey == "" {
 		return nil, diag.FromErr(fmt.Errorf("no API key for Fastly"))
 	}
 
@@ -56,8 +104,22 @@ func (c *Config) Client() (*APIClient, diag.Diagnostics) {
 	// by each resource will start TLS handshake regardless of the existing connection pool status.
 	// explicitly assigning http2.Transport so there will be just one TLS-ALPN negotiation happening
 	// (across all Fastly provider resources) against the same api.fastly.com:443 destination.
+	proxy := http.ProxyFromEnvironment
+	if c.ProxyURL != "" {
+		if c.ForceHTTP2 {
+			return nil, diag.Errorf("proxy_url can't be used with force_http2")
+		}
+		// Credentials in the URL are sent to the proxy in the Proxy-Authorization
+		// header by http.Transport.
+		proxyURL, err := url.Parse(c.ProxyURL)
+		if err != nil {
+			return nil, diag.Errorf("invalid proxy_url: %s", err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
 	httpDefaultTransport := &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
+		Proxy: proxy,
 		DialContext: (&net.Dialer{
 			Timeout:   30 * time.Second,
 			KeepAlive: 30 * time.Second,
@@ -79,6 +141,115 @@ func (c *Config) Client() (*APIClient, diag.Diagnostics) {
 		fastlyClient.HTTPClient.Transport = logging.NewTransport("Fastly", httpDefaultTransport)
 	}
 
+	if c.MaxRetries > 0 {
+		fastlyClient.HTTPClient.Transport = &retryTransport{base: fastlyClient.HTTPClient.Transport, maxRetries: c.MaxRetries, retryPOSTs: c.RetryPOSTs}
+	}
+
+	if c.APIKeySecondary != "" && !c.NoAuth {
+		fastlyClient.HTTPClient.Transport = &tokenFallbackTransport{base: fastlyClient.HTTPClient.Transport, secondary: c.APIKeySecondary}
+	}
+
+	if c.AuditLogPath != "" {
+		auditLog, err := openAuditLog(c.AuditLogPath)
+		if err != nil {
+			return nil, diag.Errorf("error opening audit_log: %s", err)
+		}
+		fastlyClient.HTTPClient.Transport = &auditTransport{base: fastlyClient.HTTPClient.Transport, log: auditLog}
+	}
+
+	if c.Offline {
+		fastlyClient.HTTPClient.Transport = offlineTransport{}
+	}
+
+	fastlyClient.HTTPClient.Timeout = c.RequestTimeout
+
 	client.conn = fastlyClient
+	client.apiKey = c.APIKey
+	client.forbidNewVersions = c.ForbidNewVersions
+	client.offline = c.Offline
+	client.validateShields = c.ValidateShields
+
+	uploadClient, err := gofastly.NewClientForEndpoint(c.APIKey, c.BaseURL)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	// Share the transport so that uploads use the same connection pool.
+	uploadClient.HTTPClient = &http.Client{
+		Transport: fastlyClient.HTTPClient.Transport,
+		Timeout:   c.UploadTimeout,
+	}
+	client.uploadConn = uploadClient
+
+	registryTransport := http.RoundTripper(logging.NewTransport("OCI", httpDefaultTransport))
+	if c.Offline {
+		registryTransport = offlineTransport{}
+	}
+	client.registryClient = &http.Client{
+		Transport: registryTransport,
+		Timeout:   c.UploadTimeout,
+	}
 	return &client, nil
 }
+
+// connWithContext returns a client for the Fastly API whose requests are
+// cancelled when ctx is done, e.g. when Terraform is interrupted or an
+// operation times out.
+func (c *APIClient) connWithContext(ctx context.Context) *gofastly.Client {
+	return c.clientWithContext(ctx, c.conn)
+}
+
+// uploadConnWithContext returns the uploadConn, but with requests that are
+// cancelled when ctx is done.
+func (c *APIClient) uploadConnWithContext(ctx context.Context) *gofastly.Client {
+	return c.clientWithContext(ctx, c.uploadConn)
+}
+
+// clientWithContext returns a copy of a client that makes its requests with
+// ctx, sharing its connection pool. go-fastly doesn't accept a context for its
+// requests, so it is added by the transport.
+//
+// go-fastly serializes mutating requests per client, and the copy is a client
+// of its own, so the transport takes the lock of conn instead. Resources that
+// change the same service, e.g. dictionary items and ACL entries, are created
+// in parallel by Terraform, and the API can reject concurrent changes.
+func (c *APIClient) clientWithContext(ctx context.Context, conn *gofastly.Client) *gofastly.Client {
+	client, err := gofastly.NewClientForEndpoint(c.apiKey, conn.Address)
+	if err != nil {
+		// The address has already been parsed successfully by conn.
+		return conn
+	}
+	transport := conn.HTTPClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.HTTPClient = &http.Client{
+		Transport: &contextTransport{ctx: ctx, base: transport, updateLock: c.updateLock(conn)},
+		Timeout:   conn.HTTPClient.Timeout,
+	}
+	return client
+}
+
+// updateLock returns the lock that serializes the mutating requests made with
+// copies of conn.
+func (c *APIClient) updateLock(conn *gofastly.Client) *sync.Mutex {
+	lock, _ := c.updateLocks.LoadOrStore(conn, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// contextTransport is a http.RoundTripper that makes requests with a context,
+// so that they are cancelled when it is done. Requests other than GET and HEAD
+// requests hold updateLock until the response is received.
+type contextTransport struct {
+	ctx        context.Context
+	base       http.RoundTripper
+	updateLock *sync.Mutex
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.updateLock != nil && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		t.updateLock.Lock()
+		defer t.updateLock.Unlock()
+	}
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
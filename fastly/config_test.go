@@ -1,8 +1,19 @@
 package fastly
 
 import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
 )
 
 func TestUserAgentContainsProviderVersion(t *testing.T) {
@@ -43,3 +54,229 @@ func TestForceHttp2(t *testing.T) {
 		t.Errorf("failed to create client with force_http2: %#v, %#v", ts1, ts2)
 	}
 }
+
+func TestForbidNewVersions(t *testing.T) {
+	c := Config{
+		APIKey:            "someapikey",
+		BaseURL:           "http://localhost",
+		ForbidNewVersions: true,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	if !client.forbidNewVersions {
+		t.Errorf("expected forbid_new_versions to be passed to the API client")
+	}
+}
+
+func TestConnWithContext(t *testing.T) {
+	requests := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requests)
+		// Block until the client gives up on the request.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := Config{
+		APIKey:  "someapikey",
+		BaseURL: server.URL,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requests
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() {
+		_, err := client.connWithContext(ctx).GetService(&gofastly.GetServiceInput{ID: "service-id"})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected the request to be cancelled, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the request to be cancelled with its context")
+	}
+}
+
+func TestConnWithContextSerializesUpdates(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(50 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id": "service-id"}`)
+	}))
+	defer server.Close()
+
+	c := Config{
+		APIKey:  "someapikey",
+		BaseURL: server.URL,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each resource gets a client for the context of its own operation.
+			conn := client.connWithContext(context.Background())
+			if _, err := conn.UpdateService(&gofastly.UpdateServiceInput{ServiceID: "service-id", Name: gofastly.String("example")}); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight != 1 {
+		t.Errorf("expected updates made with the same client to be serialized, got %d at once", maxInFlight)
+	}
+}
+
+func TestTimeouts(t *testing.T) {
+	c := Config{
+		APIKey:         "someapikey",
+		BaseURL:        "http://localhost",
+		RequestTimeout: 30 * time.Second,
+		UploadTimeout:  10 * time.Minute,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	ctx := context.Background()
+	if timeout := client.connWithContext(ctx).HTTPClient.Timeout; timeout != c.RequestTimeout {
+		t.Errorf("expected requests to time out after %s, got %s", c.RequestTimeout, timeout)
+	}
+
+	uploadConn := uploadConnFromContext(withUploadConn(ctx, client.uploadConnWithContext(ctx)), client.conn)
+	if uploadConn.HTTPClient.Timeout != c.UploadTimeout {
+		t.Errorf("expected uploads to time out after %s, got %s", c.UploadTimeout, uploadConn.HTTPClient.Timeout)
+	}
+	if uploadConnFromContext(ctx, client.conn) != client.conn {
+		t.Error("expected uploads without an upload client to use the given client")
+	}
+
+	if timeout := registryClientFromContext(withRegistryClient(ctx, client.registryClient)).Timeout; timeout != c.UploadTimeout {
+		t.Errorf("expected pulls from registries to time out after %s, got %s", c.UploadTimeout, timeout)
+	}
+	if registryClientFromContext(ctx) != http.DefaultClient {
+		t.Error("expected pulls without a registry client to use the default client")
+	}
+}
+
+func TestProxyURL(t *testing.T) {
+	var proxyAuth, requestURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxyAuth = r.Header.Get("Proxy-Authorization")
+		requestURL = r.URL.String()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer proxy.Close()
+
+	c := Config{
+		APIKey:   "someapikey",
+		BaseURL:  "http://api.fastly.invalid",
+		ProxyURL: strings.Replace(proxy.URL, "http://", "http://user:p%40ss@", 1),
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	_, _ = client.connWithContext(context.Background()).GetCurrentUser()
+	if requestURL != "http://api.fastly.invalid/current_user" {
+		t.Errorf("expected the request to be sent through the proxy, got %q", requestURL)
+	}
+	if expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("user:p@ss")); proxyAuth != expected {
+		t.Errorf("expected the proxy credentials to be sent, got %q", proxyAuth)
+	}
+
+	if resp, err := client.registryClient.Get("http://registry.invalid/v2/"); err == nil {
+		resp.Body.Close()
+	}
+	if requestURL != "http://registry.invalid/v2/" {
+		t.Errorf("expected the registry request to be sent through the proxy, got %q", requestURL)
+	}
+
+	c.ForceHTTP2 = true
+	if _, diagnostics := c.Client(); !diagnostics.HasError() {
+		t.Error("expected proxy_url with force_http2 to fail")
+	}
+}
+
+func TestAPIKeySecondary(t *testing.T) {
+	var keys, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		keys = append(keys, r.Header.Get("Fastly-Key"))
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Fastly-Key") != "new-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"msg": "Provided credentials are missing or invalid"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "service-id", "name": "renamed"}`))
+	}))
+	defer server.Close()
+
+	c := Config{
+		APIKey:          "old-key",
+		APIKeySecondary: "new-key",
+		BaseURL:         server.URL,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("failed to create client: %s", diagToErr(diagnostics))
+	}
+
+	conn := client.connWithContext(context.Background())
+	if _, err := conn.UpdateService(&gofastly.UpdateServiceInput{ServiceID: "service-id", Name: gofastly.String("renamed")}); err != nil {
+		t.Fatalf("expected the request to succeed with the secondary key, got %s", err)
+	}
+	if _, err := conn.GetService(&gofastly.GetServiceInput{ID: "service-id"}); err != nil {
+		t.Fatalf("expected the request to succeed with the secondary key, got %s", err)
+	}
+	if expected := []string{"old-key", "new-key", "new-key"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("expected requests with keys %v, got %v", expected, keys)
+	}
+	if len(bodies) < 2 || bodies[1] == "" || bodies[1] != bodies[0] {
+		t.Errorf("expected the retried request to have the same body, got %q", bodies)
+	}
+
+	keys = nil
+	c.APIKeySecondary = ""
+	client, _ = c.Client()
+	if _, err := client.connWithContext(context.Background()).GetService(&gofastly.GetServiceInput{ID: "service-id"}); err == nil {
+		t.Error("expected the request to fail without a secondary key")
+	}
+	if len(keys) != 1 {
+		t.Errorf("expected one request without a secondary key, got %d", len(keys))
+	}
+}
@@ -6,8 +6,20 @@ const MessageTypeDescription = "How the message should be formatted. Can be eith
 // GzipLevelDescription describes Gzip compression.
 const GzipLevelDescription = "Level of Gzip compression from `0-9`. `0` means no compression. `1` is the fastest and the least compressed version, `9` is the slowest and the most compressed version. Default `0`"
 
+// RequestMaxEntriesDescription describes the maximum number of logs batched into one request.
+const RequestMaxEntriesDescription = "The maximum number of logs sent in one request. Defaults to `0` for unbounded"
+
+// RequestMaxBytesDescription describes the maximum size of the logs batched into one request.
+const RequestMaxBytesDescription = "The maximum number of bytes sent in one request. Defaults to `0` for unbounded"
+
 // TimestampFormatDescription describes the timestamp format.
 const TimestampFormatDescription = "The `strftime` specified timestamp formatting (default `%Y-%m-%dT%H:%M:%S.000`)"
 
 // SnippetTypeDescription describes the VCL snippet location.
 const SnippetTypeDescription = "The location in generated VCL where the snippet should be placed (can be one of `init`, `recv`, `hash`, `hit`, `miss`, `pass`, `fetch`, `error`, `deliver`, `log` or `none`)"
+
+// ValidateFormatVariablesDescription describes checking the VCL variables of a logging format.
+const ValidateFormatVariablesDescription = "Whether to check, when planning, that the `%{...}V` placeholders in `format` only use known VCL variables. The provider's list of variables may lag behind Fastly's, so a format Fastly accepts can fail the check. Default `false`"
+
+// FormatIsJSONDescription describes JSON logging formats.
+const FormatIsJSONDescription = "Whether `format` is JSON. If `true`, the format is checked to be valid JSON, once its placeholders are replaced, when planning, and is configured serialized like `jsonencode` does, with its keys sorted and without whitespace. Changes to how the JSON is written, such as whitespace or the order of keys, then don't cause a diff. Default `false`"
@@ -0,0 +1,89 @@
+package fastly
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	lines := func(from, to int) string {
+		var b strings.Builder
+		for i := from; i <= to; i++ {
+			b.WriteString("line " + string(rune('a'+i-1)) + "\n")
+		}
+		return b.String()
+	}
+
+	for name, testcase := range map[string]struct {
+		old, new string
+		expected string
+	}{
+		"unchanged": {
+			old:      "a\nb\n",
+			new:      "a\nb\n",
+			expected: "",
+		},
+		"changed line": {
+			old: "if ( req.url ) {\n  set req.http.a = \"1\";\n}\n",
+			new: "if ( req.url ) {\n  set req.http.a = \"2\";\n}\n",
+			expected: `--- old
++++ new
+@@ -1,3 +1,3 @@
+ if ( req.url ) {
+-  set req.http.a = "1";
++  set req.http.a = "2";
+ }
+`,
+		},
+		"added to empty": {
+			old: "",
+			new: "a\nb",
+			expected: `--- old
++++ new
+@@ -0,0 +1,2 @@
++a
++b
+`,
+		},
+		"separate hunks": {
+			old: lines(1, 12),
+			new: strings.Replace(strings.Replace(lines(1, 12), "line b\n", "", 1), "line k\n", "line k\nline x\n", 1),
+			expected: `--- old
++++ new
+@@ -1,5 +1,4 @@
+ line a
+-line b
+ line c
+ line d
+ line e
+@@ -9,4 +8,5 @@
+ line i
+ line j
+ line k
++line x
+ line l
+`,
+		},
+		"close changes share a hunk": {
+			old: lines(1, 6),
+			new: strings.Replace(strings.Replace(lines(1, 6), "line a\n", "line z\n", 1), "line f\n", "", 1),
+			expected: `--- old
++++ new
+@@ -1,6 +1,5 @@
+-line a
++line z
+ line b
+ line c
+ line d
+ line e
+-line f
+`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := unifiedDiff("old", "new", testcase.old, testcase.new); actual != testcase.expected {
+				t.Errorf("expected\n%s\ngot\n%s", testcase.expected, actual)
+			}
+		})
+	}
+}
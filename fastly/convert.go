@@ -2,6 +2,7 @@ package fastly
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -13,6 +14,24 @@ func uintOrDefault(int *uint) uint {
 	return *int
 }
 
+// formatOptionalTime formats t as RFC 3339, returning an empty string if t is
+// nil.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// redactSensitive returns a placeholder in place of a sensitive value, unless it is empty, so that structures holding
+// the value can be logged.
+func redactSensitive(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "(sensitive value)"
+}
+
 // diagToErr takes a diag.Diagnostics and finds the first Error (ignoring Warnings).
 // This is useful for some of the SDK functions which are context aware but still return Go errors, e.g. StateContext
 // and resource.RetryContext.
@@ -2,6 +2,7 @@ package fastly
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +15,10 @@ func TestDefaultUint(t *testing.T) {
 	v := uint(10)
 	assert.Equal(t, v, uintOrDefault(&v))
 }
+
+func TestFormatOptionalTime(t *testing.T) {
+	assert.Equal(t, "", formatOptionalTime(nil))
+
+	v := time.Date(2022, 10, 13, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, "2022-10-13T09:30:00Z", formatOptionalTime(&v))
+}
@@ -50,8 +50,8 @@ func dataSourceFastlyDatacenters() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyDatacentersRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyDatacentersRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	log.Printf("[DEBUG] Reading datacenters")
 
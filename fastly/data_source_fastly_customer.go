@@ -0,0 +1,158 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyCustomer() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyCustomerRead,
+
+		Schema: map[string]*schema.Schema{
+			"billing_contact_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the user who is the billing contact of the account. Empty if the account has no billing contact.",
+			},
+			"can_reset_passwords": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether users of the account can reset their passwords.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the account was created, in RFC 3339 format.",
+			},
+			"force_2fa": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the users of the account must log in with two-factor authentication.",
+			},
+			"force_sso": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the users of the account must log in with single sign-on.",
+			},
+			"has_billing_contact": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account has a billing contact.",
+			},
+			"has_pci": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account can use PCI-compliant caching and delivery.",
+			},
+			"has_security_contact": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account has a security contact.",
+			},
+			"has_technical_contact": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the account has a technical contact.",
+			},
+			"ip_allowlist": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP addresses the users of the account are allowed to log in from. Empty if logins aren't limited by IP address.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the account.",
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the user who owns the account.",
+			},
+			"pricing_plan": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The pricing plan of the account.",
+			},
+		},
+	}
+}
+
+// customer is the account of a Fastly customer, which go-fastly has no API for.
+type customer struct {
+	BillingContactID   string `json:"billing_contact_id"`
+	CanResetPasswords  bool   `json:"can_reset_passwords"`
+	CreatedAt          string `json:"created_at"`
+	Force2FA           bool   `json:"force_2fa"`
+	ForceSSO           bool   `json:"force_sso"`
+	HasPCI             bool   `json:"has_pci"`
+	ID                 string `json:"id"`
+	IPAllowlist        string `json:"ip_whitelist"`
+	Name               string `json:"name"`
+	OwnerID            string `json:"owner_id"`
+	PricingPlan        string `json:"pricing_plan"`
+	SecurityContactID  string `json:"security_contact_id"`
+	TechnicalContactID string `json:"technical_contact_id"`
+}
+
+func dataSourceFastlyCustomerRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading the customer")
+
+	c, err := getCurrentCustomer(conn)
+	if err != nil {
+		return diag.Errorf("error fetching the customer: %s", err)
+	}
+
+	createdAt := c.CreatedAt
+	if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+		createdAt = t.UTC().Format(time.RFC3339)
+	}
+
+	d.SetId(c.ID)
+	for k, v := range map[string]any{
+		"billing_contact_id":    c.BillingContactID,
+		"can_reset_passwords":   c.CanResetPasswords,
+		"created_at":            createdAt,
+		"force_2fa":             c.Force2FA,
+		"force_sso":             c.ForceSSO,
+		"has_billing_contact":   c.BillingContactID != "",
+		"has_pci":               c.HasPCI,
+		"has_security_contact":  c.SecurityContactID != "",
+		"has_technical_contact": c.TechnicalContactID != "",
+		"ip_allowlist":          c.IPAllowlist,
+		"name":                  c.Name,
+		"owner_id":              c.OwnerID,
+		"pricing_plan":          c.PricingPlan,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("error setting %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// getCurrentCustomer returns the customer the API token belongs to.
+func getCurrentCustomer(conn *gofastly.Client) (*customer, error) {
+	resp, err := conn.Get("/current_customer", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var c customer
+	if err := json.NewDecoder(resp.Body).Decode(&c); err != nil {
+		return nil, fmt.Errorf("error decoding the customer: %w", err)
+	}
+	return &c, nil
+}
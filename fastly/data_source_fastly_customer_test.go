@@ -0,0 +1,64 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastlyCustomerRead(t *testing.T) {
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/current_customer" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"id": "customer-id",
+			"name": "Example",
+			"owner_id": "owner-id",
+			"billing_contact_id": "billing-id",
+			"security_contact_id": null,
+			"technical_contact_id": "",
+			"force_2fa": true,
+			"force_sso": false,
+			"has_pci": false,
+			"can_reset_passwords": true,
+			"ip_whitelist": "192.0.2.0/24",
+			"pricing_plan": "developer",
+			"created_at": "2020-01-02T03:04:05+00:00"
+		}`)
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastlyCustomer().Schema, map[string]any{})
+	if diags := dataSourceFastlyCustomerRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "customer-id" {
+		t.Errorf("expected ID customer-id, got %q", d.Id())
+	}
+	for key, expected := range map[string]any{
+		"name":                  "Example",
+		"owner_id":              "owner-id",
+		"billing_contact_id":    "billing-id",
+		"has_billing_contact":   true,
+		"has_security_contact":  false,
+		"has_technical_contact": false,
+		"force_2fa":             true,
+		"force_sso":             false,
+		"has_pci":               false,
+		"can_reset_passwords":   true,
+		"ip_allowlist":          "192.0.2.0/24",
+		"pricing_plan":          "developer",
+		"created_at":            "2020-01-02T03:04:05Z",
+	} {
+		if actual := d.Get(key); actual != expected {
+			t.Errorf("expected %s to be %v, got %v", key, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyDomainSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyDomainSearchRead,
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The domain name to search for, e.g. `www.example.com`. The comparison is case-insensitive.",
+			},
+			"matches": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Every version of a service in the account that includes the domain, ordered by service name and then version.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the version is the active version of the service.",
+						},
+						"locked": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the version is locked.",
+						},
+						"service_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Alphanumeric string identifying the service.",
+						},
+						"service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the service.",
+						},
+						"service_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the service. One of `vcl`, `wasm`.",
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version of the service that includes the domain.",
+						},
+					},
+				},
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the service whose active version includes the domain. Empty if no active version includes it.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the service whose active version includes the domain. Empty if no active version includes it.",
+			},
+			"service_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The active version of the service that includes the domain. `0` if no active version includes it.",
+			},
+		},
+	}
+}
+
+func dataSourceFastlyDomainSearchRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+	domain := d.Get("domain").(string)
+
+	log.Printf("[DEBUG] Searching services for domain (%s)", domain)
+
+	services, err := listAllServices(conn)
+	if err != nil {
+		return diag.Errorf("error fetching services: %s", err)
+	}
+
+	matches, err := searchServiceDomains(conn, services, domain)
+	if err != nil {
+		return diag.Errorf("error searching services for domain (%s): %s", domain, err)
+	}
+
+	d.SetId(strings.ToLower(domain))
+
+	var serviceID, serviceName string
+	var serviceVersion int
+	for _, m := range matches {
+		if m["active"].(bool) {
+			serviceID = m["service_id"].(string)
+			serviceName = m["service_name"].(string)
+			serviceVersion = m["version"].(int)
+			break
+		}
+	}
+
+	if err := d.Set("matches", matches); err != nil {
+		return diag.Errorf("error setting matches: %s", err)
+	}
+	if err := d.Set("service_id", serviceID); err != nil {
+		return diag.Errorf("error setting service_id: %s", err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.Errorf("error setting service_name: %s", err)
+	}
+	if err := d.Set("service_version", serviceVersion); err != nil {
+		return diag.Errorf("error setting service_version: %s", err)
+	}
+
+	return nil
+}
+
+// searchServiceDomains returns a match for every version of the given services that includes domain. The API has
+// no account-wide domain lookup, so the domains of each service are listed in turn.
+func searchServiceDomains(conn *gofastly.Client, services []*gofastly.Service, domain string) ([]map[string]any, error) {
+	var matches []map[string]any
+	for _, s := range services {
+		domains, err := conn.ListServiceDomains(&gofastly.ListServiceDomainInput{ID: s.ID})
+		if err != nil {
+			return nil, fmt.Errorf("error listing domains for service (%s): %w", s.ID, err)
+		}
+
+		var versions []map[string]any
+		for _, sd := range domains {
+			if sd.DeletedAt != nil || !strings.EqualFold(sd.Name, domain) {
+				continue
+			}
+			versions = append(versions, map[string]any{
+				"service_id":   s.ID,
+				"service_name": s.Name,
+				"service_type": s.Type,
+				"version":      int(sd.ServiceVersion),
+				"active":       uint(sd.ServiceVersion) == s.ActiveVersion,
+				"locked":       sd.Locked,
+			})
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			return versions[i]["version"].(int) < versions[j]["version"].(int)
+		})
+		matches = append(matches, versions...)
+	}
+	return matches, nil
+}
@@ -0,0 +1,43 @@
+package fastly
+
+import (
+	"net/http"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchServiceDomains(t *testing.T) {
+	domains := map[string]string{
+		"/service/id-a/domain": `[
+			{"name": "www.example.com", "service_id": "id-a", "version": 2, "locked": true},
+			{"name": "WWW.example.com", "service_id": "id-a", "version": 3},
+			{"name": "api.example.com", "service_id": "id-a", "version": 3}
+		]`,
+		"/service/id-b/domain": `[
+			{"name": "www.example.com", "service_id": "id-b", "version": 1, "deleted_at": "2022-01-01T00:00:00Z"}
+		]`,
+	}
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(domains[r.URL.Path]))
+	})).conn
+
+	services := []*gofastly.Service{
+		{ID: "id-a", Name: "a", Type: "vcl", ActiveVersion: 2},
+		{ID: "id-b", Name: "b", Type: "wasm", ActiveVersion: 1},
+	}
+	matches, err := searchServiceDomains(conn, services, "www.example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []map[string]any{
+		{"service_id": "id-a", "service_name": "a", "service_type": "vcl", "version": 2, "active": true, "locked": true},
+		{"service_id": "id-a", "service_name": "a", "service_type": "vcl", "version": 3, "active": false, "locked": false},
+	}
+	if diff := cmp.Diff(expected, matches); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,241 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// eventsPageSize is the number of events requested at a time.
+const eventsPageSize = 100
+
+func dataSourceFastlyEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyEventsRead,
+
+		Schema: map[string]*schema.Schema{
+			"created_after": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return the events created at or after this time, in RFC 3339 format, e.g. `2022-01-01T00:00:00Z`.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"created_before": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return the events created at or before this time, in RFC 3339 format.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"event_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events of this type, e.g. `version.activate`.",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The events that match the filters, most recent first.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"admin": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the event was performed by a Fastly employee.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Timestamp (GMT) when the event happened.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A description of the event.",
+						},
+						"event_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the event.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the event.",
+						},
+						"ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The IP address the event was performed from.",
+						},
+						"metadata": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The details of the event as a JSON object, e.g. the version that was activated. Use `jsondecode` to read them.",
+						},
+						"service_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the service the event is about, if any.",
+						},
+						"user_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user that performed the event.",
+						},
+					},
+				},
+			},
+			"limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          1000,
+				Description:      "The most events to return. Default `1000`",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events about this service.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the events performed by this user.",
+			},
+		},
+	}
+}
+
+// eventsFilter is the filters of the events API used by the data source.
+// go-fastly's GetAPIEvents can't filter by creation time.
+type eventsFilter struct {
+	serviceID, userID, eventType string
+	createdAfter, createdBefore  string
+}
+
+func (f eventsFilter) params() map[string]string {
+	params := map[string]string{"sort": "-created_at"}
+	for key, value := range map[string]string{
+		"filter[service_id]":      f.serviceID,
+		"filter[user_id]":         f.userID,
+		"filter[event_type]":      f.eventType,
+		"filter[created_at][gte]": f.createdAfter,
+		"filter[created_at][lte]": f.createdBefore,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+	return params
+}
+
+// eventsPage is a page of the events API's JSON:API response.
+type eventsPage struct {
+	Data []struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			Admin       bool           `json:"admin"`
+			CreatedAt   string         `json:"created_at"`
+			Description string         `json:"description"`
+			EventType   string         `json:"event_type"`
+			IP          string         `json:"ip"`
+			Metadata    map[string]any `json:"metadata"`
+			ServiceID   string         `json:"service_id"`
+			UserID      string         `json:"user_id"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+}
+
+func dataSourceFastlyEventsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading events")
+
+	filter := eventsFilter{
+		serviceID:     d.Get("service_id").(string),
+		userID:        d.Get("user_id").(string),
+		eventType:     d.Get("event_type").(string),
+		createdAfter:  d.Get("created_after").(string),
+		createdBefore: d.Get("created_before").(string),
+	}
+	limit := d.Get("limit").(int)
+
+	events, err := listEvents(conn, filter, limit)
+	if err != nil {
+		return diag.Errorf("error fetching events: %s", err)
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%+v/%d", filter, limit))))
+
+	if err := d.Set("events", events); err != nil {
+		return diag.Errorf("error setting events: %s", err)
+	}
+
+	return nil
+}
+
+// listEvents returns up to limit of the events that match filter, most recent first, following the pages of results.
+func listEvents(conn *gofastly.Client, filter eventsFilter, limit int) ([]map[string]any, error) {
+	var events []map[string]any
+	for page := 1; len(events) < limit; page++ {
+		params := filter.params()
+		params["page[number]"] = strconv.Itoa(page)
+		params["page[size]"] = strconv.Itoa(eventsPageSize)
+
+		resp, err := conn.Get("/events", &gofastly.RequestOptions{Params: params})
+		if err != nil {
+			return nil, err
+		}
+		var p eventsPage
+		err = json.NewDecoder(resp.Body).Decode(&p)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error decoding events: %w", err)
+		}
+
+		for _, e := range p.Data {
+			if len(events) == limit {
+				break
+			}
+			metadata := ""
+			if len(e.Attributes.Metadata) > 0 {
+				b, err := json.Marshal(e.Attributes.Metadata)
+				if err != nil {
+					return nil, fmt.Errorf("error encoding the metadata of event %s: %w", e.ID, err)
+				}
+				metadata = string(b)
+			}
+			createdAt := e.Attributes.CreatedAt
+			if t, err := time.Parse(time.RFC3339, createdAt); err == nil {
+				createdAt = t.UTC().Format(time.RFC3339)
+			}
+			events = append(events, map[string]any{
+				"admin":       e.Attributes.Admin,
+				"created_at":  createdAt,
+				"description": e.Attributes.Description,
+				"event_type":  e.Attributes.EventType,
+				"id":          e.ID,
+				"ip":          e.Attributes.IP,
+				"metadata":    metadata,
+				"service_id":  e.Attributes.ServiceID,
+				"user_id":     e.Attributes.UserID,
+			})
+		}
+		if len(p.Data) == 0 || p.Links.Next == "" {
+			break
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,73 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastlyEventsRead(t *testing.T) {
+	event := func(id, createdAt string) string {
+		return fmt.Sprintf(`{"id": %q, "type": "event", "attributes": {"event_type": "version.activate", "created_at": %q, "service_id": "service-id", "user_id": "user-id", "metadata": {"version": 3}}}`, id, createdAt)
+	}
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		for key, expected := range map[string]string{
+			"filter[service_id]":      "service-id",
+			"filter[event_type]":      "version.activate",
+			"filter[created_at][gte]": "2022-01-01T00:00:00Z",
+			"sort":                    "-created_at",
+		} {
+			if query.Get(key) != expected {
+				t.Errorf("expected %s to be %q, got %q", key, expected, query.Get(key))
+			}
+		}
+		if query.Has("filter[user_id]") {
+			t.Error("expected filters that aren't set not to be sent")
+		}
+
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch query.Get("page[number]") {
+		case "1":
+			fmt.Fprintf(w, `{"data": [%s, %s], "links": {"next": "/events?page[number]=2"}}`, event("e3", "2022-01-03T00:00:00Z"), event("e2", "2022-01-02T00:00:00Z"))
+		case "2":
+			fmt.Fprintf(w, `{"data": [%s], "links": {}}`, event("e1", "2022-01-01T00:00:00Z"))
+		default:
+			t.Errorf("unexpected request for page %q", query.Get("page[number]"))
+		}
+	}))
+
+	for name, testcase := range map[string]struct {
+		limit    int
+		expected string
+	}{
+		"all pages":   {limit: 1000, expected: "[e3 e2 e1]"},
+		"up to limit": {limit: 2, expected: "[e3 e2]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceFastlyEvents().Schema, map[string]any{
+				"service_id":    "service-id",
+				"event_type":    "version.activate",
+				"created_after": "2022-01-01T00:00:00Z",
+				"limit":         testcase.limit,
+			})
+			if diags := dataSourceFastlyEventsRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			var ids []string
+			for _, e := range d.Get("events").([]any) {
+				ids = append(ids, e.(map[string]any)["id"].(string))
+			}
+			if got := fmt.Sprint(ids); got != testcase.expected {
+				t.Errorf("expected %s, got %s", testcase.expected, got)
+			}
+			if metadata := d.Get("events.0.metadata"); metadata != `{"version":3}` {
+				t.Errorf("expected the metadata as JSON, got %q", metadata)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package fastly
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceFastlyProducts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyProductsRead,
+
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Whether each product is enabled on the service, keyed by product ID, e.g. to only configure the blocks of a product when `enabled[\"image_optimizer\"]` is true.",
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+			},
+			"enabled_products": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The IDs of the products that are enabled on the service.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"required_products": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Products that must be enabled on the service. Reading the data source fails if any of them isn't, naming the missing products.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(products, false)),
+				},
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the service to look up the products of. Products are enabled for each service.",
+			},
+		},
+	}
+}
+
+func dataSourceFastlyProductsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+	serviceID := d.Get("service_id").(string)
+
+	log.Printf("[DEBUG] Reading the products of service (%s)", serviceID)
+
+	enabled := make(map[string]any, len(products))
+	var enabledProducts []string
+	for _, product := range products {
+		ok, err := getProductEnablement(conn, product, serviceID)
+		if err != nil {
+			return diag.Errorf("error fetching whether %s is enabled on service (%s): %s", product, serviceID, err)
+		}
+		enabled[product] = ok
+		if ok {
+			enabledProducts = append(enabledProducts, product)
+		}
+	}
+
+	var missing []string
+	for _, p := range d.Get("required_products").(*schema.Set).List() {
+		if !contains(enabledProducts, p.(string)) {
+			missing = append(missing, p.(string))
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return diag.Errorf("service (%s) doesn't have the required products %s enabled", serviceID, strings.Join(missing, ", "))
+	}
+
+	d.SetId(serviceID)
+	if err := d.Set("enabled", enabled); err != nil {
+		return diag.Errorf("error setting enabled: %s", err)
+	}
+	if err := d.Set("enabled_products", enabledProducts); err != nil {
+		return diag.Errorf("error setting enabled_products: %s", err)
+	}
+
+	return nil
+}
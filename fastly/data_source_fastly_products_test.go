@@ -0,0 +1,60 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastlyProductsRead(t *testing.T) {
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case productEnablementPath("image_optimizer", "service-id"), productEnablementPath("websockets", "service-id"):
+			_, _ = w.Write([]byte(`{"product": {"id": "image_optimizer"}, "service": {"id": "service-id"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": [{"title": "Not found"}]}`))
+		}
+	}))
+
+	for name, testcase := range map[string]struct {
+		required      []any
+		expectedError string
+	}{
+		"no requirements": {},
+		"enabled":         {required: []any{"websockets", "image_optimizer"}},
+		"missing": {
+			required:      []any{"websockets", "ngwaf", "fanout"},
+			expectedError: "service (service-id) doesn't have the required products fanout, ngwaf enabled",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceFastlyProducts().Schema, map[string]any{
+				"service_id":        "service-id",
+				"required_products": testcase.required,
+			})
+			diags := dataSourceFastlyProductsRead(context.Background(), d, meta)
+			if testcase.expectedError != "" {
+				if !diags.HasError() || diags[0].Summary != testcase.expectedError {
+					t.Fatalf("expected error %q, got %v", testcase.expectedError, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+
+			if actual := fmt.Sprint(d.Get("enabled_products").(*schema.Set).List()); actual != "[image_optimizer websockets]" && actual != "[websockets image_optimizer]" {
+				t.Errorf("expected image_optimizer and websockets to be enabled, got %s", actual)
+			}
+			enabled := d.Get("enabled").(map[string]any)
+			if len(enabled) != len(products) || enabled["image_optimizer"] != true || enabled["ngwaf"] != false {
+				t.Errorf("expected whether each product is enabled, got %v", enabled)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyServiceAuthorizations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyServiceAuthorizationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"authorizations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The service authorizations that match the filters, ordered by service ID and then user ID.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the service authorization.",
+						},
+						"permission": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The permissions granted to the user.",
+						},
+						"service_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the service the permissions are granted for.",
+						},
+						"user_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user the permissions are granted to.",
+						},
+					},
+				},
+			},
+			"permissions": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Only return the service authorizations that grant one of these permissions. Can be `full`, `read_only`, `purge_select` or `purge_all`.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateServiceAuthorizationPermission(),
+				},
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the service authorizations for this service.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the service authorizations of this user.",
+			},
+		},
+	}
+}
+
+func dataSourceFastlyServiceAuthorizationsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading service authorizations")
+
+	authorizations, err := listServiceAuthorizations(conn)
+	if err != nil {
+		return diag.Errorf("error fetching service authorizations: %s", err)
+	}
+
+	serviceID, userID := d.Get("service_id").(string), d.Get("user_id").(string)
+	var permissions []string
+	for _, p := range d.Get("permissions").(*schema.Set).List() {
+		permissions = append(permissions, p.(string))
+	}
+	sort.Strings(permissions)
+	authorizations = filterServiceAuthorizations(authorizations, serviceID, userID, permissions)
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s/%s/%s", serviceID, userID, strings.Join(permissions, ",")))))
+
+	if err := d.Set("authorizations", flattenServiceAuthorizations(authorizations)); err != nil {
+		return diag.Errorf("error setting authorizations: %s", err)
+	}
+
+	return nil
+}
+
+// listServiceAuthorizations returns all of the service authorizations of the account, following the pages of results.
+func listServiceAuthorizations(conn *gofastly.Client) ([]*gofastly.ServiceAuthorization, error) {
+	var authorizations []*gofastly.ServiceAuthorization
+	for page := 1; ; page++ {
+		resp, err := conn.ListServiceAuthorizations(&gofastly.ListServiceAuthorizationsInput{
+			PageNumber: page,
+			PageSize:   100,
+		})
+		if err != nil {
+			return nil, err
+		}
+		authorizations = append(authorizations, resp.Items...)
+		if len(resp.Items) == 0 || resp.Info.Links.Next == "" {
+			return authorizations, nil
+		}
+	}
+}
+
+// filterServiceAuthorizations returns the service authorizations that match each of the filters that isn't empty,
+// ordered by service ID and then user ID. permissions must be sorted.
+func filterServiceAuthorizations(authorizations []*gofastly.ServiceAuthorization, serviceID, userID string, permissions []string) []*gofastly.ServiceAuthorization {
+	var filtered []*gofastly.ServiceAuthorization
+	for _, sa := range authorizations {
+		if sa.Service == nil || sa.User == nil {
+			continue
+		}
+		if serviceID != "" && sa.Service.ID != serviceID {
+			continue
+		}
+		if userID != "" && sa.User.ID != userID {
+			continue
+		}
+		if len(permissions) > 0 {
+			if i := sort.SearchStrings(permissions, sa.Permission); i == len(permissions) || permissions[i] != sa.Permission {
+				continue
+			}
+		}
+		filtered = append(filtered, sa)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Service.ID != filtered[j].Service.ID {
+			return filtered[i].Service.ID < filtered[j].Service.ID
+		}
+		return filtered[i].User.ID < filtered[j].User.ID
+	})
+	return filtered
+}
+
+func flattenServiceAuthorizations(authorizations []*gofastly.ServiceAuthorization) []map[string]any {
+	result := make([]map[string]any, len(authorizations))
+	for i, sa := range authorizations {
+		result[i] = map[string]any{
+			"id":         sa.ID,
+			"permission": sa.Permission,
+			"service_id": sa.Service.ID,
+			"user_id":    sa.User.ID,
+		}
+	}
+	return result
+}
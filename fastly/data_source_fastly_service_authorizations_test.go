@@ -0,0 +1,57 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastlyServiceAuthorizationsRead(t *testing.T) {
+	authorization := func(id, serviceID, userID, permission string) string {
+		return fmt.Sprintf(`{"id": %q, "type": "service_authorization", "attributes": {"permission": %q}, "relationships": {"service": {"data": {"id": %q, "type": "service"}}, "user": {"data": {"id": %q, "type": "user"}}}}`, id, permission, serviceID, userID)
+	}
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Query().Get("page[number]") {
+		case "1":
+			fmt.Fprintf(w, `{"data": [%s, %s], "links": {"next": "/service-authorizations?page[number]=2"}}`,
+				authorization("sa-1", "production", "zoe", "full"),
+				authorization("sa-2", "staging", "alex", "full"))
+		case "2":
+			fmt.Fprintf(w, `{"data": [%s, %s], "links": {}}`,
+				authorization("sa-3", "production", "alex", "full"),
+				authorization("sa-4", "production", "sam", "read_only"))
+		default:
+			t.Errorf("unexpected request for page %q", r.URL.Query().Get("page[number]"))
+			fmt.Fprint(w, `{"data": []}`)
+		}
+	}))
+
+	for name, testcase := range map[string]struct {
+		config   map[string]any
+		expected string
+	}{
+		"all":            {config: map[string]any{}, expected: "[sa-3 sa-4 sa-1 sa-2]"},
+		"by service":     {config: map[string]any{"service_id": "production"}, expected: "[sa-3 sa-4 sa-1]"},
+		"by user":        {config: map[string]any{"user_id": "alex"}, expected: "[sa-3 sa-2]"},
+		"by permissions": {config: map[string]any{"service_id": "production", "permissions": []any{"full", "purge_all"}}, expected: "[sa-3 sa-1]"},
+		"no match":       {config: map[string]any{"user_id": "sam", "permissions": []any{"full"}}, expected: "[]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceFastlyServiceAuthorizations().Schema, testcase.config)
+			if diags := dataSourceFastlyServiceAuthorizationsRead(context.Background(), d, meta); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			var ids []string
+			for _, sa := range d.Get("authorizations").([]any) {
+				ids = append(ids, sa.(map[string]any)["id"].(string))
+			}
+			if got := fmt.Sprint(ids); got != testcase.expected {
+				t.Errorf("expected %s, got %s", testcase.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyServiceCompute() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyServiceComputeRead,
+
+		Schema: map[string]*schema.Schema{
+			"active_version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The active version of the service. `0` if no version is active.",
+			},
+			"backends": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The backends of the version, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname or IP address of the backend.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the backend.",
+						},
+						"override_host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname sent to the backend in place of the `Host` header of the request.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port of the backend.",
+						},
+						"shield": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The POP that shields the backend.",
+						},
+						"ssl_cert_hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname the certificate of the backend is verified against.",
+						},
+						"use_ssl": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the backend is connected to with TLS.",
+						},
+					},
+				},
+			},
+			"comment": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the service.",
+			},
+			"domains": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The domains of the version, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The comment of the domain.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain name.",
+						},
+					},
+				},
+			},
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the service. Exactly one of `id` or `name` must be set.",
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"linked_stores": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The stores linked to the version, e.g. KV, config and secret stores, ordered by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"link_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the link between the version and the store.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name the service uses for the store.",
+						},
+						"store_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the store.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the store, e.g. `kv-store`, `config` or `secret-store`.",
+						},
+					},
+				},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The name of the service. Exactly one of `id` or `name` must be set.",
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"package": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The metadata of the Wasm package of the version. Empty if the version has no package.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"authors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The authors of the package.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the package.",
+						},
+						"hashsum": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SHA-512 hash of the package.",
+						},
+						"language": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The language the package is written in.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the package.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the package, in bytes.",
+						},
+					},
+				},
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The version of the service to read. Defaults to the active version.",
+			},
+		},
+	}
+}
+
+// serviceResourceLink is the link between a version of a service and a store, which go-fastly has no API for.
+type serviceResourceLink struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ResourceID   string `json:"resource_id"`
+	ResourceType string `json:"resource_type"`
+}
+
+func dataSourceFastlyServiceComputeRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	serviceID := d.Get("id").(string)
+	if serviceID == "" {
+		name := d.Get("name").(string)
+		log.Printf("[DEBUG] Searching for the Compute service (%s)", name)
+		service, err := conn.SearchService(&gofastly.SearchServiceInput{
+			Name: name,
+		})
+		if err != nil {
+			return diag.Errorf("error searching for the service named %q: %s", name, err)
+		}
+		serviceID = service.ID
+	}
+
+	log.Printf("[DEBUG] Reading Compute service (%s)", serviceID)
+	s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+		ID: serviceID,
+	})
+	if err != nil {
+		return diag.Errorf("error fetching Fastly Service (%s): %s", serviceID, err)
+	}
+	if s.Type != ServiceTypeCompute {
+		return diag.Errorf("Fastly Service (%s) is a %s service, not a Compute service", serviceID, s.Type)
+	}
+
+	version := d.Get("version").(int)
+	if version == 0 {
+		if version = s.ActiveVersion.Number; version == 0 {
+			return diag.Errorf("Fastly Service (%s) has no active version, set version to read one of its versions", serviceID)
+		}
+	}
+
+	domains, err := conn.ListDomains(&gofastly.ListDomainsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return diag.Errorf("error fetching the domains of Fastly Service (%s), version (%d): %s", serviceID, version, err)
+	}
+	backends, err := conn.ListBackends(&gofastly.ListBackendsInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		return diag.Errorf("error fetching the backends of Fastly Service (%s), version (%d): %s", serviceID, version, err)
+	}
+	pkg, err := conn.GetPackage(&gofastly.GetPackageInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+	})
+	if err != nil {
+		if err, ok := err.(*gofastly.HTTPError); !ok || !err.IsNotFound() {
+			return diag.Errorf("error fetching the package of Fastly Service (%s), version (%d): %s", serviceID, version, err)
+		}
+	}
+	links, err := listServiceResourceLinks(conn, serviceID, version)
+	if err != nil {
+		return diag.Errorf("error fetching the linked stores of Fastly Service (%s), version (%d): %s", serviceID, version, err)
+	}
+
+	d.SetId(serviceID)
+	for k, v := range map[string]any{
+		"active_version": s.ActiveVersion.Number,
+		"backends":       flattenComputeBackends(backends),
+		"comment":        s.Comment,
+		"domains":        flattenComputeDomains(domains),
+		"linked_stores":  flattenServiceResourceLinks(links),
+		"name":           s.Name,
+		"package":        flattenComputePackageMetadata(pkg),
+		"version":        version,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("error setting %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// listServiceResourceLinks returns the stores linked to version of the service with serviceID, sorted by name.
+func listServiceResourceLinks(conn *gofastly.Client, serviceID string, version int) ([]*serviceResourceLink, error) {
+	resp, err := conn.Get(fmt.Sprintf("/service/%s/version/%d/resource", serviceID, version), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var links []*serviceResourceLink
+	if err := json.NewDecoder(resp.Body).Decode(&links); err != nil {
+		return nil, fmt.Errorf("error decoding the linked stores: %w", err)
+	}
+	sort.SliceStable(links, func(i, j int) bool {
+		return links[i].Name < links[j].Name
+	})
+	return links, nil
+}
+
+func flattenComputeDomains(domains []*gofastly.Domain) []map[string]any {
+	result := make([]map[string]any, len(domains))
+	for i, domain := range domains {
+		result[i] = map[string]any{
+			"comment": domain.Comment,
+			"name":    domain.Name,
+		}
+	}
+	return result
+}
+
+func flattenComputeBackends(backends []*gofastly.Backend) []map[string]any {
+	result := make([]map[string]any, len(backends))
+	for i, b := range backends {
+		result[i] = map[string]any{
+			"address":           b.Address,
+			"name":              b.Name,
+			"override_host":     b.OverrideHost,
+			"port":              int(b.Port),
+			"shield":            b.Shield,
+			"ssl_cert_hostname": b.SSLCertHostname,
+			"use_ssl":           b.UseSSL,
+		}
+	}
+	return result
+}
+
+func flattenComputePackageMetadata(pkg *gofastly.Package) []map[string]any {
+	if pkg == nil {
+		return nil
+	}
+	return []map[string]any{{
+		"authors":     pkg.Metadata.Authors,
+		"description": pkg.Metadata.Description,
+		"hashsum":     pkg.Metadata.HashSum,
+		"language":    pkg.Metadata.Language,
+		"name":        pkg.Metadata.Name,
+		"size":        int(pkg.Metadata.Size),
+	}}
+}
+
+func flattenServiceResourceLinks(links []*serviceResourceLink) []map[string]any {
+	result := make([]map[string]any, len(links))
+	for i, l := range links {
+		result[i] = map[string]any{
+			"link_id":  l.ID,
+			"name":     l.Name,
+			"store_id": l.ResourceID,
+			"type":     l.ResourceType,
+		}
+	}
+	return result
+}
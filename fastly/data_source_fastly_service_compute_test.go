@@ -0,0 +1,113 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceComputeTestHandler handles the requests the fastly_service_compute data source makes about a
+// service of serviceType whose active version, 3, has no package if hasPackage is false.
+func serviceComputeTestHandler(t *testing.T, serviceType string, hasPackage bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/service/search":
+			if name := r.URL.Query().Get("name"); name != "example" {
+				t.Errorf("unexpected search for %q", name)
+			}
+			fmt.Fprint(w, `{"id": "service-id", "name": "example", "type": "wasm"}`)
+		case "/service/service-id/details":
+			fmt.Fprintf(w, `{"id": "service-id", "name": "example", "type": %q, "comment": "Owned by another workspace", "active_version": {"number": 3}}`, serviceType)
+		case "/service/service-id/version/3/domain":
+			fmt.Fprint(w, `[{"name": "www.example.com", "comment": "main"}, {"name": "api.example.com"}]`)
+		case "/service/service-id/version/3/backend":
+			fmt.Fprint(w, `[{"name": "origin", "address": "origin.example.com", "port": 443, "use_ssl": true, "ssl_cert_hostname": "origin.example.com"}]`)
+		case "/service/service-id/version/3/package":
+			if !hasPackage {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"msg": "Record not found"}`)
+				return
+			}
+			fmt.Fprint(w, `{"id": "package-id", "metadata": {"name": "app", "description": "An app", "authors": ["dev@example.com"], "language": "rust", "size": 1024, "hashsum": "abc123"}}`)
+		case "/service/service-id/version/3/resource":
+			fmt.Fprint(w, `[{"id": "link-2", "name": "settings", "resource_id": "config-id", "resource_type": "config"}, {"id": "link-1", "name": "assets", "resource_id": "kv-id", "resource_type": "kv-store"}]`)
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestDataSourceFastlyServiceComputeRead(t *testing.T) {
+	meta := testAPIClient(t, serviceComputeTestHandler(t, ServiceTypeCompute, true))
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastlyServiceCompute().Schema, map[string]any{
+		"name": "example",
+	})
+	if diags := dataSourceFastlyServiceComputeRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "service-id" {
+		t.Errorf("expected ID service-id, got %q", d.Id())
+	}
+	for key, expected := range map[string]any{
+		"active_version":               3,
+		"version":                      3,
+		"comment":                      "Owned by another workspace",
+		"domains.#":                    2,
+		"backends.0.address":           "origin.example.com",
+		"backends.0.port":              443,
+		"backends.0.use_ssl":           true,
+		"backends.0.ssl_cert_hostname": "origin.example.com",
+		"package.0.name":               "app",
+		"package.0.authors":            []any{"dev@example.com"},
+		"package.0.language":           "rust",
+		"package.0.size":               1024,
+		"package.0.hashsum":            "abc123",
+		"linked_stores.0.link_id":      "link-1",
+		"linked_stores.0.name":         "assets",
+		"linked_stores.0.store_id":     "kv-id",
+		"linked_stores.0.type":         "kv-store",
+		"linked_stores.1.link_id":      "link-2",
+		"linked_stores.1.name":         "settings",
+		"linked_stores.1.store_id":     "config-id",
+		"linked_stores.1.type":         "config",
+	} {
+		if actual := d.Get(key); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected %s to be %v, got %v", key, expected, actual)
+		}
+	}
+}
+
+func TestDataSourceFastlyServiceComputeReadWithoutPackage(t *testing.T) {
+	meta := testAPIClient(t, serviceComputeTestHandler(t, ServiceTypeCompute, false))
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastlyServiceCompute().Schema, map[string]any{
+		"id": "service-id",
+	})
+	if diags := dataSourceFastlyServiceComputeRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if packages := d.Get("package").([]any); len(packages) != 0 {
+		t.Errorf("expected no package, got %v", packages)
+	}
+}
+
+func TestDataSourceFastlyServiceComputeReadVCLService(t *testing.T) {
+	meta := testAPIClient(t, serviceComputeTestHandler(t, ServiceTypeVCL, true))
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastlyServiceCompute().Schema, map[string]any{
+		"id": "service-id",
+	})
+	diags := dataSourceFastlyServiceComputeRead(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "not a Compute service") {
+		t.Errorf("expected an error for a VCL service, got %v", diags)
+	}
+}
@@ -0,0 +1,107 @@
+package fastly
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyShields() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyShieldsRead,
+
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the shields of POPs in this region of the world, e.g. `Europe`. The comparison is case-insensitive.",
+			},
+			"pops": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The POPs available for shielding, ordered by shield code.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A code representing the POP location.",
+						},
+						"group": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A code representing the general region of the world in which the POP location resides.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the POP.",
+						},
+						"shield": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The shield code of the POP, to use for `shield` in a `backend` block.",
+						},
+					},
+				},
+			},
+			"shields": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The shield codes of the POPs available for shielding, in alphabetical order, e.g. `lga-ny-us`.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceFastlyShieldsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading shields")
+
+	datacenters, err := conn.AllDatacenters()
+	if err != nil {
+		return diag.Errorf("error fetching datacenters: %s", err)
+	}
+
+	shields := shieldDatacenters(datacenters, d.Get("group").(string))
+
+	codes := make([]string, len(shields))
+	for i, dc := range shields {
+		codes[i] = dc.Shield
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(codes, ","))))
+
+	if err := d.Set("pops", flattenDatacenters(shields)); err != nil {
+		return diag.Errorf("error setting pops: %s", err)
+	}
+	if err := d.Set("shields", codes); err != nil {
+		return diag.Errorf("error setting shields: %s", err)
+	}
+
+	return nil
+}
+
+// shieldDatacenters returns the datacenters available for shielding, optionally
+// only those in group, ordered by shield code.
+func shieldDatacenters(datacenters []gofastly.Datacenter, group string) []gofastly.Datacenter {
+	var shields []gofastly.Datacenter
+	for _, dc := range datacenters {
+		if dc.Shield == "" || (group != "" && !strings.EqualFold(dc.Group, group)) {
+			continue
+		}
+		shields = append(shields, dc)
+	}
+	sort.Slice(shields, func(i, j int) bool {
+		return shields[i].Shield < shields[j].Shield
+	})
+	return shields
+}
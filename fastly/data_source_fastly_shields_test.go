@@ -0,0 +1,64 @@
+package fastly
+
+import (
+	"fmt"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestShieldDatacenters(t *testing.T) {
+	datacenters := []gofastly.Datacenter{
+		{Code: "LHR", Group: "Europe", Shield: "london-uk"},
+		{Code: "JFK", Group: "North America", Shield: "jfk-ny-us"},
+		{Code: "LGA", Group: "North America", Shield: "lga-ny-us"},
+		{Code: "EWR", Group: "North America"},
+		{Code: "AMS", Group: "Europe", Shield: "amsterdam-nl"},
+	}
+
+	for name, testcase := range map[string]struct {
+		group    string
+		expected string
+	}{
+		"all":      {expected: "[amsterdam-nl jfk-ny-us lga-ny-us london-uk]"},
+		"by group": {group: "europe", expected: "[amsterdam-nl london-uk]"},
+		"no match": {group: "Africa", expected: "[]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var shields []string
+			for _, dc := range shieldDatacenters(datacenters, testcase.group) {
+				shields = append(shields, dc.Shield)
+			}
+			if got := fmt.Sprint(shields); got != testcase.expected {
+				t.Errorf("expected %s, got %s", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccFastlyDataSource_Shields(t *testing.T) {
+	resourceName := "data.fastly_shields.some"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFastlyDataSourceShieldsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "shields.0"),
+					resource.TestCheckResourceAttrSet(resourceName, "pops.0.shield"),
+					resource.TestCheckResourceAttrPair(resourceName, "pops.0.shield", resourceName, "shields.0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccFastlyDataSourceShieldsConfig = `
+data "fastly_shields" "some" {
+}
+`
@@ -50,8 +50,8 @@ func dataSourceFastlyTLSActivation() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSActivationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSActivationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var activation *fastly.TLSActivation
 
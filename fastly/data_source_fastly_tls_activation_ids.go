@@ -29,8 +29,8 @@ func dataSourceFastlyTLSActivationIds() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSActivationIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSActivationIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var certificateID string
 
@@ -80,8 +80,8 @@ func dataSourceFastlyTLSCertificate() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSCertificateRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSCertificateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
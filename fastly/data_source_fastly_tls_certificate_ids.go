@@ -23,8 +23,8 @@ func dataSourceFastlyTLSCertificateIDs() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSCertificateIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSCertificateIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	certificates, err := listTLSCertificates(conn)
 	if err != nil {
@@ -104,8 +104,8 @@ const (
 	tlsCustomService   = "CUSTOM"
 )
 
-func dataSourceFastlyTLSConfigurationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSConfigurationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var configuration *fastly.CustomTLSConfiguration
 
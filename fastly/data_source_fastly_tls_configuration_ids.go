@@ -23,8 +23,8 @@ func dataSourceFastlyTLSConfigurationIDs() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSConfigurationIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSConfigurationIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	configurations, err := listTLSConfigurations(conn)
 	if err != nil {
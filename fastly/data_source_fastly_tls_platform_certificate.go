@@ -64,8 +64,8 @@ func dataSourceFastlyTLSPlatformCertificate() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSPlatformCertificateRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSPlatformCertificateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
@@ -23,8 +23,8 @@ func dataSourceFastlyTLSPlatformCertificateIDs() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSPlatformCertificateIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSPlatformCertificateIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	certificates, err := listPlatformTLSCertificates(conn)
 	if err != nil {
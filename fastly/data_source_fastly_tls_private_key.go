@@ -66,8 +66,8 @@ func dataSourceFastlyTLSPrivateKey() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSPrivateKeyRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSPrivateKeyRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
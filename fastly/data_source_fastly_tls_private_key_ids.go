@@ -3,9 +3,11 @@ package fastly
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
+	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -21,14 +23,38 @@ func dataSourceFastlyTLSPrivateKeyIDs() *schema.Resource {
 				Computed:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the private keys with this name, the human-readable name assigned to them when uploaded.",
+			},
+			"public_key_sha1": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the private keys with this hash of their public key, e.g. from the `public_key_sha1` of a `fastly_tls_private_key` managed elsewhere.",
+			},
 		},
 	}
 }
 
-func dataSourceFastlyTLSPrivateKeyIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSPrivateKeyIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	var filters []TLSPrivateKeyPredicate
+	name := d.Get("name").(string)
+	if name != "" {
+		filters = append(filters, func(key *fastly.PrivateKey) bool {
+			return key.Name == name
+		})
+	}
+	publicKeySHA1 := d.Get("public_key_sha1").(string)
+	if publicKeySHA1 != "" {
+		filters = append(filters, func(key *fastly.PrivateKey) bool {
+			return strings.EqualFold(key.PublicKeySHA1, publicKeySHA1)
+		})
+	}
 
-	keys, err := listTLSPrivateKeys(conn)
+	keys, err := listTLSPrivateKeys(conn, filters...)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -38,7 +64,7 @@ func dataSourceFastlyTLSPrivateKeyIDsRead(_ context.Context, d *schema.ResourceD
 		ids = append(ids, key.ID)
 	}
 
-	d.SetId(fmt.Sprintf("%d", hashcode.String(""))) // if other filters are added to this data source, they should be included in this hashcode instead of the empty string
+	d.SetId(fmt.Sprintf("%d", hashcode.String(name+"/"+publicKeySHA1)))
 	if err := d.Set("ids", ids); err != nil {
 		return diag.FromErr(err)
 	}
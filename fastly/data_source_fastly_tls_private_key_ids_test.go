@@ -1,14 +1,57 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/require"
 )
 
+func TestDataSourceFastlyTLSPrivateKeyIDsFilters(t *testing.T) {
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") != "1" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [
+			{"id": "key-1", "type": "tls_private_key", "attributes": {"name": "www", "public_key_sha1": "AAAA"}},
+			{"id": "key-2", "type": "tls_private_key", "attributes": {"name": "www", "public_key_sha1": "BBBB"}},
+			{"id": "key-3", "type": "tls_private_key", "attributes": {"name": "api", "public_key_sha1": "CCCC"}}
+		]}`)
+	}))
+
+	for name, testcase := range map[string]struct {
+		config    map[string]any
+		expectIDs []string
+	}{
+		"all":         {config: map[string]any{}, expectIDs: []string{"key-1", "key-2", "key-3"}},
+		"by name":     {config: map[string]any{"name": "www"}, expectIDs: []string{"key-1", "key-2"}},
+		"by sha1":     {config: map[string]any{"public_key_sha1": "bbbb"}, expectIDs: []string{"key-2"}},
+		"by both":     {config: map[string]any{"name": "api", "public_key_sha1": "AAAA"}},
+		"no matching": {config: map[string]any{"name": "missing"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceFastlyTLSPrivateKeyIDs().Schema, testcase.config)
+			diags := dataSourceFastlyTLSPrivateKeyIDsRead(context.Background(), d, meta)
+			require.False(t, diags.HasError(), "%v", diags)
+
+			var ids []string
+			for _, id := range d.Get("ids").(*schema.Set).List() {
+				ids = append(ids, id.(string))
+			}
+			sort.Strings(ids)
+			require.Equal(t, testcase.expectIDs, ids)
+		})
+	}
+}
+
 func TestAccFastlyDataSourceTLSPrivateKeyIds_basic(t *testing.T) {
 	key, _, err := generateKeyAndCert()
 	require.NoError(t, err)
@@ -67,8 +67,8 @@ func dataSourceFastlyTLSSubscription() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSSubscriptionRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSSubscriptionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var subscription *fastly.TLSSubscription
 
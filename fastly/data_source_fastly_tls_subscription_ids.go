@@ -24,8 +24,8 @@ func dataSourceFastlyTLSSubscriptionIDs() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyTLSSubscriptionIDsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyTLSSubscriptionIDsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	subscriptions, err := listTLSSubscriptions(conn)
 	if err != nil {
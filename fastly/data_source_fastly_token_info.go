@@ -0,0 +1,163 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// tokenScopes are the scopes an API token can have.
+var tokenScopes = []string{
+	string(gofastly.GlobalScope),
+	string(gofastly.GlobalReadScope),
+	string(gofastly.PurgeAllScope),
+	string(gofastly.PurgeSelectScope),
+}
+
+func dataSourceFastlyTokenInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyTokenInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the token was created, in RFC 3339 format.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the token expires, in RFC 3339 format. Empty if the token doesn't expire.",
+			},
+			"ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The IP address the token was created from.",
+			},
+			"last_used_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the token was last used, in RFC 3339 format.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the token.",
+			},
+			"required_scopes": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Scopes the token must have, e.g. `global` to manage services. Reading the data source fails if the token lacks any of them. The `global` scope includes the others. Can be `global`, `global:read`, `purge_all` or `purge_select`.",
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(tokenScopes, false)),
+				},
+			},
+			"required_service_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "IDs of services the token must be able to access. Reading the data source fails if the token is limited to services that don't include all of them.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"scopes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The scopes of the token.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"services": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "The IDs of the services the token is limited to. Empty if the token can access all services.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the user the token belongs to.",
+			},
+		},
+	}
+}
+
+func dataSourceFastlyTokenInfoRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading the API token")
+
+	token, err := conn.GetTokenSelf()
+	if err != nil {
+		return diag.Errorf("error fetching the API token: %s", err)
+	}
+	scopes := strings.Fields(string(token.Scope))
+
+	var requiredScopes, requiredServices []string
+	for _, s := range d.Get("required_scopes").(*schema.Set).List() {
+		requiredScopes = append(requiredScopes, s.(string))
+	}
+	for _, s := range d.Get("required_service_ids").(*schema.Set).List() {
+		requiredServices = append(requiredServices, s.(string))
+	}
+	if problems := checkTokenRequirements(scopes, token.Services, requiredScopes, requiredServices); len(problems) > 0 {
+		return diag.Errorf("the API token %q (%s) %s", token.Name, token.ID, strings.Join(problems, ", and "))
+	}
+
+	d.SetId(token.ID)
+	for k, v := range map[string]any{
+		"created_at":   formatOptionalTime(token.CreatedAt),
+		"expires_at":   formatOptionalTime(token.ExpiresAt),
+		"ip":           token.IP,
+		"last_used_at": formatOptionalTime(token.LastUsedAt),
+		"name":         token.Name,
+		"scopes":       scopes,
+		"services":     token.Services,
+		"user_id":      token.UserID,
+	} {
+		if err := d.Set(k, v); err != nil {
+			return diag.Errorf("error setting %s: %s", k, err)
+		}
+	}
+
+	return nil
+}
+
+// checkTokenRequirements returns how a token with scopes, limited to services unless services is empty, falls short
+// of the required scopes and services.
+func checkTokenRequirements(scopes, services, requiredScopes, requiredServices []string) []string {
+	var problems []string
+
+	var missingScopes []string
+	if !contains(scopes, string(gofastly.GlobalScope)) {
+		for _, s := range requiredScopes {
+			if !contains(scopes, s) {
+				missingScopes = append(missingScopes, s)
+			}
+		}
+	}
+	if len(missingScopes) > 0 {
+		sort.Strings(missingScopes)
+		problems = append(problems, fmt.Sprintf("lacks the required scopes %s, it has the scopes %s", strings.Join(missingScopes, ", "), strings.Join(scopes, ", ")))
+	}
+
+	var missingServices []string
+	if len(services) > 0 {
+		for _, s := range requiredServices {
+			if !contains(services, s) {
+				missingServices = append(missingServices, s)
+			}
+		}
+	}
+	if len(missingServices) > 0 {
+		sort.Strings(missingServices)
+		problems = append(problems, fmt.Sprintf("can't access the required services %s, it is limited to the services %s", strings.Join(missingServices, ", "), strings.Join(services, ", ")))
+	}
+
+	return problems
+}
@@ -0,0 +1,53 @@
+package fastly
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckTokenRequirements(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		scopes, services                 []string
+		requiredScopes, requiredServices []string
+		expected                         []string
+	}{
+		"no requirements": {
+			scopes: []string{"global:read"},
+		},
+		"global includes the other scopes": {
+			scopes:         []string{"global"},
+			requiredScopes: []string{"global:read", "purge_all"},
+		},
+		"missing scopes": {
+			scopes:         []string{"purge_select", "global:read"},
+			requiredScopes: []string{"purge_select", "purge_all", "global"},
+			expected:       []string{"lacks the required scopes global, purge_all, it has the scopes purge_select, global:read"},
+		},
+		"all services": {
+			scopes:           []string{"global"},
+			requiredServices: []string{"service-a"},
+		},
+		"limited services": {
+			scopes:           []string{"global"},
+			services:         []string{"service-a", "service-b"},
+			requiredServices: []string{"service-c", "service-a"},
+			expected:         []string{"can't access the required services service-c, it is limited to the services service-a, service-b"},
+		},
+		"missing scopes and services": {
+			scopes:           []string{"global:read"},
+			services:         []string{"service-a"},
+			requiredScopes:   []string{"global"},
+			requiredServices: []string{"service-b"},
+			expected: []string{
+				"lacks the required scopes global, it has the scopes global:read",
+				"can't access the required services service-b, it is limited to the services service-a",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := checkTokenRequirements(testcase.scopes, testcase.services, testcase.requiredScopes, testcase.requiredServices); !reflect.DeepEqual(actual, testcase.expected) {
+				t.Errorf("expected %q, got %q", testcase.expected, actual)
+			}
+		})
+	}
+}
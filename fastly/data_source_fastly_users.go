@@ -0,0 +1,126 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyUsers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyUsersRead,
+
+		Schema: map[string]*schema.Schema{
+			"login": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the user with this login, which is their email address. The comparison is case-insensitive.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the users with this real life name.",
+			},
+			"role": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Only return the users with this role. Can be `user`, `billing`, `engineer`, or `superuser`.",
+				ValidateDiagFunc: validateUserRole(),
+			},
+			"users": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The users of the account that match the filters, ordered by login.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the user, e.g. for the `user_id` of a `fastly_service_authorization`.",
+						},
+						"login": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The email address, which is the login name, of the user.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The real life name of the user.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The role of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastlyUsersRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Reading users")
+
+	// Users are listed by customer, so the customer is that of the user the API key belongs to.
+	current, err := conn.GetCurrentUser()
+	if err != nil {
+		return diag.Errorf("error fetching current user: %s", err)
+	}
+	users, err := conn.ListCustomerUsers(&gofastly.ListCustomerUsersInput{CustomerID: current.CustomerID})
+	if err != nil {
+		return diag.Errorf("error fetching users: %s", err)
+	}
+
+	login, name, role := d.Get("login").(string), d.Get("name").(string), d.Get("role").(string)
+	users = filterUsers(users, login, name, role)
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s/%s/%s/%s", current.CustomerID, login, name, role))))
+
+	if err := d.Set("users", flattenUsers(users)); err != nil {
+		return diag.Errorf("error setting users: %s", err)
+	}
+
+	return nil
+}
+
+// filterUsers returns the users that match each of the filters that isn't empty, ordered by login.
+func filterUsers(users []*gofastly.User, login, name, role string) []*gofastly.User {
+	var filtered []*gofastly.User
+	for _, u := range users {
+		if (login != "" && !strings.EqualFold(u.Login, login)) ||
+			(name != "" && u.Name != name) ||
+			(role != "" && u.Role != role) {
+			continue
+		}
+		filtered = append(filtered, u)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Login < filtered[j].Login
+	})
+	return filtered
+}
+
+func flattenUsers(users []*gofastly.User) []map[string]any {
+	result := make([]map[string]any, len(users))
+	for i, u := range users {
+		result[i] = map[string]any{
+			"id":    u.ID,
+			"login": u.Login,
+			"name":  u.Name,
+			"role":  u.Role,
+		}
+	}
+	return result
+}
@@ -0,0 +1,76 @@
+package fastly
+
+import (
+	"fmt"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFilterUsers(t *testing.T) {
+	users := []*gofastly.User{
+		{ID: "1", Login: "zoe@example.com", Name: "Zoe", Role: "engineer"},
+		{ID: "2", Login: "alex@example.com", Name: "Alex", Role: "superuser"},
+		{ID: "3", Login: "sam@example.com", Name: "Sam", Role: "engineer"},
+	}
+
+	for name, testcase := range map[string]struct {
+		login, name, role string
+		expected          string
+	}{
+		"all":      {expected: "[alex@example.com sam@example.com zoe@example.com]"},
+		"by login": {login: "Sam@Example.com", expected: "[sam@example.com]"},
+		"by name":  {name: "Zoe", expected: "[zoe@example.com]"},
+		"by role":  {role: "engineer", expected: "[sam@example.com zoe@example.com]"},
+		"no match": {login: "zoe@example.com", role: "billing", expected: "[]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var logins []string
+			for _, u := range filterUsers(users, testcase.login, testcase.name, testcase.role) {
+				logins = append(logins, u.Login)
+			}
+			if got := fmt.Sprint(logins); got != testcase.expected {
+				t.Errorf("expected %s, got %s", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccFastlyDataSource_Users(t *testing.T) {
+	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
+	resourceName := "data.fastly_users.some"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFastlyDataSourceUsersConfig(login),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "users.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "users.0.id", "fastly_user.foo", "id"),
+					resource.TestCheckResourceAttr(resourceName, "users.0.role", "engineer"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFastlyDataSourceUsersConfig(login string) string {
+	return fmt.Sprintf(`
+resource "fastly_user" "foo" {
+  login = "%s"
+  name  = "tf-test-user"
+  role  = "engineer"
+}
+
+data "fastly_users" "some" {
+  login = fastly_user.foo.login
+}
+`, login)
+}
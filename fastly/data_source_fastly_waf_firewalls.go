@@ -0,0 +1,171 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyWAFFirewalls() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyWAFFirewallsRead,
+
+		Schema: map[string]*schema.Schema{
+			"firewalls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Web Application Firewalls of the account that match the filters, ordered by service ID and service version.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of the active firewall version. `0` if no version is active.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the firewall was created, in RFC 3339 format.",
+						},
+						"disabled": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the firewall is disabled.",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the firewall, e.g. to import a `fastly_service_waf_configuration`.",
+						},
+						"prefetch_condition": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the condition that determines whether requests are checked by the firewall.",
+						},
+						"response_object": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the response object returned for blocked requests.",
+						},
+						"service_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the service the firewall belongs to.",
+						},
+						"service_version": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The version of the service the firewall belongs to.",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "When the firewall was last updated, in RFC 3339 format.",
+						},
+					},
+				},
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the firewalls of this service.",
+			},
+			"service_version": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Only return the firewalls of this version of the service. Requires `service_id`.",
+				RequiredWith: []string{"service_id"},
+			},
+		},
+	}
+}
+
+func dataSourceFastlyWAFFirewallsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	serviceID, serviceVersion := d.Get("service_id").(string), d.Get("service_version").(int)
+	log.Printf("[DEBUG] Reading WAF firewalls")
+
+	firewalls, err := listAllWAFs(conn, serviceID, serviceVersion)
+	if err != nil {
+		return diag.Errorf("error fetching WAF firewalls: %s", err)
+	}
+
+	result := make([]map[string]any, len(firewalls))
+	for i, f := range firewalls {
+		activeVersion, err := activeWAFVersion(conn, f.ID)
+		if err != nil {
+			return diag.Errorf("error fetching the versions of WAF firewall (%s): %s", f.ID, err)
+		}
+		result[i] = map[string]any{
+			"active_version":     activeVersion,
+			"created_at":         formatOptionalTime(f.CreatedAt),
+			"disabled":           f.Disabled,
+			"id":                 f.ID,
+			"prefetch_condition": f.PrefetchCondition,
+			"response_object":    f.Response,
+			"service_id":         f.ServiceID,
+			"service_version":    f.ServiceVersion,
+			"updated_at":         formatOptionalTime(f.UpdatedAt),
+		}
+	}
+
+	d.SetId(strconv.Itoa(hashcode.String(fmt.Sprintf("%s/%d", serviceID, serviceVersion))))
+
+	if err := d.Set("firewalls", result); err != nil {
+		return diag.Errorf("error setting WAF firewalls: %s", err)
+	}
+
+	return nil
+}
+
+// listAllWAFs returns the firewalls of the service with serviceID and serviceVersion, ignoring the filters that are
+// empty, fetching each page of results in turn. The firewalls are sorted by service ID and service version.
+func listAllWAFs(conn *gofastly.Client, serviceID string, serviceVersion int) ([]*gofastly.WAF, error) {
+	var firewalls []*gofastly.WAF
+	for page := 1; ; page++ {
+		resp, err := conn.ListWAFs(&gofastly.ListWAFsInput{
+			FilterService: serviceID,
+			FilterVersion: serviceVersion,
+			PageNumber:    page,
+			PageSize:      gofastly.WAFPaginationPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		firewalls = append(firewalls, resp.Items...)
+		if resp.Info.Links.Next == "" || len(resp.Items) == 0 {
+			break
+		}
+	}
+	sort.SliceStable(firewalls, func(i, j int) bool {
+		if firewalls[i].ServiceID != firewalls[j].ServiceID {
+			return firewalls[i].ServiceID < firewalls[j].ServiceID
+		}
+		return firewalls[i].ServiceVersion < firewalls[j].ServiceVersion
+	})
+	return firewalls, nil
+}
+
+// activeWAFVersion returns the number of the active version of the firewall with wafID, or 0 if no version is active.
+func activeWAFVersion(conn *gofastly.Client, wafID string) (int, error) {
+	resp, err := conn.ListAllWAFVersions(&gofastly.ListAllWAFVersionsInput{
+		WAFID: wafID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range resp.Items {
+		if v.Active {
+			return v.Number, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,56 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceFastlyWAFFirewallsRead(t *testing.T) {
+	firewall := func(id, serviceID string, serviceVersion int) string {
+		return fmt.Sprintf(`{"type": "waf_firewall", "id": %q, "attributes": {"service_id": %q, "service_version_number": %d, "prefetch_condition": "WAF_Prefetch", "response": "WAF_Response", "created_at": "2022-01-01T00:00:00Z"}}`, id, serviceID, serviceVersion)
+	}
+	version := func(id string, number int, active bool) string {
+		return fmt.Sprintf(`{"type": "waf_firewall_version", "id": %q, "attributes": {"number": %d, "active": %t}}`, id, number, active)
+	}
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		switch r.URL.Path {
+		case "/waf/firewalls":
+			switch r.URL.Query().Get("page[number]") {
+			case "1":
+				fmt.Fprintf(w, `{"data": [%s, %s], "links": {"next": "/waf/firewalls?page[number]=2"}}`, firewall("waf-c", "service-b", 2), firewall("waf-b", "service-b", 1))
+			case "2":
+				fmt.Fprintf(w, `{"data": [%s], "links": {}}`, firewall("waf-a", "service-a", 4))
+			default:
+				t.Errorf("unexpected request for page %q", r.URL.Query().Get("page[number]"))
+			}
+		case "/waf/firewalls/waf-a/versions":
+			fmt.Fprintf(w, `{"data": [%s, %s], "links": {}}`, version("a1", 1, false), version("a2", 2, true))
+		case "/waf/firewalls/waf-b/versions", "/waf/firewalls/waf-c/versions":
+			fmt.Fprintf(w, `{"data": [%s], "links": {}}`, version("v1", 1, false))
+		default:
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+	}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceFastlyWAFFirewalls().Schema, map[string]any{})
+	if diags := dataSourceFastlyWAFFirewallsRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var firewalls []string
+	for _, f := range d.Get("firewalls").([]any) {
+		f := f.(map[string]any)
+		firewalls = append(firewalls, fmt.Sprintf("%s:%s/%d:%d", f["id"], f["service_id"], f["service_version"], f["active_version"]))
+	}
+	if expected, actual := "[waf-a:service-a/4:2 waf-b:service-b/1:0 waf-c:service-b/2:0]", fmt.Sprint(firewalls); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+	if createdAt := d.Get("firewalls.0.created_at"); createdAt != "2022-01-01T00:00:00Z" {
+		t.Errorf("expected created_at in RFC 3339 format, got %q", createdAt)
+	}
+}
@@ -31,8 +31,8 @@ func dataSourceFastlyIPRanges() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyIPRangesRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyIPRangesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	log.Printf("[DEBUG] Reading IP ranges")
 
@@ -0,0 +1,118 @@
+package fastly
+
+import (
+	"context"
+	"log"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceFastlyServiceVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceFastlyServiceVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Alphanumeric string identifying the service.",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "A list of all versions of the service, ordered by version number.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether this is the active version of the service.",
+						},
+						"comment": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A freeform descriptive note.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date and time in ISO 8601 format.",
+						},
+						"deployed": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Unused at this time.",
+						},
+						"locked": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether this version is locked. Versions become locked once they have been activated.",
+						},
+						"number": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of this version.",
+						},
+						"staging": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether this version is deployed to the staging network.",
+						},
+						"testing": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Unused at this time.",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date and time in ISO 8601 format.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFastlyServiceVersionsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	serviceID := d.Get("service_id").(string)
+
+	log.Printf("[DEBUG] Reading versions for service (%s)", serviceID)
+
+	versions, err := conn.ListVersions(&gofastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return diag.Errorf("error fetching versions for service (%s): %s", serviceID, err)
+	}
+
+	d.SetId(serviceID)
+
+	if err := d.Set("versions", flattenServiceVersions(versions)); err != nil {
+		return diag.Errorf("error setting service versions: %s", err)
+	}
+
+	return nil
+}
+
+func flattenServiceVersions(versions []*gofastly.Version) []map[string]any {
+	result := make([]map[string]any, len(versions))
+	for i, v := range versions {
+		result[i] = map[string]any{
+			"number":     v.Number,
+			"comment":    v.Comment,
+			"active":     v.Active,
+			"locked":     v.Locked,
+			"deployed":   v.Deployed,
+			"staging":    v.Staging,
+			"testing":    v.Testing,
+			"created_at": formatOptionalTime(v.CreatedAt),
+			"updated_at": formatOptionalTime(v.UpdatedAt),
+		}
+	}
+	return result
+}
@@ -0,0 +1,115 @@
+package fastly
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFlattenServiceVersions(t *testing.T) {
+	createdAt := time.Date(2022, 10, 13, 9, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		remote []*gofastly.Version
+		local  []map[string]any
+	}{
+		{
+			remote: []*gofastly.Version{
+				{
+					Number:    1,
+					Comment:   "initial version",
+					Active:    true,
+					Locked:    true,
+					CreatedAt: &createdAt,
+					UpdatedAt: &createdAt,
+				},
+				{
+					Number:  2,
+					Comment: "draft",
+				},
+			},
+			local: []map[string]any{
+				{
+					"number":     1,
+					"comment":    "initial version",
+					"active":     true,
+					"locked":     true,
+					"deployed":   false,
+					"staging":    false,
+					"testing":    false,
+					"created_at": "2022-10-13T09:30:00Z",
+					"updated_at": "2022-10-13T09:30:00Z",
+				},
+				{
+					"number":     2,
+					"comment":    "draft",
+					"active":     false,
+					"locked":     false,
+					"deployed":   false,
+					"staging":    false,
+					"testing":    false,
+					"created_at": "",
+					"updated_at": "",
+				},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		out := flattenServiceVersions(c.remote)
+		if !reflect.DeepEqual(out, c.local) {
+			t.Fatalf("Error matching:\nexpected: %#v\ngot: %#v", c.local, out)
+		}
+	}
+}
+
+func TestAccFastlyDataSourceServiceVersions_Config(t *testing.T) {
+	resourceName := "data.fastly_service_versions.example"
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.%s.com", name)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFastlyDataSourceServiceVersionsConfig(name, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "service_id", "fastly_service_vcl.example", "id"),
+					resource.TestCheckResourceAttr(resourceName, "versions.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.number", "1"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.active", "true"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.locked", "true"),
+					resource.TestCheckResourceAttr(resourceName, "versions.0.comment", "example version"),
+				),
+			},
+		},
+	})
+}
+
+func testAccFastlyDataSourceServiceVersionsConfig(name, domain string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "example" {
+  name            = "%s"
+  version_comment = "example version"
+
+  domain {
+    name = "%s"
+  }
+
+  force_destroy = true
+}
+
+data "fastly_service_versions" "example" {
+  service_id = fastly_service_vcl.example.id
+}
+`, name, domain)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"sort"
 	"strconv"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -77,12 +78,12 @@ func dataSourceFastlyServices() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyServicesRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyServicesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	log.Printf("[DEBUG] Reading services")
 
-	services, err := conn.ListServices(&gofastly.ListServicesInput{})
+	services, err := listAllServices(conn)
 	if err != nil {
 		return diag.Errorf("error fetching services: %s", err)
 	}
@@ -102,6 +103,24 @@ func dataSourceFastlyServicesRead(_ context.Context, d *schema.ResourceData, met
 	return nil
 }
 
+// listAllServices returns every service in the account, fetching each page of
+// results in turn. The services are sorted by name.
+func listAllServices(conn *gofastly.Client) ([]*gofastly.Service, error) {
+	var services []*gofastly.Service
+	p := conn.NewListServicesPaginator(&gofastly.ListServicesInput{})
+	for p.HasNext() {
+		page, err := p.GetNext()
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, page...)
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+	return services, nil
+}
+
 func flattenServiceIDs(services []*gofastly.Service) []string {
 	result := make([]string, len(services))
 	for i, s := range services {
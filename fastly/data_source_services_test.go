@@ -4,11 +4,38 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+func TestListAllServices(t *testing.T) {
+	pages := map[string]string{
+		"1": `[{"id": "id-c", "name": "c"}, {"id": "id-a", "name": "a"}]`,
+		"2": `[{"id": "id-b", "name": "b"}]`,
+	}
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		w.Header().Set("Link", fmt.Sprintf(`<%s/service?page=2>; rel="last"`, "http://"+r.Host))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(pages[page]))
+	})).conn
+
+	services, err := listAllServices(conn)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var names []string
+	for _, s := range services {
+		names = append(names, s.Name)
+	}
+	if fmt.Sprint(names) != "[a b c]" {
+		t.Errorf("expected services from every page sorted by name, got %v", names)
+	}
+}
+
 func TestAccFastlyDataSourceServices_Config(t *testing.T) {
 	resourceName := "data.fastly_services.some"
 	serviceName := "fastly_service_vcl.example_service"
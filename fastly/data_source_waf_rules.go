@@ -69,8 +69,8 @@ func dataSourceFastlyWAFRules() *schema.Resource {
 	}
 }
 
-func dataSourceFastlyWAFRulesRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func dataSourceFastlyWAFRulesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 	input := &gofastly.ListAllWAFRulesInput{
 		Include: "waf_rule_revisions",
 	}
@@ -0,0 +1,139 @@
+package fastly
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// serviceAttributeError records the block of a service, and the instance of it, that a request failed for, so that
+// the diagnostic can point at the block in the configuration.
+type serviceAttributeError struct {
+	key  string
+	name string
+	err  error
+}
+
+func (e *serviceAttributeError) Error() string {
+	if e.name == "" {
+		return fmt.Sprintf("error updating %s: %s", e.key, e.err)
+	}
+	return fmt.Sprintf("error updating %s %q: %s", e.key, e.name, e.err)
+}
+
+func (e *serviceAttributeError) Unwrap() error {
+	return e.err
+}
+
+// connError records the client that a request failed with, when it isn't the client of the provider, so that the
+// diagnostic reports the state of the rate limit seen by that client.
+type connError struct {
+	conn *gofastly.Client
+	err  error
+}
+
+func (e *connError) Error() string {
+	return e.err.Error()
+}
+
+func (e *connError) Unwrap() error {
+	return e.err
+}
+
+// errorFromConn records that err was returned by a request made with conn. It returns nil if err is nil.
+func errorFromConn(conn *gofastly.Client, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &connError{conn: conn, err: err}
+}
+
+// apiErrorDiagnostics returns the diagnostics for an error. Errors from the Fastly API are broken down into the
+// errors the API reported, the state of the API rate limit and a hint about how to fix the problem. Errors for a
+// block of a service (see serviceAttributeError) are reported against that block. The rate limit is read from the
+// client recorded by errorFromConn, or conn if there isn't one.
+func apiErrorDiagnostics(err error, conn *gofastly.Client) diag.Diagnostics {
+	var httpErr *gofastly.HTTPError
+	if !errors.As(err, &httpErr) {
+		return diag.FromErr(err)
+	}
+	var connErr *connError
+	if errors.As(err, &connErr) {
+		conn = connErr.conn
+	}
+
+	var title string
+	var details []string
+	for _, e := range httpErr.Errors {
+		if title == "" {
+			title = e.Title
+		}
+		detail := e.Detail
+		if detail == "" {
+			detail = e.Title
+		}
+		if e.Code != "" {
+			detail = fmt.Sprintf("%s (code %s)", detail, e.Code)
+		}
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if title == "" {
+		title = http.StatusText(httpErr.StatusCode)
+	}
+	details = append(details, fmt.Sprintf("HTTP status: %d %s", httpErr.StatusCode, http.StatusText(httpErr.StatusCode)))
+
+	if conn != nil {
+		// The client hasn't seen the rate limit headers if the reset is still the zero Unix time.
+		if reset := conn.RateLimitReset(); reset.Unix() != 0 {
+			details = append(details, fmt.Sprintf("API rate limit: %d requests remaining, resets at %s", conn.RateLimitRemaining(), reset.Format(time.RFC3339)))
+		}
+	}
+	if hint := apiErrorHint(httpErr.StatusCode); hint != "" {
+		details = append(details, hint)
+	}
+
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Fastly API error: %s", title),
+		Detail:   strings.Join(details, "\n"),
+	}
+
+	var attrErr *serviceAttributeError
+	if errors.As(err, &attrErr) {
+		d.AttributePath = cty.GetAttrPath(attrErr.key)
+		if attrErr.name == "" {
+			d.Summary = fmt.Sprintf("Fastly API error for %s: %s", attrErr.key, title)
+		} else {
+			d.Summary = fmt.Sprintf("Fastly API error for %s %q: %s", attrErr.key, attrErr.name, title)
+		}
+	}
+
+	return diag.Diagnostics{d}
+}
+
+// apiErrorHint returns a suggestion for how to fix a request that failed with the given status code.
+func apiErrorHint(statusCode int) string {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "Check that the API token set by api_key or FASTLY_API_KEY is valid and hasn't expired."
+	case statusCode == http.StatusForbidden:
+		return "Check that the API token has the scope and the access to the service needed for this change, e.g. a global scope rather than purge_select."
+	case statusCode == http.StatusNotFound:
+		return "The object may have been deleted outside of Terraform. Refreshing the state will remove it, so that the next apply recreates it."
+	case statusCode == http.StatusConflict:
+		return "The object conflicts with one that already exists, e.g. a domain claimed by another service. The fastly_domain_search data source shows which service includes a domain."
+	case statusCode == http.StatusTooManyRequests:
+		return "The API rate limit was exceeded. Wait until it resets, or reduce the number of changes made in each apply."
+	case statusCode >= http.StatusInternalServerError:
+		return "This is likely a temporary problem with the Fastly API. Retry the apply, and check https://status.fastly.com if the problem persists."
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package fastly
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	apiErr := &gofastly.HTTPError{
+		StatusCode: http.StatusConflict,
+		Errors: []*gofastly.ErrorObject{
+			{Title: "Duplicate record", Detail: "Domain 'www.example.com' is taken by another customer", Code: "409"},
+		},
+	}
+	err := &serviceAttributeError{key: "domain", name: "www.example.com", err: apiErr}
+
+	diags := apiErrorDiagnostics(err, &gofastly.Client{})
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	d := diags[0]
+	if expected := `Fastly API error for domain "www.example.com": Duplicate record`; d.Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, d.Summary)
+	}
+	if !d.AttributePath.Equals(cty.GetAttrPath("domain")) {
+		t.Errorf("expected the diagnostic to point at the domain block, got %#v", d.AttributePath)
+	}
+	for _, expected := range []string{
+		"Domain 'www.example.com' is taken by another customer (code 409)",
+		"HTTP status: 409 Conflict",
+		"fastly_domain_search",
+	} {
+		if !strings.Contains(d.Detail, expected) {
+			t.Errorf("expected detail to contain %q, got %q", expected, d.Detail)
+		}
+	}
+
+	diags = apiErrorDiagnostics(&gofastly.HTTPError{StatusCode: http.StatusServiceUnavailable}, nil)
+	if expected := "Fastly API error: Service Unavailable"; diags[0].Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, diags[0].Summary)
+	}
+	if diags[0].AttributePath != nil {
+		t.Errorf("expected no attribute path, got %#v", diags[0].AttributePath)
+	}
+
+	failedConn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Fastly-RateLimit-Remaining", "0")
+		w.Header().Set("Fastly-RateLimit-Reset", "1700000000")
+	})).conn
+	if _, err := failedConn.Post("/service", nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	rateLimitErr := &gofastly.HTTPError{StatusCode: http.StatusTooManyRequests}
+	expected := "API rate limit: 0 requests remaining, resets at " + time.Unix(1700000000, 0).Format(time.RFC3339)
+	diags = apiErrorDiagnostics(errorFromConn(failedConn, rateLimitErr), &gofastly.Client{})
+	if !strings.Contains(diags[0].Detail, expected) {
+		t.Errorf("expected the rate limit of the client that failed, got %q", diags[0].Detail)
+	}
+	diags = apiErrorDiagnostics(rateLimitErr, &gofastly.Client{})
+	if strings.Contains(diags[0].Detail, "API rate limit:") {
+		t.Errorf("expected no rate limit from a client that hasn't seen one, got %q", diags[0].Detail)
+	}
+
+	diags = apiErrorDiagnostics(errors.New("boom"), nil)
+	if diags[0].Summary != "boom" {
+		t.Errorf("expected other errors to be reported as they are, got %q", diags[0].Summary)
+	}
+}
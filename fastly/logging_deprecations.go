@@ -0,0 +1,151 @@
+package fastly
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// loggingEndpointDeprecation describes a logging endpoint that Fastly has sunset or is sunsetting, and how to move its
+// configuration to the block that replaces it.
+type loggingEndpointDeprecation struct {
+	// endpoint is the name of the sunset endpoint.
+	endpoint string
+	// replacement is the block that replaces the deprecated block.
+	replacement string
+	// guidance explains how to configure the replacement.
+	guidance string
+	// attributes maps the attributes of the deprecated block to the attributes of the replacement that take the same
+	// value.
+	attributes map[string]string
+	// settings are the attributes of the replacement that don't come from the deprecated block.
+	settings map[string]string
+}
+
+// deprecatedLoggingEndpoints are the logging blocks of sunset endpoints, by block.
+var deprecatedLoggingEndpoints = map[string]loggingEndpointDeprecation{
+	"logging_logentries": {
+		endpoint:    "Logentries",
+		replacement: "logging_https",
+		guidance:    "Logentries is now Rapid7 InsightOps, which receives logs through its webhook. Replace <region> in url with the region of your InsightOps account, e.g. us or eu, and <token> with the token of the logging_logentries block.",
+		attributes: map[string]string{
+			"name":               "name",
+			"format":             "format",
+			"format_version":     "format_version",
+			"placement":          "placement",
+			"response_condition": "response_condition",
+		},
+		settings: map[string]string{
+			"content_type": "text/plain",
+			"method":       "POST",
+			"url":          "https://<region>.webhook.logs.insight.rapid7.com/v1/noformat/<token>",
+		},
+	},
+}
+
+// message returns the schema deprecation message of a deprecated block.
+func (dep loggingEndpointDeprecation) message(key string) string {
+	var mapped []string
+	for _, from := range sortedKeys(dep.attributes) {
+		if to := dep.attributes[from]; to == from {
+			mapped = append(mapped, from)
+		} else {
+			mapped = append(mapped, fmt.Sprintf("%s as %s", from, to))
+		}
+	}
+	var settings []string
+	for _, k := range sortedKeys(dep.settings) {
+		settings = append(settings, fmt.Sprintf("%s = %q", k, dep.settings[k]))
+	}
+	return fmt.Sprintf("Fastly has sunset %s logging endpoints. Replace %s with %s, keeping %s and setting %s. %s",
+		dep.endpoint, key, dep.replacement, strings.Join(mapped, ", "), strings.Join(settings, ", "), dep.guidance)
+}
+
+// upgrade returns the configuration of the replacement of a deprecated block.
+func (dep loggingEndpointDeprecation) upgrade(block map[string]any) map[string]any {
+	upgraded := make(map[string]any, len(dep.attributes)+len(dep.settings))
+	for from, to := range dep.attributes {
+		if v, ok := block[from]; ok && v != "" {
+			upgraded[to] = v
+		}
+	}
+	for k, v := range dep.settings {
+		upgraded[k] = v
+	}
+	return upgraded
+}
+
+// deprecateLoggingEndpoints marks the blocks of sunset logging endpoints in a service schema as deprecated, so that
+// Terraform warns when they are configured.
+func deprecateLoggingEndpoints(s *schema.Resource) {
+	for key, dep := range deprecatedLoggingEndpoints {
+		if sch, ok := s.Schema[key]; ok {
+			sch.Deprecated = dep.message(key)
+		}
+	}
+}
+
+// loggingEndpointUpgradeDiagnostics returns a warning for each block of a sunset logging endpoint in the state of a
+// service, with the configuration of the block that replaces it.
+func loggingEndpointUpgradeDiagnostics(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, key := range sortedKeys(deprecatedLoggingEndpoints) {
+		set, ok := d.Get(key).(*schema.Set)
+		if !ok {
+			continue
+		}
+		dep := deprecatedLoggingEndpoints[key]
+		for _, v := range set.List() {
+			block := v.(map[string]any)
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s %q uses a sunset logging endpoint", key, block["name"]),
+				Detail: fmt.Sprintf("Fastly has sunset %s logging endpoints. Replace this block with:\n\n%s\n%s",
+					dep.endpoint, renderHCLBlock(dep.replacement, dep.upgrade(block)), dep.guidance),
+			})
+		}
+	}
+	return diags
+}
+
+// renderHCLBlock returns the HCL of a block with the given attributes, sorted by name.
+func renderHCLBlock(name string, attributes map[string]any) string {
+	var b strings.Builder
+	width := 0
+	for k := range attributes {
+		if len(k) > width {
+			width = len(k)
+		}
+	}
+	fmt.Fprintf(&b, "%s {\n", name)
+	for _, k := range sortedKeys(attributes) {
+		fmt.Fprintf(&b, "  %-*s = %s\n", width, k, hclValue(attributes[k]))
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// hclValue returns the HCL literal of a value. Strings are escaped so that template sequences, e.g. the %{...}V
+// placeholders of a logging format, are kept as they are.
+func hclValue(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	quoted := strconv.Quote(s)
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(quoted)
+}
+
+// sortedKeys returns the keys of a map with string keys in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package fastly
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLoggingEndpointDeprecations(t *testing.T) {
+	for _, r := range []*schema.Resource{resourceServiceVCL(), resourceServiceCompute()} {
+		for key, dep := range deprecatedLoggingEndpoints {
+			if _, ok := r.Schema[dep.replacement]; !ok {
+				t.Fatalf("%s is replaced by %s, which doesn't exist", key, dep.replacement)
+			}
+			if !strings.Contains(r.Schema[key].Deprecated, "Replace "+key+" with "+dep.replacement) {
+				t.Errorf("expected %s to be deprecated in favor of %s, got %q", key, dep.replacement, r.Schema[key].Deprecated)
+			}
+			replacement := r.Schema[dep.replacement].Elem.(*schema.Resource).Schema
+			for from, to := range dep.attributes {
+				_, hasFrom := r.Schema[key].Elem.(*schema.Resource).Schema[from]
+				if _, ok := replacement[to]; hasFrom && !ok {
+					t.Errorf("%s maps %s to %s, which %s doesn't have", key, from, to, dep.replacement)
+				}
+			}
+			for k := range dep.settings {
+				if _, ok := replacement[k]; !ok {
+					t.Errorf("%s sets %s, which %s doesn't have", key, k, dep.replacement)
+				}
+			}
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceServiceVCL().Schema, map[string]any{
+		"name": "service",
+		"logging_logentries": []any{
+			map[string]any{
+				"name":   "logentries-endpoint",
+				"token":  "secret-token",
+				"format": `{"host": "%{req.http.host}V", "path": "${path}"}`,
+			},
+		},
+	})
+	diags := loggingEndpointUpgradeDiagnostics(d)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected one warning, got %#v", diags)
+	}
+	if expected := `logging_logentries "logentries-endpoint" uses a sunset logging endpoint`; diags[0].Summary != expected {
+		t.Errorf("expected summary %q, got %q", expected, diags[0].Summary)
+	}
+	expectedBlock := `logging_https {
+  content_type   = "text/plain"
+  format         = "{\"host\": \"%%{req.http.host}V\", \"path\": \"$${path}\"}"
+  format_version = 2
+  method         = "POST"
+  name           = "logentries-endpoint"
+  url            = "https://<region>.webhook.logs.insight.rapid7.com/v1/noformat/<token>"
+}
+`
+	if !strings.Contains(diags[0].Detail, expectedBlock) {
+		t.Errorf("expected the detail to contain\n%s\ngot\n%s", expectedBlock, diags[0].Detail)
+	}
+	if strings.Contains(diags[0].Detail, "secret-token") {
+		t.Error("expected the token not to be shown")
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceServiceVCL().Schema, map[string]any{"name": "service"})
+	if diags := loggingEndpointUpgradeDiagnostics(d); len(diags) != 0 {
+		t.Errorf("expected no warnings without deprecated blocks, got %#v", diags)
+	}
+}
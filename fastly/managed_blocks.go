@@ -0,0 +1,104 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// managedBlockKeys returns the blocks of a service that managed_blocks can list, sorted: the blocks of the attribute
+// handlers that manage a set of blocks, other than the blocks every service must have, which are always managed.
+func managedBlockKeys(s *schema.Resource, serviceDef ServiceDefinition) []string {
+	var keys []string
+	for _, a := range serviceDef.GetAttributeHandler() {
+		h, ok := a.(*blockSetAttributeHandler)
+		if !ok {
+			continue
+		}
+		if sch, ok := s.Schema[h.GetKey()]; ok && !sch.Required {
+			keys = append(keys, h.GetKey())
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// managesBlock returns whether a service with managed_blocks set to managed, and cloned_blocks set to cloned, manages
+// the blocks with key. When managed_blocks is empty, every block that wasn't copied from clone_from is managed.
+func managesBlock(managed, cloned any, key string) bool {
+	if set, ok := managed.(*schema.Set); ok && set.Len() > 0 {
+		return set.Contains(key)
+	}
+	list, _ := cloned.([]any)
+	for _, k := range list {
+		if k == key {
+			return false
+		}
+	}
+	return true
+}
+
+// manageBlocks registers managed_blocks on a service resource. The blocks it doesn't list are ignored: their
+// differences are suppressed, so that the blocks read when importing a service don't show as removals, they aren't
+// refreshed, and configuring them is an error.
+func manageBlocks(s *schema.Resource, serviceDef ServiceDefinition) {
+	keys := managedBlockKeys(s, serviceDef)
+
+	s.Schema["managed_blocks"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "The blocks the provider manages, e.g. `[\"backend\", \"logging_s3\"]`, so that a service can be managed partly with Terraform and partly in the UI. The blocks that aren't listed are left as they are: they aren't refreshed, imported blocks of those types don't show as removals, and they can't be configured. `domain` blocks are always managed. Empty, the default, manages every block that wasn't copied from `clone_from`",
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(keys, false)),
+		},
+	}
+
+	for _, key := range keys {
+		key := key
+		s.Schema[key].DiffSuppressFunc = func(_, _, _ string, d *schema.ResourceData) bool {
+			return !managesBlock(d.Get("managed_blocks"), d.Get("cloned_blocks"), key)
+		}
+	}
+
+	s.CustomizeDiff = customdiff.All(s.CustomizeDiff, func(_ context.Context, d *schema.ResourceDiff, _ any) error {
+		return validateManagedBlocks(d.GetRawConfig(), d.Get("managed_blocks"), d.Get("cloned_blocks"), keys)
+	})
+}
+
+// validateManagedBlocks returns an error if config has blocks that aren't managed. The planned value of those blocks is
+// their value in state, so their configuration is checked instead.
+func validateManagedBlocks(config cty.Value, managed, cloned any, keys []string) error {
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() {
+		return nil
+	}
+
+	var unmanaged []string
+	for _, key := range keys {
+		if managesBlock(managed, cloned, key) || !config.Type().HasAttribute(key) {
+			continue
+		}
+		v := config.GetAttr(key)
+		if !v.IsNull() && v.IsKnown() && v.LengthInt() > 0 {
+			unmanaged = append(unmanaged, key)
+		}
+	}
+
+	if len(unmanaged) > 0 {
+		return fmt.Errorf("invalid managed_blocks: the configuration has %s blocks, which aren't managed as managed_blocks doesn't list them, or they were copied from clone_from. Add them to managed_blocks, or remove the blocks", strings.Join(unmanaged, ", "))
+	}
+	return nil
+}
+
+// managesHandler returns whether the service d manages the blocks of an attribute handler. Handlers that don't
+// manage a set of blocks, e.g. the service settings, are always managed.
+func managesHandler(d interface{ Get(string) any }, a ServiceAttributeDefinition) bool {
+	h, ok := a.(*blockSetAttributeHandler)
+	return !ok || managesBlock(d.Get("managed_blocks"), d.Get("cloned_blocks"), h.GetKey())
+}
@@ -0,0 +1,133 @@
+package fastly
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestManagedBlockKeys(t *testing.T) {
+	keys := managedBlockKeys(resourceServiceVCL(), vclService)
+	var hasBackend bool
+	for _, key := range keys {
+		if key == "domain" {
+			t.Errorf("expected domain blocks to always be managed, got keys %v", keys)
+		}
+		hasBackend = hasBackend || key == "backend"
+	}
+	if !hasBackend {
+		t.Errorf("expected backend blocks to be listable, got keys %v", keys)
+	}
+}
+
+func TestManagesBlock(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		managed  any
+		cloned   any
+		expected bool
+	}{
+		"unset":      {managed: nil, expected: true},
+		"empty":      {managed: schema.NewSet(schema.HashString, nil), expected: true},
+		"listed":     {managed: schema.NewSet(schema.HashString, []any{"backend", "header"}), expected: true},
+		"unlisted":   {managed: schema.NewSet(schema.HashString, []any{"backend"}), expected: false},
+		"cloned":     {cloned: []any{"condition", "header"}, expected: false},
+		"not cloned": {cloned: []any{"condition"}, expected: true},
+		"cloned and listed": {
+			managed:  schema.NewSet(schema.HashString, []any{"header"}),
+			cloned:   []any{"header"},
+			expected: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := managesBlock(testcase.managed, testcase.cloned, "header"); actual != testcase.expected {
+				t.Errorf("expected %t, got %t", testcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestValidateManagedBlocks(t *testing.T) {
+	keys := []string{"backend", "header"}
+	block := cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"name": cty.StringVal("example")})})
+	noBlocks := cty.ListValEmpty(cty.Object(map[string]cty.Type{"name": cty.String}))
+	config := cty.ObjectVal(map[string]cty.Value{"backend": block, "header": block})
+
+	for name, testcase := range map[string]struct {
+		config   cty.Value
+		managed  []any
+		cloned   []any
+		expected string
+	}{
+		"every block managed": {config: config},
+		"listed blocks":       {config: config, managed: []any{"backend", "header"}},
+		"unlisted blocks": {
+			config:   config,
+			managed:  []any{"backend"},
+			expected: "invalid managed_blocks: the configuration has header blocks",
+		},
+		"no unlisted blocks": {
+			config:  cty.ObjectVal(map[string]cty.Value{"backend": block, "header": noBlocks}),
+			managed: []any{"backend"},
+		},
+		"cloned blocks": {
+			config:   config,
+			cloned:   []any{"header"},
+			expected: "invalid managed_blocks: the configuration has header blocks",
+		},
+		"null configuration": {config: cty.NullVal(config.Type()), managed: []any{"backend"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateManagedBlocks(testcase.config, schema.NewSet(schema.HashString, testcase.managed), testcase.cloned, keys)
+			if testcase.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			} else if err == nil || !strings.HasPrefix(err.Error(), testcase.expected) {
+				t.Errorf("expected error %q, got %v", testcase.expected, err)
+			}
+		})
+	}
+}
+
+func TestManagedBlocksDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "service-id",
+		Attributes: map[string]string{
+			"activate":                "true",
+			"force_destroy":           "false",
+			"name":                    "example",
+			"domain.#":                "1",
+			"domain.0.name":           "example.com",
+			"domain.0.comment":        "",
+			"header.#":                "1",
+			"header.0.name":           "added in the UI",
+			"header.0.action":         "set",
+			"header.0.type":           "response",
+			"header.0.destination":    "http.X-Example",
+			"managed_blocks.#":        "1",
+			"managed_blocks.0":        "backend",
+			"detect_unmanaged_blocks": "false",
+		},
+	}
+	cfg := map[string]any{
+		"name":           "example",
+		"domain":         []any{map[string]any{"name": "example.com"}},
+		"managed_blocks": []any{"backend"},
+	}
+
+	diff, err := resourceServiceVCL().Diff(context.Background(), state, terraform.NewResourceConfigRaw(cfg), &APIClient{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil {
+		for key := range diff.Attributes {
+			if strings.HasPrefix(key, "header") {
+				t.Errorf("expected the header blocks to be ignored, got a diff for %s", key)
+			}
+		}
+	}
+}
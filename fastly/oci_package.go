@@ -0,0 +1,297 @@
+package fastly
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// ociDefaultRegistry is the registry of references that don't name one, as with container images.
+const ociDefaultRegistry = "registry-1.docker.io"
+
+// ociPackageMaxSize limits the size of a package pulled from a registry. It is well above the largest package the
+// Fastly API accepts, so that a layer that isn't a package can't fill the disk.
+const ociPackageMaxSize = 1 << 30
+
+// ociManifestMediaTypes are the manifests that can be pulled, in order of preference.
+var ociManifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// ociReferencePattern matches a reference to an artifact pinned by digest, e.g. ghcr.io/org/app@sha256:<digest>.
+var ociReferencePattern = regexp.MustCompile(`^([a-z0-9]+(?:[._-][a-z0-9]+)*(?:\.[a-z0-9]+(?:[._-][a-z0-9]+)*)*(?::[0-9]+)?/)?([a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*)@(sha256:[a-f0-9]{64})$`)
+
+// ociReference is an artifact in an OCI registry, pinned by digest.
+type ociReference struct {
+	Registry   string
+	Repository string
+	Digest     string
+}
+
+// parseOCIReference parses a reference to an artifact that is pinned by digest. As with container images, the first
+// component of the repository is taken to be the registry when it contains a dot or a port or is localhost, and
+// references without a registry are pulled from Docker Hub.
+func parseOCIReference(ref string) (*ociReference, error) {
+	m := ociReferencePattern.FindStringSubmatch(ref)
+	if m == nil {
+		return nil, fmt.Errorf("expected a reference pinned by digest, e.g. ghcr.io/org/app@sha256:<digest>, got %q", ref)
+	}
+
+	registry, repository := strings.TrimSuffix(m[1], "/"), m[2]
+	if registry != "" && !strings.ContainsAny(registry, ".:") && registry != "localhost" {
+		repository = registry + "/" + repository
+		registry = ""
+	}
+	if registry == "" {
+		registry = ociDefaultRegistry
+		if !strings.Contains(repository, "/") {
+			repository = "library/" + repository
+		}
+	}
+	return &ociReference{Registry: registry, Repository: repository, Digest: m[3]}, nil
+}
+
+// ociDescriptor describes content in a registry.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// ociManifest is an image or artifact manifest.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// ociClient pulls packages from OCI registries, authenticating with username and password when they are set.
+type ociClient struct {
+	http     *http.Client
+	username string
+	password string
+}
+
+// newOCIClient returns a client that pulls packages from registries over HTTPS with client.
+func newOCIClient(client *http.Client, username, password string) *ociClient {
+	return &ociClient{http: client, username: username, password: password}
+}
+
+// pullPackage downloads the package of an artifact to a temporary file and returns its name. The caller removes the
+// file. The manifest and the package are checked against their digests, so the package is the one the reference
+// pins.
+func (c *ociClient) pullPackage(ctx context.Context, ref *ociReference) (string, error) {
+	body, err := c.get(ctx, ref, "manifests/"+ref.Digest, strings.Join(ociManifestMediaTypes, ", "))
+	if err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(io.LimitReader(body, packageManifestMaxSize))
+	body.Close()
+	if err != nil {
+		return "", fmt.Errorf("error reading manifest: %s", err)
+	}
+	if digest := fmt.Sprintf("sha256:%x", sha256.Sum256(data)); digest != ref.Digest {
+		return "", fmt.Errorf("manifest has digest %s, expected %s", digest, ref.Digest)
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return "", fmt.Errorf("error decoding manifest: %s", err)
+	}
+	layer, err := manifest.packageLayer()
+	if err != nil {
+		return "", err
+	}
+
+	body, err = c.get(ctx, ref, "blobs/"+layer.Digest, "")
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	f, err := os.CreateTemp("", "fastly-package-*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(body, ociPackageMaxSize+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	switch {
+	case err != nil:
+		err = fmt.Errorf("error downloading package: %s", err)
+	case n > ociPackageMaxSize:
+		err = fmt.Errorf("package is larger than %d bytes", int64(ociPackageMaxSize))
+	case "sha256:"+hex.EncodeToString(h.Sum(nil)) != layer.Digest:
+		err = fmt.Errorf("package has digest sha256:%x, expected %s", h.Sum(nil), layer.Digest)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// packageLayer returns the layer holding the package: the only layer, or else the only layer that is a gzipped
+// tarball, going by its media type or its title annotation.
+func (m *ociManifest) packageLayer() (*ociDescriptor, error) {
+	var found *ociDescriptor
+	for i, l := range m.Layers {
+		if len(m.Layers) > 1 && !strings.HasSuffix(l.MediaType, "tar+gzip") && !strings.HasSuffix(l.Annotations["org.opencontainers.image.title"], ".tar.gz") {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("manifest has more than one gzipped tarball layer")
+		}
+		found = &m.Layers[i]
+	}
+	if found == nil {
+		return nil, fmt.Errorf("manifest has no gzipped tarball layer")
+	}
+	if !strings.HasPrefix(found.Digest, "sha256:") {
+		return nil, fmt.Errorf("unsupported layer digest %q", found.Digest)
+	}
+	return found, nil
+}
+
+// get returns the body of a request to the registry API of a repository. When the registry asks for authentication,
+// the request is made again with a bearer token or basic authentication, as the registry asks.
+func (c *ociClient) get(ctx context.Context, ref *ociReference, path, accept string) (io.ReadCloser, error) {
+	u := fmt.Sprintf("https://%s/v2/%s/%s", ref.Registry, ref.Repository, path)
+	resp, err := c.do(ctx, u, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		authorization, err := c.authorize(ctx, challenge)
+		if err != nil {
+			return nil, fmt.Errorf("error authenticating with %s: %s", ref.Registry, err)
+		}
+		if resp, err = c.do(ctx, u, accept, authorization); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("error fetching %s: %s", u, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (c *ociClient) do(ctx context.Context, u, accept, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return c.http.Do(req)
+}
+
+// authorize returns the Authorization header that answers a WWW-Authenticate challenge of a registry.
+func (c *ociClient) authorize(ctx context.Context, challenge string) (string, error) {
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if c.username == "" {
+			return "", fmt.Errorf("the registry requires a username and password")
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(c.username+":"+c.password)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Host == "" {
+		return "", fmt.Errorf("invalid token realm %q", params["realm"])
+	}
+	// The registry's credentials are sent to the realm, so they mustn't be sent unencrypted.
+	if realm.Scheme != "https" {
+		return "", fmt.Errorf("token realm %q doesn't use https", params["realm"])
+	}
+	q := realm.Query()
+	for _, k := range []string{"service", "scope"} {
+		if v := params[k]; v != "" {
+			q.Set(k, v)
+		}
+	}
+	realm.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error fetching token: %s", resp.Status)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("error decoding token: %s", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("no token was returned")
+	}
+	return "Bearer " + token.Token, nil
+}
+
+// parseAuthChallenge returns the scheme and parameters of a WWW-Authenticate challenge, e.g.
+// Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:org/app:pull".
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(challenge), " ")
+	params := map[string]string{}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimLeft(rest, ", ") {
+		var key, value string
+		key, rest, _ = strings.Cut(rest, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(rest, `"`) {
+			var b strings.Builder
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				b.WriteByte(rest[i])
+			}
+			value = b.String()
+			if i < len(rest) {
+				i++
+			}
+			rest = rest[i:]
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		params[key] = strings.TrimSpace(value)
+	}
+	return scheme, params
+}
@@ -0,0 +1,184 @@
+package fastly
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	for ref, expected := range map[string]*ociReference{
+		"ghcr.io/org/app@" + digest:            {Registry: "ghcr.io", Repository: "org/app", Digest: digest},
+		"localhost:5000/app@" + digest:         {Registry: "localhost:5000", Repository: "app", Digest: digest},
+		"localhost/org/app@" + digest:          {Registry: "localhost", Repository: "org/app", Digest: digest},
+		"org/app@" + digest:                    {Registry: ociDefaultRegistry, Repository: "org/app", Digest: digest},
+		"app@" + digest:                        {Registry: ociDefaultRegistry, Repository: "library/app", Digest: digest},
+		"ghcr.io/org/app:latest":               nil,
+		"ghcr.io/org/app@sha256:abc":           nil,
+		"ghcr.io/Org/app@" + digest:            nil,
+		"https://ghcr.io/org/app@" + digest:    nil,
+		"ghcr.io/org/app@sha512:" + digest[7:]: nil,
+	} {
+		actual, err := parseOCIReference(ref)
+		if expected == nil {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %#v", ref, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", ref, err)
+		} else if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %#v, got %#v", ref, expected, actual)
+		}
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://ghcr.io/token",service="ghcr.io", scope="repository:org/app:pull",error=invalid_token`)
+	expected := map[string]string{
+		"realm":   "https://ghcr.io/token",
+		"service": "ghcr.io",
+		"scope":   "repository:org/app:pull",
+		"error":   "invalid_token",
+	}
+	if scheme != "Bearer" || !reflect.DeepEqual(params, expected) {
+		t.Errorf("expected Bearer %v, got %s %v", expected, scheme, params)
+	}
+}
+
+func TestOCIClientPullPackage(t *testing.T) {
+	pkg, err := os.ReadFile("test_fixtures/package/valid.tar.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(pkg))
+	manifest := []byte(fmt.Sprintf(`{
+  "schemaVersion": 2,
+  "mediaType": "application/vnd.oci.image.manifest.v1+json",
+  "config": {"mediaType": "application/vnd.oci.empty.v1+json", "digest": "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", "size": 2},
+  "layers": [
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar", "digest": "sha256:%x", "size": 3},
+    {"mediaType": "application/vnd.oci.image.layer.v1.tar+gzip", "digest": "%s", "size": %d}
+  ]
+}`, sha256.Sum256([]byte("abc")), pkgDigest, len(pkg)))
+	manifestDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(manifest))
+
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			if user, pass, _ := r.BasicAuth(); user != "user" || pass != "pass" || r.URL.Query().Get("scope") != "repository:org/app:pull" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"token": "secret"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry",scope="repository:org/app:pull"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/org/app/manifests/" + manifestDigest:
+			w.Write(manifest)
+		case "/v2/org/app/blobs/" + pkgDigest:
+			w.Write(pkg)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	c := &ociClient{http: server.Client(), username: "user", password: "pass"}
+	filename, err := c.pullPackage(context.Background(), &ociReference{Registry: registry, Repository: "org/app", Digest: manifestDigest})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer os.Remove(filename)
+	if pulled, err := os.ReadFile(filename); err != nil || !bytes.Equal(pulled, pkg) {
+		t.Errorf("expected the package to be pulled, got %d bytes, %v", len(pulled), err)
+	}
+
+	otherDigest := "sha256:" + strings.Repeat("0", 64)
+	if _, err := c.pullPackage(context.Background(), &ociReference{Registry: registry, Repository: "org/app", Digest: otherDigest}); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a missing manifest to be reported, got %v", err)
+	}
+
+	c.password = "wrong"
+	if _, err := c.pullPackage(context.Background(), &ociReference{Registry: registry, Repository: "org/app", Digest: manifestDigest}); err == nil || !strings.Contains(err.Error(), "error authenticating") {
+		t.Errorf("expected wrong credentials to be reported, got %v", err)
+	}
+}
+
+func TestOCIClientAuthorizeRealm(t *testing.T) {
+	var requested bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = true
+		w.Write([]byte(`{"token": "secret"}`))
+	}))
+	defer server.Close()
+
+	c := &ociClient{http: server.Client(), username: "user", password: "pass"}
+	_, err := c.authorize(context.Background(), fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+	if err == nil || !strings.Contains(err.Error(), "doesn't use https") {
+		t.Errorf("expected a realm without https to be rejected, got %v", err)
+	}
+	if requested {
+		t.Error("expected no request to be sent to the realm")
+	}
+}
+
+func TestOCIManifestPackageLayer(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	for name, testcase := range map[string]struct {
+		layers      []ociDescriptor
+		expectError string
+	}{
+		"single layer": {
+			layers: []ociDescriptor{{MediaType: "application/octet-stream", Digest: digest}},
+		},
+		"titled layer": {
+			layers: []ociDescriptor{
+				{MediaType: "application/wasm", Digest: "sha256:00"},
+				{MediaType: "application/octet-stream", Digest: digest, Annotations: map[string]string{"org.opencontainers.image.title": "package.tar.gz"}},
+			},
+		},
+		"no layers": {
+			expectError: "manifest has no gzipped tarball layer",
+		},
+		"ambiguous": {
+			layers: []ociDescriptor{
+				{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: digest},
+				{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Digest: digest},
+			},
+			expectError: "manifest has more than one gzipped tarball layer",
+		},
+		"unsupported digest": {
+			layers:      []ociDescriptor{{Digest: "sha512:00"}},
+			expectError: `unsupported layer digest "sha512:00"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			layer, err := (&ociManifest{Layers: testcase.layers}).packageLayer()
+			if testcase.expectError != "" {
+				if err == nil || err.Error() != testcase.expectError {
+					t.Errorf("expected error %q, got %v", testcase.expectError, err)
+				}
+				return
+			}
+			if err != nil || layer.Digest != digest {
+				t.Errorf("expected layer %s, got %#v, %v", digest, layer, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// offlineTransport is a http.RoundTripper that fails every request, so that no request reaches the Fastly API when
+// the provider is offline, even from code that doesn't check isOffline.
+type offlineTransport struct{}
+
+// RoundTrip implements http.RoundTripper.
+func (offlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("the provider is offline, so %s %s wasn't requested from the Fastly API", req.Method, req.URL.Path)
+}
+
+// isOffline returns whether the provider is configured with offline set, so that it makes no API requests.
+func isOffline(meta any) bool {
+	client, ok := meta.(*APIClient)
+	return ok && client.offline
+}
+
+// offlineResource makes a resource keep its state instead of refreshing it when the provider is offline, and fail
+// instead of making changes or importing.
+func offlineResource(resourceType string, r *schema.Resource) {
+	if read := r.ReadContext; read != nil {
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			if isOffline(meta) {
+				log.Printf("[DEBUG] Not refreshing %s (%s), the provider is offline", resourceType, d.Id())
+				return nil
+			}
+			return read(ctx, d, meta)
+		}
+	}
+
+	forbid := func(operation string, f schema.CreateContextFunc) schema.CreateContextFunc {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+			if isOffline(meta) {
+				return diag.Errorf("can't %s %s: the provider is offline, so it only validates configurations and plans", operation, resourceType)
+			}
+			return f(ctx, d, meta)
+		}
+	}
+	r.CreateContext = forbid("create", r.CreateContext)
+	r.UpdateContext = schema.UpdateContextFunc(forbid("update", schema.CreateContextFunc(r.UpdateContext)))
+	r.DeleteContext = schema.DeleteContextFunc(forbid("delete", schema.CreateContextFunc(r.DeleteContext)))
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta any) ([]*schema.ResourceData, error) {
+			if isOffline(meta) {
+				return nil, fmt.Errorf("can't import %s: the provider is offline", resourceType)
+			}
+			return importState(ctx, d, meta)
+		}
+	}
+}
+
+// offlineDataSource makes a data source fail with a clear message when the provider is offline, as it has no state to
+// fall back on.
+func offlineDataSource(dataSourceType string, r *schema.Resource) {
+	read := r.ReadContext
+	if read == nil {
+		return
+	}
+	r.ReadContext = func(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+		if isOffline(meta) {
+			return diag.Errorf("can't read %s: the provider is offline, and the data source needs the Fastly API", dataSourceType)
+		}
+		return read(ctx, d, meta)
+	}
+}
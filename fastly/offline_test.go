@@ -0,0 +1,51 @@
+package fastly
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOffline(t *testing.T) {
+	c := Config{
+		BaseURL: gofastly.DefaultEndpoint,
+		Offline: true,
+	}
+	client, diagnostics := c.Client()
+	if diagnostics.HasError() {
+		t.Fatalf("expected an offline client without an API key, got %s", diagToErr(diagnostics))
+	}
+	if _, err := client.connWithContext(context.Background()).GetCurrentUser(); err == nil || !strings.Contains(err.Error(), "the provider is offline") {
+		t.Errorf("expected requests to fail when offline, got %v", err)
+	}
+
+	p := Provider()
+	r := p.ResourcesMap["fastly_service_vcl"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]any{"name": "service"})
+	d.SetId("service-id")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Errorf("expected refreshing a resource to keep its state when offline, got %s", diagToErr(diags))
+	}
+	if d.Id() != "service-id" || d.Get("name") != "service" {
+		t.Errorf("expected the state to be kept, got %s, %s", d.Id(), d.Get("name"))
+	}
+	if diags := r.UpdateContext(context.Background(), d, client); !diags.HasError() || !strings.Contains(diagToErr(diags).Error(), "can't update fastly_service_vcl") {
+		t.Errorf("expected updating a resource to fail when offline, got %v", diags)
+	}
+	if _, err := r.Importer.StateContext(context.Background(), d, client); err == nil {
+		t.Error("expected importing a resource to fail when offline")
+	}
+
+	ds := p.DataSourcesMap["fastly_users"]
+	d = schema.TestResourceDataRaw(t, ds.Schema, map[string]any{})
+	if diags := ds.ReadContext(context.Background(), d, client); !diags.HasError() || !strings.Contains(diagToErr(diags).Error(), "can't read fastly_users") {
+		t.Errorf("expected reading a data source to fail when offline, got %v", diags)
+	}
+
+	if shields, fallback := client.shieldCodes(context.Background()); !fallback || len(shields) == 0 {
+		t.Error("expected shields to be checked against the built-in list when offline")
+	}
+}
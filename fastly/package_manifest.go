@@ -0,0 +1,337 @@
+package fastly
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// packageManifestName is the name of the manifest describing a Compute@Edge package.
+const packageManifestName = "fastly.toml"
+
+// packageManifestMaxSize limits how much of the manifest is read, so that a malformed package can't exhaust memory.
+const packageManifestMaxSize = 1 << 20
+
+// packageLanguages are the languages that can be set in a package manifest.
+var packageLanguages = []string{"assemblyscript", "go", "javascript", "other", "rust"}
+
+// packageManifest is the metadata read from the manifest of a package.
+type packageManifest struct {
+	Name     string
+	Language string
+	Authors  []string
+}
+
+// readPackageManifest returns the manifest of the package at filename. The Fastly CLI packages a project into a
+// directory, so the manifest is taken from the top level of the package or from the directory at its top level.
+func readPackageManifest(filename string) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("package isn't a gzipped tarball: %s", err)
+	}
+	defer gz.Close()
+
+	var manifest []byte
+	depth := 0
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("package isn't a gzipped tarball: %s", err)
+		}
+		name := path.Clean(strings.TrimPrefix(hdr.Name, "./"))
+		if hdr.Typeflag != tar.TypeReg || path.Base(name) != packageManifestName {
+			continue
+		}
+		d := strings.Count(name, "/")
+		if d > 1 || (manifest != nil && d >= depth) {
+			continue
+		}
+		if manifest, err = io.ReadAll(io.LimitReader(tr, packageManifestMaxSize)); err != nil {
+			return nil, fmt.Errorf("error reading %s: %s", name, err)
+		}
+		depth = d
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("package has no %s", packageManifestName)
+	}
+	return manifest, nil
+}
+
+// checkPackageManifest returns an error if the manifest of the package at filename is missing, or sets metadata that
+// would stop the package being deployed. A package that doesn't exist yet, e.g. because it is built during the apply, is
+// left for the upload to report. parsePackageManifest only understands a subset of TOML, so a manifest it can't parse
+// is left for the API to check too.
+func checkPackageManifest(filename string) error {
+	data, err := readPackageManifest(filename)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Printf("[DEBUG] Not checking the manifest of package %s, which doesn't exist yet", filename)
+			return nil
+		}
+		return fmt.Errorf("invalid package %s: %s", filename, err)
+	}
+	manifest, err := parsePackageManifest(data)
+	if err != nil {
+		log.Printf("[WARN] Not checking the %s of package %s, which couldn't be parsed: %s", packageManifestName, filename, err)
+		return nil
+	}
+	if problems := validatePackageManifest(manifest); len(problems) > 0 {
+		return fmt.Errorf("invalid package %s: %s: %s", filename, packageManifestName, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// parsePackageManifest returns the metadata set by the top-level name, language and authors keys of a manifest. Only
+// as much TOML as these keys need is understood: the values of other keys are skipped, and parsing stops at the first
+// table.
+func parsePackageManifest(data []byte) (*packageManifest, error) {
+	m := &packageManifest{}
+	s := &manifestScanner{src: string(data)}
+	for {
+		s.skipSpace(true)
+		if s.done() || s.peek() == '[' {
+			return m, nil
+		}
+
+		key, err := s.key()
+		if err != nil {
+			return nil, err
+		}
+		s.skipSpace(false)
+		if !s.consume('=') {
+			return nil, s.errorf("expected = after key %q", key)
+		}
+		s.skipSpace(false)
+
+		switch key {
+		case "name":
+			m.Name, err = s.string()
+		case "language":
+			m.Language, err = s.string()
+		case "authors":
+			m.Authors, err = s.stringArray()
+		default:
+			s.skipValue()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", key, err)
+		}
+
+		s.skipSpace(false)
+		if !s.done() && s.peek() != '\n' && s.peek() != '\r' && s.peek() != '#' {
+			return nil, s.errorf("unexpected content after the value of %q", key)
+		}
+	}
+}
+
+// validatePackageManifest returns the problems with the metadata of a package that would stop it being deployed.
+func validatePackageManifest(m *packageManifest) []string {
+	var problems []string
+	if strings.TrimSpace(m.Name) == "" {
+		problems = append(problems, "name must be set")
+	}
+	switch {
+	case m.Language == "":
+		problems = append(problems, "language must be set")
+	case !contains(packageLanguages, m.Language):
+		problems = append(problems, fmt.Sprintf("language %q isn't one of %s", m.Language, strings.Join(packageLanguages, ", ")))
+	}
+	for i, author := range m.Authors {
+		if strings.TrimSpace(author) == "" {
+			problems = append(problems, fmt.Sprintf("author %d is empty", i+1))
+		}
+	}
+	return problems
+}
+
+// manifestScanner reads the parts of a TOML document that are needed from a package manifest.
+type manifestScanner struct {
+	src  string
+	pos  int
+	line int
+}
+
+func (s *manifestScanner) done() bool { return s.pos >= len(s.src) }
+
+func (s *manifestScanner) peek() byte { return s.src[s.pos] }
+
+func (s *manifestScanner) consume(c byte) bool {
+	if !s.done() && s.peek() == c {
+		s.pos++
+		return true
+	}
+	return false
+}
+
+func (s *manifestScanner) errorf(format string, a ...any) error {
+	return fmt.Errorf("line %d: %s", s.line+1, fmt.Sprintf(format, a...))
+}
+
+// skipSpace skips spaces and tabs, and when newlines is set also newlines and comments.
+func (s *manifestScanner) skipSpace(newlines bool) {
+	for !s.done() {
+		switch c := s.peek(); {
+		case c == ' ' || c == '\t':
+			s.pos++
+		case newlines && (c == '\r' || c == '\n'):
+			if c == '\n' {
+				s.line++
+			}
+			s.pos++
+		case newlines && c == '#':
+			for !s.done() && s.peek() != '\n' {
+				s.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// key reads a bare or quoted key. Dotted keys are read whole, so they never match the keys of the metadata.
+func (s *manifestScanner) key() (string, error) {
+	if !s.done() && (s.peek() == '"' || s.peek() == '\'') {
+		return s.string()
+	}
+	start := s.pos
+	for !s.done() {
+		c := s.peek()
+		if c != '_' && c != '-' && c != '.' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			break
+		}
+		s.pos++
+	}
+	if s.pos == start {
+		return "", s.errorf("expected a key")
+	}
+	return s.src[start:s.pos], nil
+}
+
+// string reads a single-line basic or literal string.
+func (s *manifestScanner) string() (string, error) {
+	if s.done() || (s.peek() != '"' && s.peek() != '\'') {
+		return "", s.errorf("expected a string")
+	}
+	quote := s.peek()
+	if strings.HasPrefix(s.src[s.pos:], strings.Repeat(string(quote), 3)) {
+		return "", s.errorf("expected a single-line string")
+	}
+
+	start := s.pos
+	for s.pos++; !s.done(); s.pos++ {
+		switch s.peek() {
+		case '\n':
+			return "", s.errorf("unterminated string")
+		case '\\':
+			if quote == '"' {
+				s.pos++
+			}
+		case quote:
+			s.pos++
+			raw := s.src[start:s.pos]
+			if quote == '\'' {
+				return raw[1 : len(raw)-1], nil
+			}
+			v, err := strconv.Unquote(raw)
+			if err != nil {
+				return "", s.errorf("invalid string %s", raw)
+			}
+			return v, nil
+		}
+	}
+	return "", s.errorf("unterminated string")
+}
+
+// stringArray reads an array of strings, which may span lines.
+func (s *manifestScanner) stringArray() ([]string, error) {
+	if !s.consume('[') {
+		return nil, s.errorf("expected an array of strings")
+	}
+	values := []string{}
+	for {
+		s.skipSpace(true)
+		if s.consume(']') {
+			return values, nil
+		}
+		v, err := s.string()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		s.skipSpace(true)
+		if s.consume(']') {
+			return values, nil
+		}
+		if !s.consume(',') {
+			return nil, s.errorf("expected , or ] in array")
+		}
+	}
+}
+
+// skipValue skips a value of any type, including arrays and inline tables that span lines and multi-line strings.
+func (s *manifestScanner) skipValue() {
+	depth := 0
+	for !s.done() {
+		switch c := s.peek(); c {
+		case '"', '\'':
+			s.skipString(c)
+			continue
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		case '#':
+			if depth == 0 {
+				return
+			}
+			for !s.done() && s.peek() != '\n' {
+				s.pos++
+			}
+			continue
+		case '\n':
+			if depth <= 0 {
+				return
+			}
+			s.line++
+		}
+		s.pos++
+	}
+}
+
+// skipString skips a string of any kind that is quoted with quote.
+func (s *manifestScanner) skipString(quote byte) {
+	delim := string(quote)
+	if strings.HasPrefix(s.src[s.pos:], strings.Repeat(delim, 3)) {
+		delim = strings.Repeat(delim, 3)
+	}
+	for s.pos += len(delim); !s.done(); s.pos++ {
+		switch {
+		case s.peek() == '\\' && quote == '"':
+			s.pos++
+		case strings.HasPrefix(s.src[s.pos:], delim):
+			s.pos += len(delim)
+			return
+		case s.peek() == '\n':
+			s.line++
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package fastly
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadPackageManifest(t *testing.T) {
+	for _, filename := range []string{"test_fixtures/package/valid.tar.gz", "test_fixtures/package/valid2.tar.gz"} {
+		data, err := readPackageManifest(filename)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", filename, err)
+		}
+		m, err := parsePackageManifest(data)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", filename, err)
+		}
+		if m.Language != "rust" || !reflect.DeepEqual(m.Authors, []string{"fastly@fastly.com"}) {
+			t.Errorf("%s: unexpected manifest %#v", filename, m)
+		}
+		if problems := validatePackageManifest(m); len(problems) > 0 {
+			t.Errorf("%s: unexpected problems %v", filename, problems)
+		}
+	}
+
+	if _, err := readPackageManifest("test_fixtures/package/invalid.tar.gz"); err == nil || !strings.Contains(err.Error(), "has no fastly.toml") {
+		t.Errorf("expected a missing manifest to be reported, got %v", err)
+	}
+	if _, err := readPackageManifest("package_manifest.go"); err == nil || !strings.Contains(err.Error(), "isn't a gzipped tarball") {
+		t.Error("expected a file that isn't a tarball to be reported")
+	}
+}
+
+func TestParsePackageManifest(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		manifest       string
+		expected       *packageManifest
+		expectError    string
+		expectProblems []string
+	}{
+		"valid": {
+			manifest: `
+# This file describes a Fastly Compute@Edge package.
+manifest_version = 2
+name = 'edge-app' # literal string
+description = """
+A "multi-line" description
+name = "not the name"
+"""
+authors = [
+  "a@example.com", # first author
+  "b@example.com",
+]
+language = "rust"
+metadata = { tags = ["a", "b"] }
+
+[scripts]
+name = "ignored"
+`,
+			expected: &packageManifest{Name: "edge-app", Language: "rust", Authors: []string{"a@example.com", "b@example.com"}},
+		},
+		"no authors": {
+			manifest: "name = \"app\"\nlanguage = \"javascript\"\n",
+			expected: &packageManifest{Name: "app", Language: "javascript"},
+		},
+		"metadata missing": {
+			manifest:       "description = \"app\"\nauthors = []\n",
+			expected:       &packageManifest{Authors: []string{}},
+			expectProblems: []string{"name must be set", "language must be set"},
+		},
+		"unknown language and empty author": {
+			manifest:       "name = \"app\"\nlanguage = \"cobol\"\nauthors = [\"a@example.com\", \" \"]\n",
+			expected:       &packageManifest{Name: "app", Language: "cobol", Authors: []string{"a@example.com", " "}},
+			expectProblems: []string{`language "cobol" isn't one of assemblyscript, go, javascript, other, rust`, "author 2 is empty"},
+		},
+		"authors not an array": {
+			manifest:    "name = \"app\"\nauthors = \"a@example.com\"\n",
+			expectError: "invalid authors: line 2: expected an array of strings",
+		},
+		"authors not strings": {
+			manifest:    "authors = [1, 2]\n",
+			expectError: "invalid authors: line 1: expected a string",
+		},
+		"name not a string": {
+			manifest:    "name = 42\n",
+			expectError: "invalid name: line 1: expected a string",
+		},
+		"unterminated name": {
+			manifest:    "name = \"app\nlanguage = \"rust\"\n",
+			expectError: "invalid name: line 1: unterminated string",
+		},
+		"missing equals": {
+			manifest:    "name \"app\"\n",
+			expectError: `line 1: expected = after key "name"`,
+		},
+		"trailing content": {
+			manifest:    "language = \"rust\" \"go\"\n",
+			expectError: `line 1: unexpected content after the value of "language"`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m, err := parsePackageManifest([]byte(testcase.manifest))
+			if testcase.expectError != "" {
+				if err == nil || err.Error() != testcase.expectError {
+					t.Fatalf("expected error %q, got %v", testcase.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(m, testcase.expected) {
+				t.Errorf("expected %#v, got %#v", testcase.expected, m)
+			}
+			if problems := validatePackageManifest(m); !reflect.DeepEqual(problems, testcase.expectProblems) {
+				t.Errorf("expected problems %q, got %q", testcase.expectProblems, problems)
+			}
+		})
+	}
+}
+
+func TestCheckPackageManifest(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		manifest    string
+		expectError string
+	}{
+		"valid": {
+			manifest: "name = \"app\"\nlanguage = \"rust\"\n",
+		},
+		"invalid metadata": {
+			manifest:    "name = \"app\"\nlanguage = \"cobol\"\n",
+			expectError: `language "cobol" isn't one of`,
+		},
+		"not understood": {
+			manifest: "name = \"\"\"\napp\"\"\"\nlanguage = \"rust\"\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "package.tar.gz")
+			f, err := os.Create(filename)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gz := gzip.NewWriter(f)
+			tw := tar.NewWriter(gz)
+			if err := tw.WriteHeader(&tar.Header{Name: "app/fastly.toml", Mode: 0o600, Size: int64(len(testcase.manifest))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(testcase.manifest)); err != nil {
+				t.Fatal(err)
+			}
+			for _, c := range []interface{ Close() error }{tw, gz, f} {
+				if err := c.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err = checkPackageManifest(filename)
+			if testcase.expectError == "" && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			if testcase.expectError != "" && (err == nil || !strings.Contains(err.Error(), testcase.expectError)) {
+				t.Errorf("expected error containing %q, got %v", testcase.expectError, err)
+			}
+		})
+	}
+
+	if err := checkPackageManifest(filepath.Join(t.TempDir(), "missing.tar.gz")); err != nil {
+		t.Errorf("expected a package that doesn't exist yet not to be checked, got %s", err)
+	}
+}
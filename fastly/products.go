@@ -0,0 +1,108 @@
+package fastly
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+)
+
+// NOTE: go-fastly v6 has no client for the product enablement API, so the
+// requests are made directly with the client's JSON helpers, which still
+// return a *gofastly.HTTPError for failed requests.
+
+// products are the IDs of the products of the product enablement API.
+var products = []string{
+	"bot_management",
+	"brotli_compression",
+	ddosProtectionProduct,
+	"domain_inspector",
+	"fanout",
+	"image_optimizer",
+	"log_explorer_insights",
+	"ngwaf",
+	"origin_inspector",
+	"websockets",
+}
+
+// productEnablement is an entry of the product enablement API, e.g. for
+// /enabled-products/v1/ddos_protection/services/{service_id}.
+type productEnablement struct {
+	Product struct {
+		ID string `json:"id"`
+	} `json:"product"`
+	Service struct {
+		ID string `json:"id"`
+	} `json:"service"`
+	Configuration map[string]any `json:"configuration,omitempty"`
+}
+
+func productEnablementPath(product, serviceID string) string {
+	return fmt.Sprintf("/enabled-products/v1/%s/services/%s", product, serviceID)
+}
+
+// getProductEnablement returns whether product is enabled on a service. A
+// product that isn't enabled isn't an error.
+func getProductEnablement(conn *gofastly.Client, product, serviceID string) (bool, error) {
+	resp, err := conn.Get(productEnablementPath(product, serviceID), nil)
+	if err != nil {
+		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.IsNotFound() {
+			return false, nil
+		}
+		return false, err
+	}
+	defer resp.Body.Close()
+	return true, nil
+}
+
+// enableProduct enables product on a service.
+func enableProduct(conn *gofastly.Client, product, serviceID string) error {
+	resp, err := conn.Put(productEnablementPath(product, serviceID), nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// disableProduct disables product on a service. A product that isn't enabled
+// isn't an error.
+func disableProduct(conn *gofastly.Client, product, serviceID string) error {
+	resp, err := conn.Delete(productEnablementPath(product, serviceID), nil)
+	if err != nil {
+		if httpErr, ok := err.(*gofastly.HTTPError); ok && httpErr.IsNotFound() {
+			return nil
+		}
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// getProductConfiguration returns the configuration of a product enabled on a
+// service.
+func getProductConfiguration(conn *gofastly.Client, product, serviceID string) (map[string]any, error) {
+	resp, err := conn.Get(productEnablementPath(product, serviceID)+"/configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeProductConfiguration(resp)
+}
+
+// updateProductConfiguration updates the configuration of a product enabled
+// on a service, returning the new configuration.
+func updateProductConfiguration(conn *gofastly.Client, product, serviceID string, configuration map[string]any) (map[string]any, error) {
+	resp, err := conn.PatchJSON(productEnablementPath(product, serviceID)+"/configuration", configuration, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeProductConfiguration(resp)
+}
+
+func decodeProductConfiguration(resp *http.Response) (map[string]any, error) {
+	defer resp.Body.Close()
+	var e productEnablement
+	if err := json.NewDecoder(resp.Body).Decode(&e); err != nil {
+		return nil, fmt.Errorf("error decoding product configuration: %w", err)
+	}
+	return e.Configuration, nil
+}
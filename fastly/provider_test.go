@@ -1,9 +1,12 @@
 package fastly
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -21,6 +24,19 @@ func init() {
 	}
 }
 
+// testAPIClient returns a client for a Fastly API served by handler. The server is closed when the test finishes.
+func testAPIClient(t *testing.T, handler http.Handler) *APIClient {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	conn, err := gofastly.NewClientForEndpoint("someapikey", server.URL)
+	if err != nil {
+		t.Fatalf("failed to create client: %s", err)
+	}
+	return &APIClient{conn: conn, apiKey: "someapikey"}
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -0,0 +1,83 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourcePurgeAll purges everything cached for a service when it's created, and so whenever its triggers change.
+// There's nothing to read back or delete, so it only exists in the state.
+func resourcePurgeAll() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePurgeAllCreate,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: resourcePurgeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"confirm": {
+				Type:             schema.TypeBool,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Must be `true`, to confirm that everything cached for the service should be purged. Every request will go to the origin until the cache is filled again.",
+				ValidateDiagFunc: validation.ToDiagFunc(validatePurgeConfirmed),
+			},
+			"purged_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp (GMT) when the service was purged.",
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to purge.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that purge the service again whenever they change, e.g. the version of a release.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePurgeAllCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+	serviceID := d.Get("service_id").(string)
+
+	log.Printf("[DEBUG] Purging everything from service (%s)", serviceID)
+
+	if _, err := conn.PurgeAll(&gofastly.PurgeAllInput{ServiceID: serviceID}); err != nil {
+		return diag.Errorf("error purging everything from service (%s): %s", serviceID, err)
+	}
+
+	d.SetId(resource.UniqueId())
+	if err := d.Set("purged_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourcePurgeDelete removes a purge from the state. A purge can't be undone.
+func resourcePurgeDelete(_ context.Context, d *schema.ResourceData, _ any) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func validatePurgeConfirmed(val any, key string) ([]string, []error) {
+	if !val.(bool) {
+		return nil, []error{fmt.Errorf("%s must be true to purge", key)}
+	}
+	return nil, nil
+}
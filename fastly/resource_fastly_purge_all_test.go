@@ -0,0 +1,63 @@
+package fastly
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourcePurgeAll(t *testing.T) {
+	var purges int
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/service/service-id/purge_all" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		purges++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "ok"}`))
+	}))
+
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourcePurgeAll().Schema, map[string]any{
+		"service_id": "service-id",
+		"confirm":    true,
+		"triggers":   map[string]any{"release": "v1"},
+	})
+	if diags := resourcePurgeAllCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error creating: %s", diagToErr(diags))
+	}
+	if purges != 1 || d.Id() == "" || d.Get("purged_at") == "" {
+		t.Fatalf("expected the service to be purged once, got %d purges, ID %q", purges, d.Id())
+	}
+
+	state := d.State()
+
+	if diags := resourcePurgeDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error deleting: %s", diagToErr(diags))
+	}
+	if purges != 1 {
+		t.Errorf("expected deleting not to purge, got %d purges", purges)
+	}
+
+	diff, err := resourcePurgeAll().Diff(ctx, state, terraform.NewResourceConfigRaw(map[string]any{
+		"service_id": "service-id",
+		"confirm":    true,
+		"triggers":   map[string]any{"release": "v2"},
+	}), meta)
+	if err != nil {
+		t.Fatalf("unexpected error diffing: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Error("expected a change to triggers to purge again")
+	}
+
+	if _, errs := validatePurgeConfirmed(false, "confirm"); len(errs) == 0 {
+		t.Error("expected confirm = false to be rejected")
+	}
+}
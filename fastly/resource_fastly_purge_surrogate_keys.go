@@ -0,0 +1,112 @@
+package fastly
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxPurgeKeys is the most surrogate keys the API purges in a single request.
+const maxPurgeKeys = 256
+
+// resourcePurgeSurrogateKeys purges the content tagged with surrogate keys when it's created, and so whenever its keys
+// or triggers change. Like resourcePurgeAll, it only exists in the state.
+func resourcePurgeSurrogateKeys() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePurgeSurrogateKeysCreate,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: resourcePurgeDelete,
+
+		Schema: map[string]*schema.Schema{
+			"keys": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "The surrogate keys to purge the content tagged with.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"purge_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The ID of the purge of each of the keys.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"purged_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp (GMT) when the keys were purged.",
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to purge.",
+			},
+			"soft": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Mark the content as stale instead of removing it, so that it can still be served while it's revalidated, or if the origin is down. Default `false`",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that purge the keys again whenever they change, e.g. the version of a release.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePurgeSurrogateKeysCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+	serviceID := d.Get("service_id").(string)
+
+	var keys []string
+	for _, k := range d.Get("keys").(*schema.Set).List() {
+		keys = append(keys, k.(string))
+	}
+	sort.Strings(keys)
+
+	log.Printf("[DEBUG] Purging %d surrogate keys from service (%s)", len(keys), serviceID)
+
+	purgeIDs := make(map[string]string, len(keys))
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > maxPurgeKeys {
+			batch = batch[:maxPurgeKeys]
+		}
+		keys = keys[len(batch):]
+
+		ids, err := conn.PurgeKeys(&gofastly.PurgeKeysInput{
+			ServiceID: serviceID,
+			Keys:      batch,
+			Soft:      d.Get("soft").(bool),
+		})
+		if err != nil {
+			return diag.Errorf("error purging surrogate keys from service (%s): %s", serviceID, err)
+		}
+		for key, id := range ids {
+			purgeIDs[key] = id
+		}
+	}
+
+	d.SetId(resource.UniqueId())
+	if err := d.Set("purge_ids", purgeIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("purged_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourcePurgeSurrogateKeys(t *testing.T) {
+	var batches [][]string
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/service/service-id/purge" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("Fastly-Soft-Purge") != "1" {
+			t.Error("expected a soft purge")
+		}
+		keys := strings.Fields(r.Header.Get("Surrogate-Key"))
+		batches = append(batches, keys)
+
+		ids := map[string]string{}
+		for _, key := range keys {
+			ids[key] = "purge-" + key
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ids)
+	}))
+
+	keys := make([]any, 300)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%03d", i)
+	}
+	d := schema.TestResourceDataRaw(t, resourcePurgeSurrogateKeys().Schema, map[string]any{
+		"service_id": "service-id",
+		"keys":       keys,
+		"soft":       true,
+	})
+	if diags := resourcePurgeSurrogateKeysCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error creating: %s", diagToErr(diags))
+	}
+
+	if len(batches) != 2 || len(batches[0]) != maxPurgeKeys || len(batches[1]) != 300-maxPurgeKeys {
+		t.Fatalf("expected the keys to be purged in batches of %d, got %d batches", maxPurgeKeys, len(batches))
+	}
+	purgeIDs := d.Get("purge_ids").(map[string]any)
+	if len(purgeIDs) != 300 || purgeIDs["key-299"] != "purge-key-299" {
+		t.Errorf("expected the purge ID of each key, got %d", len(purgeIDs))
+	}
+}
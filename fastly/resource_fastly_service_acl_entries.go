@@ -1,15 +1,24 @@
 package fastly
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/fastly/terraform-provider-fastly/fastly/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,6 +31,10 @@ func resourceServiceACLEntries() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceACLEntriesImport,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeACLEntriesDiff,
+			customizeACLEntriesFileDiff,
+		),
 		Schema: map[string]*schema.Schema{
 			"acl_id": {
 				Type:        schema.TypeString,
@@ -30,10 +43,12 @@ func resourceServiceACLEntries() *schema.Resource {
 				Description: "The ID of the ACL that the items belong to",
 			},
 			"entry": {
-				Type:        schema.TypeSet,
-				Optional:    true,
-				Description: "ACL Entries",
-				MaxItems:    gofastly.MaximumACLSize,
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "ACL Entries",
+				MaxItems:      gofastly.MaximumACLSize,
+				Set:           hashACLEntry,
+				ConflictsWith: []string{"entries_file"},
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return !d.HasChange("acl_id") && !d.Get("manage_entries").(bool)
 				},
@@ -50,9 +65,11 @@ func resourceServiceACLEntries() *schema.Resource {
 							Computed:    true,
 						},
 						"ip": {
-							Type:        schema.TypeString,
-							Description: "An IP address that is the focus for the ACL",
-							Required:    true,
+							Type:             schema.TypeString,
+							Description:      "An IP address that is the focus for the ACL. A subnet can also be given in CIDR notation (e.g. `10.0.0.0/8`) instead of using `subnet`",
+							Required:         true,
+							ValidateDiagFunc: validateACLEntryIP(),
+							DiffSuppressFunc: suppressEquivalentACLEntry,
 						},
 						"negated": {
 							Type:        schema.TypeBool,
@@ -61,13 +78,31 @@ func resourceServiceACLEntries() *schema.Resource {
 							Description: "A boolean that will negate the match if true",
 						},
 						"subnet": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "An optional subnet mask applied to the IP address",
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "An optional subnet mask applied to the IP address",
+							ValidateDiagFunc: validateACLEntrySubnet(),
+							DiffSuppressFunc: suppressEquivalentACLEntry,
 						},
 					},
 				},
 			},
+			"entries_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path to a file containing an IP address or CIDR (e.g. `10.0.0.0/8`) on each line, used instead of `entry`. Blank lines and lines starting with `#` are ignored",
+				ConflictsWith: []string{"entry"},
+			},
+			"entries_file_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A SHA256 hash of the entries when `entries_file` is used. This changes whenever the entries in the file differ from the entries in the ACL",
+			},
+			"entries_file_summary": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A summary of how many entries are added and removed when the entries in the ACL are updated from `entries_file`, e.g. `3 to add, 1 to remove`. It is recomputed when the state is refreshed",
+			},
 			"manage_entries": {
 				Type:        schema.TypeBool,
 				Default:     false,
@@ -85,15 +120,26 @@ func resourceServiceACLEntries() *schema.Resource {
 }
 
 func resourceServiceACLEntriesCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	aclID := d.Get("acl_id").(string)
-	entries := d.Get("entry").(*schema.Set)
+	entries := d.Get("entry").(*schema.Set).List()
+
+	if path, ok := d.GetOk("entries_file"); ok {
+		fileEntries, err := readACLEntriesFile(path.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		entries = nil
+		for _, e := range fileEntries {
+			entries = append(entries, e)
+		}
+	}
 
 	batchACLEntries := []*gofastly.BatchACLEntry{}
 
-	for _, vRaw := range entries.List() {
+	for _, vRaw := range entries {
 		val := vRaw.(map[string]any)
 
 		entry := buildBatchACLEntry(val, gofastly.CreateBatchOperation)
@@ -110,10 +156,10 @@ func resourceServiceACLEntriesCreate(ctx context.Context, d *schema.ResourceData
 	return resourceServiceACLEntriesRead(ctx, d, meta)
 }
 
-func resourceServiceACLEntriesRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceServiceACLEntriesRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Print("[DEBUG] Refreshing ACL Entries Configuration")
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	aclID := d.Get("acl_id").(string)
@@ -126,6 +172,26 @@ func resourceServiceACLEntriesRead(_ context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
+	// When the entries come from a file only their hash is kept in state, so
+	// that plans aren't filled with every entry in the file. The summary is
+	// recomputed so that it reflects changes made outside of Terraform.
+	if path, ok := d.GetOk("entries_file"); ok {
+		if err := d.Set("entry", nil); err != nil {
+			return diag.FromErr(err)
+		}
+		remoteEntries := keyACLEntries(flattenACLEntries(aclEntries))
+		if err := d.Set("entries_file_hash", hashACLEntries(remoteEntries)); err != nil {
+			return diag.FromErr(err)
+		}
+		var summary string
+		if fileEntries, err := readACLEntriesFile(path.(string)); err == nil {
+			summary = summarizeACLEntriesFile(remoteEntries, fileEntries)
+		} else {
+			log.Printf("[WARN] Not summarizing the changes to ACL entries: %s", err)
+		}
+		return diag.FromErr(d.Set("entries_file_summary", summary))
+	}
+
 	err = d.Set("entry", flattenACLEntries(aclEntries))
 	if err != nil {
 		return diag.FromErr(err)
@@ -135,14 +201,40 @@ func resourceServiceACLEntriesRead(_ context.Context, d *schema.ResourceData, me
 }
 
 func resourceServiceACLEntriesUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	aclID := d.Get("acl_id").(string)
 
 	batchACLEntries := []*gofastly.BatchACLEntry{}
 
-	if d.HasChange("entry") {
+	if path, ok := d.GetOk("entries_file"); ok {
+		if d.HasChanges("entries_file", "entries_file_hash") {
+			// The entries from the file aren't kept in state, so compare them
+			// with the entries currently in the ACL.
+			remoteEntries, err := listACLEntries(conn, serviceID, aclID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			fileEntries, err := readACLEntriesFile(path.(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			batchACLEntries = buildBatchACLEntriesFromFile(remoteEntries, fileEntries)
+		}
+	} else if d.HasChange("entries_file") {
+		// The entries weren't kept in state as they previously came from a
+		// file, so compare them with the entries currently in the ACL.
+		remoteEntries, err := listACLEntries(conn, serviceID, aclID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		var oldEntries []any
+		for _, e := range remoteEntries {
+			oldEntries = append(oldEntries, e)
+		}
+		batchACLEntries = buildBatchACLEntriesDelta(oldEntries, d.Get("entry").(*schema.Set).List())
+	} else if d.HasChange("entry") {
 		oe, ne := d.GetChange("entry")
 
 		if oe == nil {
@@ -152,47 +244,7 @@ func resourceServiceACLEntriesUpdate(ctx context.Context, d *schema.ResourceData
 			ne = new(schema.Set)
 		}
 
-		oldSet := oe.(*schema.Set)
-		newSet := ne.(*schema.Set)
-
-		setDiff := NewSetDiff(func(resource any) (any, error) {
-			t, ok := resource.(map[string]any)
-			if !ok {
-				return nil, fmt.Errorf("resource failed to be type asserted: %+v", resource)
-			}
-			return t["id"], nil
-		})
-
-		diffResult, err := setDiff.Diff(oldSet, newSet)
-		if err != nil {
-			return diag.FromErr(err)
-		}
-
-		// DELETE removed resources
-		for _, resource := range diffResult.Deleted {
-			resource := resource.(map[string]any)
-
-			batchACLEntries = append(batchACLEntries, &gofastly.BatchACLEntry{
-				Operation: gofastly.DeleteBatchOperation,
-				ID:        gofastly.String(resource["id"].(string)),
-			})
-		}
-
-		// CREATE new resources
-		for _, resource := range diffResult.Added {
-			resource := resource.(map[string]any)
-
-			entry := buildBatchACLEntry(resource, gofastly.CreateBatchOperation)
-			batchACLEntries = append(batchACLEntries, entry)
-		}
-
-		// UPDATE modified resources
-		for _, resource := range diffResult.Modified {
-			resource := resource.(map[string]any)
-
-			entry := buildBatchACLEntry(resource, gofastly.UpdateBatchOperation)
-			batchACLEntries = append(batchACLEntries, entry)
-		}
+		batchACLEntries = buildBatchACLEntriesDelta(oe.(*schema.Set).List(), ne.(*schema.Set).List())
 	}
 
 	// Process the batch operations
@@ -204,16 +256,27 @@ func resourceServiceACLEntriesUpdate(ctx context.Context, d *schema.ResourceData
 	return resourceServiceACLEntriesRead(ctx, d, meta)
 }
 
-func resourceServiceACLEntriesDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceServiceACLEntriesDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	aclID := d.Get("acl_id").(string)
-	entries := d.Get("entry").(*schema.Set)
+	entries := d.Get("entry").(*schema.Set).List()
+
+	if _, ok := d.GetOk("entries_file"); ok {
+		remoteEntries, err := listACLEntries(conn, serviceID, aclID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		entries = nil
+		for _, e := range remoteEntries {
+			entries = append(entries, e)
+		}
+	}
 
 	batchACLEntries := []*gofastly.BatchACLEntry{}
 
-	for _, vRaw := range entries.List() {
+	for _, vRaw := range entries {
 		val := vRaw.(map[string]any)
 
 		batchACLEntries = append(batchACLEntries, &gofastly.BatchACLEntry{
@@ -307,23 +370,385 @@ func executeBatchACLOperations(conn *gofastly.Client, serviceID, aclID string, b
 }
 
 func buildBatchACLEntry(v map[string]any, op gofastly.BatchOperation) *gofastly.BatchACLEntry {
+	ip, subnet := v["ip"].(string), v["subnet"].(string)
+	// Entries are validated when planning, so this only fails when the values
+	// weren't known then; leave it to the API to report the problem.
+	if normalizedIP, normalizedSubnet, err := normalizeACLEntry(ip, subnet); err == nil {
+		ip, subnet = normalizedIP, normalizedSubnet
+	}
+
 	entry := &gofastly.BatchACLEntry{
 		Operation: op,
 		ID:        gofastly.String(v["id"].(string)),
-		IP:        gofastly.String(v["ip"].(string)),
+		IP:        gofastly.String(ip),
 		Negated:   gofastly.CBool(v["negated"].(bool)),
 		Comment:   gofastly.String(v["comment"].(string)),
 	}
 
-	subnet := convertSubnetToInt(v["subnet"].(string))
-	// only set zero subnet if the attribute is explicitly set
-	if v["subnet"].(string) == "0" || subnet != 0 {
-		entry.Subnet = gofastly.Int(subnet)
+	// only set the subnet (which may be zero) if the attribute is explicitly set
+	if subnet != "" {
+		entry.Subnet = gofastly.Int(convertSubnetToInt(subnet))
 	}
 
 	return entry
 }
 
+// normalizeACLEntry returns the canonical form of an ACL entry's IP address and
+// subnet, so that equivalent entries (e.g. `10.0.0.1`, `10.0.0.1/32` and
+// `10.0.0.1` with a subnet of `32`) compare equal. The IP address may use CIDR
+// notation, in which case the subnet must not also be given. A subnet covering
+// the whole address is the same as no subnet, so is normalized to "".
+func normalizeACLEntry(ip, subnet string) (string, string, error) {
+	if i := strings.Index(ip, "/"); i != -1 {
+		if subnet != "" {
+			return "", "", fmt.Errorf("subnet must not be set when ip (%s) uses CIDR notation", ip)
+		}
+		ip, subnet = ip[:i], ip[i+1:]
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", "", fmt.Errorf("%q is not a valid IP address", ip)
+	}
+	bits := net.IPv6len * 8
+	if parsed.To4() != nil {
+		bits = net.IPv4len * 8
+	}
+
+	if subnet == "" {
+		return parsed.String(), "", nil
+	}
+	n, err := strconv.Atoi(subnet)
+	if err != nil || n < 0 || n > bits {
+		return "", "", fmt.Errorf("subnet %q is not valid for %s, expected a number between 0 and %d", subnet, ip, bits)
+	}
+	if n == bits {
+		return parsed.String(), "", nil
+	}
+	return parsed.String(), strconv.Itoa(n), nil
+}
+
+// hashACLEntry hashes an ACL entry using the canonical form of its IP address
+// and subnet, so that equivalent entries are treated as the same set element.
+func hashACLEntry(v any) int {
+	m := v.(map[string]any)
+	ip, subnet := m["ip"].(string), m["subnet"].(string)
+	if normalizedIP, normalizedSubnet, err := normalizeACLEntry(ip, subnet); err == nil {
+		ip, subnet = normalizedIP, normalizedSubnet
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s/%s-", ip, subnet))
+	buf.WriteString(fmt.Sprintf("%t-", m["negated"].(bool)))
+	buf.WriteString(fmt.Sprintf("%s-", m["comment"].(string)))
+	return hashcode.String(buf.String())
+}
+
+// suppressEquivalentACLEntry suppresses differences in the ip and subnet of an
+// ACL entry when the old and new entries normalize to the same values.
+func suppressEquivalentACLEntry(k, _, _ string, d *schema.ResourceData) bool {
+	prefix := k[:strings.LastIndex(k, ".")+1]
+	oldIP, newIP := d.GetChange(prefix + "ip")
+	oldSubnet, newSubnet := d.GetChange(prefix + "subnet")
+
+	oIP, oSubnet, err := normalizeACLEntry(oldIP.(string), oldSubnet.(string))
+	if err != nil {
+		return false
+	}
+	nIP, nSubnet, err := normalizeACLEntry(newIP.(string), newSubnet.(string))
+	if err != nil {
+		return false
+	}
+	return oIP == nIP && oSubnet == nSubnet
+}
+
+// customizeACLEntriesDiff validates each entry's IP address and subnet
+// together, and reports entries that match the same addresses as another entry
+// as the API would reject them when the entries are applied.
+func customizeACLEntriesDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	seen := map[string]string{}
+	for _, e := range d.Get("entry").(*schema.Set).List() {
+		e := e.(map[string]any)
+		ip, subnet := e["ip"].(string), e["subnet"].(string)
+		// The IP address may not be known yet, e.g. if it comes from another resource.
+		if ip == "" {
+			continue
+		}
+
+		key, err := aclEntryKey(ip, subnet, false)
+		if err != nil {
+			return fmt.Errorf("invalid ACL entry: %w", err)
+		}
+
+		original := ip
+		if subnet != "" {
+			original += " (subnet " + subnet + ")"
+		}
+		if other, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate ACL entry: %s and %s both match %s", other, original, key)
+		}
+		seen[key] = original
+	}
+	return nil
+}
+
+// customizeACLEntriesFileDiff loads the entries from entries_file, if set, and
+// plans an update when they differ from the entries in the ACL, summarizing how
+// many entries will be added and removed. As with entry, changes are only
+// planned after creation if manage_entries is set.
+func customizeACLEntriesFileDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	path, ok := d.GetOk("entries_file")
+	if !ok || !d.NewValueKnown("entries_file") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("entries_file") && !d.HasChange("acl_id") && !d.Get("manage_entries").(bool) {
+		return nil
+	}
+
+	fileEntries, err := readACLEntriesFile(path.(string))
+	if err != nil {
+		return err
+	}
+	if len(fileEntries) > gofastly.MaximumACLSize {
+		return fmt.Errorf("expected %s to contain at most (%d) entries, got %d", path, gofastly.MaximumACLSize, len(fileEntries))
+	}
+
+	hash := hashACLEntries(fileEntries)
+	if hash == d.Get("entries_file_hash").(string) {
+		return nil
+	}
+
+	if d.Id() != "" && isOffline(meta) {
+		// The changes to the entries can't be counted without the entries in the ACL.
+		if err := d.SetNew("entries_file_hash", hash); err != nil {
+			return err
+		}
+		return d.SetNewComputed("entries_file_summary")
+	}
+
+	remoteEntries := map[string]map[string]any{}
+	if d.Id() != "" && !d.HasChange("acl_id") {
+		conn := meta.(*APIClient).connWithContext(ctx)
+		remoteEntries, err = listACLEntries(conn, d.Get("service_id").(string), d.Get("acl_id").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := d.SetNew("entries_file_hash", hash); err != nil {
+		return err
+	}
+	return d.SetNew("entries_file_summary", summarizeACLEntriesFile(remoteEntries, fileEntries))
+}
+
+// summarizeACLEntriesFile returns how many entries are added and removed when
+// the entries in the ACL are replaced with the entries from a file.
+func summarizeACLEntriesFile(remoteEntries, fileEntries map[string]map[string]any) string {
+	var add, remove int
+	for _, e := range buildBatchACLEntriesFromFile(remoteEntries, fileEntries) {
+		if e.Operation == gofastly.CreateBatchOperation {
+			add++
+		} else {
+			remove++
+		}
+	}
+	return fmt.Sprintf("%d to add, %d to remove", add, remove)
+}
+
+// aclEntryKey returns a key identifying the addresses an ACL entry matches,
+// such that equivalent entries have the same key.
+func aclEntryKey(ip, subnet string, negated bool) (string, error) {
+	normalizedIP, normalizedSubnet, err := normalizeACLEntry(ip, subnet)
+	if err != nil {
+		return "", err
+	}
+
+	key := normalizedIP
+	if normalizedSubnet != "" {
+		key += "/" + normalizedSubnet
+	}
+	if negated {
+		key = "!" + key
+	}
+	return key, nil
+}
+
+// keyACLEntries returns the entries keyed by aclEntryKey.
+func keyACLEntries(entries []map[string]any) map[string]map[string]any {
+	result := make(map[string]map[string]any)
+	for _, e := range entries {
+		subnet, _ := e["subnet"].(string)
+		negated, _ := e["negated"].(bool)
+		key, err := aclEntryKey(e["ip"].(string), subnet, negated)
+		if err != nil {
+			// Entries that can't be normalized are kept so that they are
+			// still replaced by the entries from the file.
+			key = fmt.Sprintf("%s/%s", e["ip"], subnet)
+		}
+		result[key] = e
+	}
+	return result
+}
+
+// readACLEntriesFile reads ACL entries from a file containing an IP address or
+// CIDR on each line, keyed by aclEntryKey.
+func readACLEntriesFile(path string) (map[string]map[string]any, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading ACL entries: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	entries := make(map[string]map[string]any)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		ip, subnet, err := normalizeACLEntry(text, "")
+		if err != nil {
+			return nil, fmt.Errorf("error reading ACL entries from %s on line %d: %w", path, line, err)
+		}
+		key, _ := aclEntryKey(ip, subnet, false)
+		if _, ok := entries[key]; ok {
+			return nil, fmt.Errorf("error reading ACL entries from %s on line %d: %s is a duplicate", path, line, key)
+		}
+		entries[key] = map[string]any{
+			"id":      "",
+			"ip":      ip,
+			"subnet":  subnet,
+			"negated": false,
+			"comment": "",
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading ACL entries from %s: %w", path, err)
+	}
+
+	return entries, nil
+}
+
+// hashACLEntries returns a SHA256 hash of the keys of the entries which doesn't
+// depend on the order of the entries.
+func hashACLEntries(entries map[string]map[string]any) string {
+	keys := make([]string, 0, len(entries))
+	for k := range entries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(keys, "\n"))))
+}
+
+// listACLEntries returns the entries currently in the ACL, keyed by aclEntryKey.
+func listACLEntries(conn *gofastly.Client, serviceID, aclID string) (map[string]map[string]any, error) {
+	aclEntries, err := conn.ListACLEntries(&gofastly.ListACLEntriesInput{
+		ServiceID: serviceID,
+		ACLID:     aclID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keyACLEntries(flattenACLEntries(aclEntries)), nil
+}
+
+// buildBatchACLEntriesFromFile returns the batch operations that replace the
+// entries in the ACL with the entries from a file.
+func buildBatchACLEntriesFromFile(remoteEntries, fileEntries map[string]map[string]any) []*gofastly.BatchACLEntry {
+	batchACLEntries := []*gofastly.BatchACLEntry{}
+
+	for key, e := range remoteEntries {
+		if _, ok := fileEntries[key]; !ok {
+			batchACLEntries = append(batchACLEntries, &gofastly.BatchACLEntry{
+				Operation: gofastly.DeleteBatchOperation,
+				ID:        gofastly.String(e["id"].(string)),
+			})
+		}
+	}
+	for key, e := range fileEntries {
+		if _, ok := remoteEntries[key]; !ok {
+			batchACLEntries = append(batchACLEntries, buildBatchACLEntry(e, gofastly.CreateBatchOperation))
+		}
+	}
+
+	return batchACLEntries
+}
+
+// buildBatchACLEntriesDelta returns the batch operations that change the entries
+// in oldEntries, which have IDs, into newEntries. Entries are matched by the
+// addresses they match, so only entries for new addresses are created and only
+// entries for addresses that are no longer listed are deleted. Entries whose
+// negated or comment changed are updated in place, and the rest are left alone.
+func buildBatchACLEntriesDelta(oldEntries, newEntries []any) []*gofastly.BatchACLEntry {
+	// Entries listed from the API leave out attributes that are empty.
+	key := func(e map[string]any) string {
+		ip, _ := e["ip"].(string)
+		subnet, _ := e["subnet"].(string)
+		if k, err := aclEntryKey(ip, subnet, false); err == nil {
+			return k
+		}
+		return fmt.Sprintf("%s/%s", ip, subnet)
+	}
+
+	oldByKey := make(map[string]map[string]any, len(oldEntries))
+	for _, e := range oldEntries {
+		e := e.(map[string]any)
+		oldByKey[key(e)] = e
+	}
+	newByKey := make(map[string]map[string]any, len(newEntries))
+	for _, e := range newEntries {
+		e := e.(map[string]any)
+		newByKey[key(e)] = e
+	}
+
+	keys := make([]string, 0, len(oldByKey)+len(newByKey))
+	for k := range oldByKey {
+		keys = append(keys, k)
+	}
+	for k := range newByKey {
+		if _, ok := oldByKey[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var deletes, updates, creates []*gofastly.BatchACLEntry
+	for _, k := range keys {
+		o, inOld := oldByKey[k]
+		n, inNew := newByKey[k]
+		switch {
+		case !inNew:
+			deletes = append(deletes, &gofastly.BatchACLEntry{
+				Operation: gofastly.DeleteBatchOperation,
+				ID:        gofastly.String(o["id"].(string)),
+			})
+		case !inOld:
+			creates = append(creates, buildBatchACLEntry(n, gofastly.CreateBatchOperation))
+		case changedACLEntry(o, n):
+			updated := make(map[string]any, len(n))
+			for attr, v := range n {
+				updated[attr] = v
+			}
+			updated["id"] = o["id"]
+			updates = append(updates, buildBatchACLEntry(updated, gofastly.UpdateBatchOperation))
+		}
+	}
+
+	// Deletes go first so that the ACL doesn't exceed its maximum size part way through.
+	return append(append(deletes, updates...), creates...)
+}
+
+// changedACLEntry returns whether the attributes of an ACL entry other than the
+// addresses it matches differ.
+func changedACLEntry(o, n map[string]any) bool {
+	oNegated, _ := o["negated"].(bool)
+	nNegated, _ := n["negated"].(bool)
+	oComment, _ := o["comment"].(string)
+	nComment, _ := n["comment"].(string)
+	return oNegated != nNegated || oComment != nComment
+}
+
 func convertSubnetToInt(s string) int {
 	subnet, _ := strconv.Atoi(s)
 	return subnet
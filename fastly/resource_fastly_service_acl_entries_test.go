@@ -1,10 +1,15 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -62,6 +67,336 @@ func TestResourceFastlyFlattenAclEntries(t *testing.T) {
 	}
 }
 
+func TestNormalizeACLEntry(t *testing.T) {
+	for _, testcase := range []struct {
+		ip             string
+		subnet         string
+		expectedIP     string
+		expectedSubnet string
+		expectError    bool
+	}{
+		{ip: "10.0.0.1", expectedIP: "10.0.0.1"},
+		{ip: "10.0.0.1", subnet: "32", expectedIP: "10.0.0.1"},
+		{ip: "10.0.0.1/32", expectedIP: "10.0.0.1"},
+		{ip: "10.0.0.0", subnet: "8", expectedIP: "10.0.0.0", expectedSubnet: "8"},
+		{ip: "10.0.0.0/8", expectedIP: "10.0.0.0", expectedSubnet: "8"},
+		{ip: "10.0.0.0", subnet: "0", expectedIP: "10.0.0.0", expectedSubnet: "0"},
+		{ip: "2001:DB8:0:0::1", expectedIP: "2001:db8::1"},
+		{ip: "2001:db8::", subnet: "32", expectedIP: "2001:db8::", expectedSubnet: "32"},
+		{ip: "2001:db8::1/128", expectedIP: "2001:db8::1"},
+		{ip: "10.0.0.0/8", subnet: "8", expectError: true},
+		{ip: "10.0.0.0", subnet: "33", expectError: true},
+		{ip: "10.0.0.0", subnet: "abc", expectError: true},
+		{ip: "2001:db8::", subnet: "129", expectError: true},
+		{ip: "10.0.0.256", expectError: true},
+		{ip: "example.com", expectError: true},
+	} {
+		ip, subnet, err := normalizeACLEntry(testcase.ip, testcase.subnet)
+		if testcase.expectError {
+			if err == nil {
+				t.Errorf("expected an error for ip %q and subnet %q", testcase.ip, testcase.subnet)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for ip %q and subnet %q: %s", testcase.ip, testcase.subnet, err)
+			continue
+		}
+		if ip != testcase.expectedIP || subnet != testcase.expectedSubnet {
+			t.Errorf("expected %q and %q for ip %q and subnet %q, got %q and %q", testcase.expectedIP, testcase.expectedSubnet, testcase.ip, testcase.subnet, ip, subnet)
+		}
+	}
+}
+
+func TestResourceFastlyServiceACLEntries_equivalentEntriesHaveNoDiff(t *testing.T) {
+	r := resourceServiceACLEntries()
+
+	d := r.Data(nil)
+	d.SetId("service-id/acl-id")
+	for k, v := range map[string]any{
+		"service_id":     "service-id",
+		"acl_id":         "acl-id",
+		"manage_entries": true,
+		"entry": []map[string]any{
+			{"id": "1", "ip": "10.0.0.1", "negated": false},
+			{"id": "2", "ip": "10.0.0.0", "subnet": "8", "negated": false},
+			{"id": "3", "ip": "2001:db8::1", "negated": false},
+		},
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("failed to set %s: %s", k, err)
+		}
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"service_id":     "service-id",
+		"acl_id":         "acl-id",
+		"manage_entries": true,
+		"entry": []any{
+			map[string]any{"ip": "10.0.0.1/32"},
+			map[string]any{"ip": "10.0.0.0/8"},
+			map[string]any{"ip": "2001:DB8:0::1", "subnet": "128"},
+		},
+	})
+
+	diff, err := r.Diff(context.Background(), d.State(), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expected no diff for equivalent entries, got %#v", diff.Attributes)
+	}
+}
+
+func TestResourceFastlyServiceACLEntries_duplicateEntries(t *testing.T) {
+	r := resourceServiceACLEntries()
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"service_id": "service-id",
+		"acl_id":     "acl-id",
+		"entry": []any{
+			map[string]any{"ip": "10.0.0.0/8"},
+			map[string]any{"ip": "10.0.0.0", "subnet": "8", "comment": "same network"},
+		},
+	})
+
+	_, err := r.Diff(context.Background(), nil, config, nil)
+	if err == nil || !strings.Contains(err.Error(), "duplicate ACL entry") {
+		t.Errorf("expected a duplicate ACL entry error, got %v", err)
+	}
+}
+
+func TestReadACLEntriesFile(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, testcase := range []struct {
+		filename     string
+		content      string
+		expectedKeys []string
+		expectError  bool
+	}{
+		{
+			filename:     "blocklist.txt",
+			content:      "# blocklist\n10.0.0.0/8\n\n  192.168.0.1  \n2001:DB8::/32\n192.168.0.2/32\n",
+			expectedKeys: []string{"10.0.0.0/8", "192.168.0.1", "192.168.0.2", "2001:db8::/32"},
+		},
+		{
+			filename:    "invalid.txt",
+			content:     "10.0.0.0/8\nexample.com\n",
+			expectError: true,
+		},
+		{
+			filename:    "duplicates.txt",
+			content:     "10.0.0.1\n10.0.0.1/32\n",
+			expectError: true,
+		},
+	} {
+		path := filepath.Join(dir, testcase.filename)
+		if err := os.WriteFile(path, []byte(testcase.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		entries, err := readACLEntriesFile(path)
+		if testcase.expectError {
+			if err == nil {
+				t.Errorf("expected an error reading %s", testcase.filename)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error reading %s: %s", testcase.filename, err)
+			continue
+		}
+
+		var keys []string
+		for k := range entries {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if !reflect.DeepEqual(keys, testcase.expectedKeys) {
+			t.Errorf("expected entries %v from %s, got %v", testcase.expectedKeys, testcase.filename, keys)
+		}
+	}
+}
+
+func TestBuildBatchACLEntriesFromFile(t *testing.T) {
+	remoteEntries := keyACLEntries([]map[string]any{
+		{"id": "1", "ip": "10.0.0.0", "subnet": "8", "negated": false},
+		{"id": "2", "ip": "192.168.0.1", "subnet": "32", "negated": false},
+		{"id": "3", "ip": "192.168.0.2", "negated": true},
+	})
+	fileEntries := map[string]map[string]any{
+		"192.168.0.1": {"id": "", "ip": "192.168.0.1", "subnet": "", "negated": false, "comment": ""},
+		"192.168.0.2": {"id": "", "ip": "192.168.0.2", "subnet": "", "negated": false, "comment": ""},
+	}
+
+	operations := map[string]gofastly.BatchOperation{}
+	for _, e := range buildBatchACLEntriesFromFile(remoteEntries, fileEntries) {
+		if e.Operation == gofastly.DeleteBatchOperation {
+			operations[*e.ID] = e.Operation
+		} else {
+			operations[*e.IP] = e.Operation
+		}
+	}
+	expected := map[string]gofastly.BatchOperation{
+		"1":           gofastly.DeleteBatchOperation,
+		"3":           gofastly.DeleteBatchOperation,
+		"192.168.0.2": gofastly.CreateBatchOperation,
+	}
+	if !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %#v, got %#v", expected, operations)
+	}
+
+	if hashACLEntries(remoteEntries) == hashACLEntries(fileEntries) {
+		t.Errorf("expected the hashes of different entries to differ")
+	}
+	if hashACLEntries(fileEntries) != hashACLEntries(keyACLEntries([]map[string]any{
+		{"ip": "192.168.0.2", "negated": false},
+		{"ip": "192.168.0.1", "subnet": "32", "negated": false},
+	})) {
+		t.Errorf("expected the hashes of equivalent entries to match")
+	}
+}
+
+func TestBuildBatchACLEntriesDelta(t *testing.T) {
+	oldEntries := []any{
+		map[string]any{"id": "1", "ip": "10.0.0.0", "subnet": "8", "negated": false, "comment": "office"},
+		map[string]any{"id": "2", "ip": "192.168.0.1", "subnet": "", "negated": false, "comment": ""},
+		map[string]any{"id": "3", "ip": "192.168.0.2", "negated": true},
+		map[string]any{"id": "4", "ip": "192.168.0.3", "subnet": "", "negated": false, "comment": "old"},
+	}
+	newEntries := []any{
+		map[string]any{"id": "", "ip": "10.0.0.0/8", "subnet": "", "negated": false, "comment": "office"},
+		map[string]any{"id": "", "ip": "192.168.0.2", "subnet": "", "negated": false, "comment": ""},
+		map[string]any{"id": "", "ip": "192.168.0.3", "subnet": "", "negated": false, "comment": "new"},
+		map[string]any{"id": "", "ip": "192.168.0.4", "subnet": "", "negated": false, "comment": ""},
+	}
+
+	var operations []string
+	for _, e := range buildBatchACLEntriesDelta(oldEntries, newEntries) {
+		switch e.Operation {
+		case gofastly.DeleteBatchOperation:
+			operations = append(operations, fmt.Sprintf("delete %s", *e.ID))
+		case gofastly.UpdateBatchOperation:
+			operations = append(operations, fmt.Sprintf("update %s %s negated=%t comment=%q", *e.ID, *e.IP, bool(*e.Negated), *e.Comment))
+		default:
+			operations = append(operations, fmt.Sprintf("%s %s", e.Operation, *e.IP))
+		}
+	}
+	expected := []string{
+		"delete 2",
+		`update 3 192.168.0.2 negated=false comment=""`,
+		`update 4 192.168.0.3 negated=false comment="new"`,
+		"create 192.168.0.4",
+	}
+	if !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %#v, got %#v", expected, operations)
+	}
+}
+
+func TestResourceFastlyServiceACLEntries_entriesFileSummary(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(entriesFile, []byte("10.0.0.0/8\n192.168.0.1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"service_id":   "service-id",
+		"acl_id":       "acl-id",
+		"entries_file": entriesFile,
+	})
+
+	diff, err := resourceServiceACLEntries().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if summary := diff.Attributes["entries_file_summary"]; summary == nil || summary.New != "2 to add, 0 to remove" {
+		t.Errorf("expected a summary of the entries to add, got %#v", summary)
+	}
+}
+
+func TestResourceFastlyServiceACLEntries_readEntriesFileSummary(t *testing.T) {
+	entriesFile := filepath.Join(t.TempDir(), "blocklist.txt")
+	if err := os.WriteFile(entriesFile, []byte("10.0.0.0/8\n192.168.0.1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// 192.168.0.1 was removed, and 172.16.0.1 added, outside of Terraform.
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "1", "ip": "10.0.0.0", "subnet": 8}, {"id": "2", "ip": "172.16.0.1"}]`))
+	}))
+
+	d := resourceServiceACLEntries().Data(nil)
+	d.SetId("service-id/acl-id")
+	for k, v := range map[string]any{
+		"service_id":           "service-id",
+		"acl_id":               "acl-id",
+		"entries_file":         entriesFile,
+		"entries_file_summary": "0 to add, 0 to remove",
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if diags := resourceServiceACLEntriesRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %s", diagToErr(diags))
+	}
+	if summary := d.Get("entries_file_summary"); summary != "1 to add, 1 to remove" {
+		t.Errorf("expected the summary to reflect the entries in the ACL, got %q", summary)
+	}
+}
+
+func TestAccFastlyServiceAclEntries_entries_file(t *testing.T) {
+	var service gofastly.ServiceDetail
+	serviceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	aclName := "ACL Test Entries File"
+	entriesFile := filepath.Join(t.TempDir(), "blocklist.txt")
+
+	writeEntriesFile := func(content string) func() {
+		return func() {
+			if err := os.WriteFile(entriesFile, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeEntriesFile("10.0.0.0/8\n192.168.0.1\n"),
+				Config:    testAccServiceACLEntriesConfigEntriesFile(serviceName, aclName, entriesFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					testAccCheckFastlyServiceACLEntriesRemoteState(&service, serviceName, aclName, []map[string]any{
+						{"id": "", "ip": "10.0.0.0", "subnet": "8", "negated": false},
+						{"id": "", "ip": "192.168.0.1", "negated": false},
+					}),
+					resource.TestCheckResourceAttr("fastly_service_acl_entries.entries", "entries_file_summary", "0 to add, 0 to remove"),
+				),
+			},
+			{
+				PreConfig: writeEntriesFile("10.0.0.0/8\n192.168.0.2\n"),
+				Config:    testAccServiceACLEntriesConfigEntriesFile(serviceName, aclName, entriesFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					testAccCheckFastlyServiceACLEntriesRemoteState(&service, serviceName, aclName, []map[string]any{
+						{"id": "", "ip": "10.0.0.0", "subnet": "8", "negated": false},
+						{"id": "", "ip": "192.168.0.2", "negated": false},
+					}),
+					resource.TestCheckResourceAttr("fastly_service_acl_entries.entries", "entries_file_summary", "0 to add, 0 to remove"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccFastlyServiceAclEntries_create(t *testing.T) {
 	var service gofastly.ServiceDetail
 	serviceName := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -440,6 +775,34 @@ resource "fastly_service_vcl" "foo" {
 }`, serviceName, domainName, backendName, aclName)
 }
 
+func testAccServiceACLEntriesConfigEntriesFile(serviceName, aclName, entriesFile string) string {
+	backendName := fmt.Sprintf("%s.aws.amazon.com", acctest.RandString(3))
+	domainName := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "foo" {
+	name = "%s"
+	domain {
+		name    = "%s"
+		comment = "tf-testing-domain"
+	}
+	backend {
+		address = "%s"
+		name    = "tf-testing-backend"
+	}
+	acl {
+		name = "%s"
+	}
+	force_destroy = true
+}
+resource "fastly_service_acl_entries" "entries" {
+	service_id     = fastly_service_vcl.foo.id
+	acl_id         = {for s in fastly_service_vcl.foo.acl : s.name => s.acl_id}["%s"]
+	manage_entries = true
+	entries_file   = "%s"
+}`, serviceName, domainName, backendName, aclName, aclName, entriesFile)
+}
+
 func testAccServiceACLEntriesConfigOneACLWithEntries(serviceName, aclName string, aclEntriesList []map[string]any, manageEntries bool) string {
 	backendName := fmt.Sprintf("%s.aws.amazon.com", acctest.RandString(3))
 	domainName := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
@@ -48,8 +48,8 @@ func resourceServiceAuthorization() *schema.Resource {
 	}
 }
 
-func resourceServiceAuthorizationCreate(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceServiceAuthorizationCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	sa, err := conn.CreateServiceAuthorization(&gofastly.CreateServiceAuthorizationInput{
 		Service:    &gofastly.SAService{ID: d.Get("service_id").(string)},
@@ -65,10 +65,10 @@ func resourceServiceAuthorizationCreate(_ context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func resourceServiceAuthorizationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceServiceAuthorizationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing Service Authorization Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	sa, err := conn.GetServiceAuthorization(&gofastly.GetServiceAuthorizationInput{
 		ID: d.Id(),
@@ -85,7 +85,7 @@ func resourceServiceAuthorizationRead(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceAuthorizationUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	if d.HasChanges("permission") {
 		_, err := conn.UpdateServiceAuthorization(&gofastly.UpdateServiceAuthorizationInput{
@@ -100,8 +100,8 @@ func resourceServiceAuthorizationUpdate(ctx context.Context, d *schema.ResourceD
 	return resourceServiceAuthorizationRead(ctx, d, meta)
 }
 
-func resourceServiceAuthorizationDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceServiceAuthorizationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeleteServiceAuthorization(&gofastly.DeleteServiceAuthorizationInput{
 		ID: d.Id(),
@@ -44,6 +44,8 @@ var computeService = &BaseServiceDefinition{
 		NewServiceLoggingKinesis(computeAttributes),
 		NewServiceDictionary(computeAttributes),
 		NewServicePackage(computeAttributes),
+		NewServicePackagePropagation(),
+		NewServiceVerify(),
 	},
 }
 
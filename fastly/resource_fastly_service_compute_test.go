@@ -1,6 +1,7 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -11,6 +12,58 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+// TestActivationSkipped checks that a version Terraform didn't activate because it has no package is neither reported
+// as deactivated on refresh nor planned to be activated again.
+func TestActivationSkipped(t *testing.T) {
+	r := resourceServiceCompute()
+
+	for name, skipped := range map[string]bool{"skipped": true, "deactivated": false} {
+		d := r.Data(nil)
+		d.SetId("service-id")
+		for k, s := range r.Schema {
+			if s.Default != nil {
+				if err := d.Set(k, s.Default); err != nil {
+					t.Fatalf("failed to set %s: %s", k, err)
+				}
+			}
+		}
+		for k, v := range map[string]any{
+			"name":               "service",
+			"domain":             []map[string]any{{"name": "example.com", "comment": ""}},
+			"package":            []map[string]any{},
+			"cloned_version":     1,
+			"active_version":     0,
+			"latest_version":     1,
+			"activation_skipped": skipped,
+		} {
+			if err := d.Set(k, v); err != nil {
+				t.Fatalf("failed to set %s: %s", k, err)
+			}
+		}
+
+		s := &gofastly.ServiceDetail{ID: "service-id"}
+		if deactivated := serviceDeactivated(d, s); deactivated == skipped {
+			t.Errorf("%s: expected deactivated to be %t, got %t", name, !skipped, deactivated)
+		}
+
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+		})
+		diff, err := r.Diff(context.Background(), d.State(), config, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		var activeVersionChanges bool
+		if diff != nil {
+			activeVersionChanges = diff.Attributes["active_version"] != nil
+		}
+		if activeVersionChanges == skipped {
+			t.Errorf("%s: expected active_version to change: %t, got %t", name, !skipped, activeVersionChanges)
+		}
+	}
+}
+
 func TestResourceFastlyFlattenBackendCompute(t *testing.T) {
 	cases := []struct {
 		serviceMetadata ServiceMetadata
@@ -25,6 +78,7 @@ func TestResourceFastlyFlattenBackendCompute(t *testing.T) {
 				{
 					Name:                "test.notexample.com",
 					Address:             "www.notexample.com",
+					Comment:             "origin pool",
 					OverrideHost:        "origin.example.com",
 					Port:                uint(80),
 					AutoLoadbalance:     true,
@@ -53,6 +107,7 @@ func TestResourceFastlyFlattenBackendCompute(t *testing.T) {
 				{
 					"name":                  "test.notexample.com",
 					"address":               "www.notexample.com",
+					"comment":               "origin pool",
 					"override_host":         "origin.example.com",
 					"port":                  80,
 					"auto_loadbalance":      true,
@@ -112,6 +167,8 @@ func TestAccFastlyServiceCompute_basic(t *testing.T) {
 						"fastly_service_compute.foo", "version_comment", ""),
 					resource.TestCheckResourceAttr(
 						"fastly_service_compute.foo", "active_version", "0"),
+					resource.TestCheckResourceAttr(
+						"fastly_service_compute.foo", "cloned_version", "1"),
 					resource.TestCheckResourceAttr(
 						"fastly_service_compute.foo", "domain.#", "1"),
 					resource.TestCheckResourceAttr(
@@ -125,7 +182,7 @@ func TestAccFastlyServiceCompute_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// These attributes are not stored on the Fastly API and must be ignored.
-				ImportStateVerifyIgnore: []string{"activate", "force_destroy", "package.0.filename", "imported"},
+				ImportStateVerifyIgnore: []string{"activate", "adopt_external_changes", "destroy_behavior", "force_destroy", "package.0.filename", "imported", "package_propagation_timeout", "wait_for_package_propagation"},
 			},
 		},
 	})
@@ -138,7 +195,7 @@ func testAccCheckServiceComputeDestroy(s *terraform.State) error {
 		}
 
 		conn := testAccProvider.Meta().(*APIClient).conn
-		l, err := conn.ListServices(&gofastly.ListServicesInput{})
+		l, err := listAllServices(conn)
 		if err != nil {
 			return fmt.Errorf("error listing services when deleting Fastly Service (%s): %s", rs.Primary.ID, err)
 		}
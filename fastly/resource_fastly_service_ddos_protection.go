@@ -0,0 +1,110 @@
+package fastly
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ddosProtectionProduct is the ID of DDoS Protection in the product enablement API.
+const ddosProtectionProduct = "ddos_protection"
+
+func resourceServiceDDoSProtection() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceDDoSProtectionCreate,
+		ReadContext:   resourceServiceDDoSProtectionRead,
+		UpdateContext: resourceServiceDDoSProtectionUpdate,
+		DeleteContext: resourceServiceDDoSProtectionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"mode": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "What DDoS Protection does with the requests of an attack it has detected. `log` only records them, `block` blocks them.",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"log", "block"}, false)),
+			},
+			"service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the service to enable DDoS Protection on.",
+			},
+		},
+	}
+}
+
+func resourceServiceDDoSProtectionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+	serviceID := d.Get("service_id").(string)
+
+	log.Printf("[DEBUG] Enabling DDoS Protection for service (%s)", serviceID)
+
+	if err := enableProduct(conn, ddosProtectionProduct, serviceID); err != nil {
+		return diag.Errorf("error enabling DDoS Protection for service (%s): %s", serviceID, err)
+	}
+
+	d.SetId(serviceID)
+
+	return resourceServiceDDoSProtectionUpdate(ctx, d, meta)
+}
+
+func resourceServiceDDoSProtectionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	log.Printf("[DEBUG] Refreshing DDoS Protection for service (%s)", d.Id())
+
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	enabled, err := getProductEnablement(conn, ddosProtectionProduct, d.Id())
+	if err != nil {
+		return diag.Errorf("error reading DDoS Protection for service (%s): %s", d.Id(), err)
+	}
+	if !enabled {
+		log.Printf("[WARN] DDoS Protection not enabled for service (%s), removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	configuration, err := getProductConfiguration(conn, ddosProtectionProduct, d.Id())
+	if err != nil {
+		return diag.Errorf("error reading DDoS Protection configuration for service (%s): %s", d.Id(), err)
+	}
+
+	if err := d.Set("service_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("mode", configuration["mode"]); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceServiceDDoSProtectionUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	_, err := updateProductConfiguration(conn, ddosProtectionProduct, d.Id(), map[string]any{
+		"mode": d.Get("mode").(string),
+	})
+	if err != nil {
+		return diag.Errorf("error updating DDoS Protection configuration for service (%s): %s", d.Id(), err)
+	}
+
+	return resourceServiceDDoSProtectionRead(ctx, d, meta)
+}
+
+func resourceServiceDDoSProtectionDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	log.Printf("[DEBUG] Disabling DDoS Protection for service (%s)", d.Id())
+
+	if err := disableProduct(conn, ddosProtectionProduct, d.Id()); err != nil {
+		return diag.Errorf("error disabling DDoS Protection for service (%s): %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package fastly
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceFastlyServiceDDoSProtection(t *testing.T) {
+	enabled := false
+	mode := "off"
+	path := "/enabled-products/v1/ddos_protection/services/service-id"
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == path && r.Method == http.MethodPut:
+			enabled = true
+		case r.URL.Path == path && r.Method == http.MethodDelete && enabled:
+			enabled = false
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case r.URL.Path == path && r.Method == http.MethodGet && enabled:
+		case r.URL.Path == path+"/configuration" && r.Method == http.MethodPatch && enabled:
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			mode = body["mode"]
+		case r.URL.Path == path+"/configuration" && r.Method == http.MethodGet && enabled:
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors": [{"title": "Not found"}]}`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"product": {"id": "ddos_protection"}, "service": {"id": "service-id"}, "configuration": {"mode": %q}}`, mode)
+	}))
+
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, resourceServiceDDoSProtection().Schema, map[string]any{
+		"service_id": "service-id",
+		"mode":       "log",
+	})
+	if diags := resourceServiceDDoSProtectionCreate(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error creating: %s", diagToErr(diags))
+	}
+	if !enabled || mode != "log" || d.Id() != "service-id" {
+		t.Fatalf("expected DDoS Protection to be enabled in log mode, got enabled %t, mode %q, ID %q", enabled, mode, d.Id())
+	}
+
+	mode = "block"
+	if diags := resourceServiceDDoSProtectionRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error reading: %s", diagToErr(diags))
+	}
+	if d.Get("mode") != "block" {
+		t.Errorf("expected the mode to be refreshed, got %q", d.Get("mode"))
+	}
+
+	if diags := resourceServiceDDoSProtectionDelete(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error deleting: %s", diagToErr(diags))
+	}
+	if enabled {
+		t.Error("expected DDoS Protection to be disabled")
+	}
+	if diags := resourceServiceDDoSProtectionDelete(ctx, d, meta); diags.HasError() {
+		t.Errorf("expected deleting when already disabled to succeed, got %s", diagToErr(diags))
+	}
+
+	if diags := resourceServiceDDoSProtectionRead(ctx, d, meta); diags.HasError() {
+		t.Fatalf("unexpected error reading: %s", diagToErr(diags))
+	}
+	if d.Id() != "" {
+		t.Error("expected DDoS Protection that has been disabled to be removed from state")
+	}
+}
+
+func TestAccFastlyServiceDDoSProtection_basic(t *testing.T) {
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceDDoSProtectionDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceDDoSProtectionConfig(name, domain, "log"),
+				Check:  resource.TestCheckResourceAttr("fastly_service_ddos_protection.example", "mode", "log"),
+			},
+			{
+				Config: testAccServiceDDoSProtectionConfig(name, domain, "block"),
+				Check:  resource.TestCheckResourceAttr("fastly_service_ddos_protection.example", "mode", "block"),
+			},
+			{
+				ResourceName:      "fastly_service_ddos_protection.example",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckServiceDDoSProtectionDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "fastly_service_ddos_protection" {
+			continue
+		}
+
+		conn := testAccProvider.Meta().(*APIClient).conn
+		enabled, err := getProductEnablement(conn, ddosProtectionProduct, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if enabled {
+			return fmt.Errorf("tried deleting DDoS Protection (%s), but it is still enabled", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccServiceDDoSProtectionConfig(name, domain, mode string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "example" {
+  name = "%s"
+
+  domain {
+    name = "%s"
+  }
+
+  backend {
+    address = "http-me.glitch.me"
+    name    = "Glitch Test Site"
+    port    = 80
+  }
+
+  force_destroy = true
+}
+
+resource "fastly_service_ddos_protection" "example" {
+  service_id = fastly_service_vcl.example.id
+  mode       = "%s"
+}
+`, name, domain, mode)
+}
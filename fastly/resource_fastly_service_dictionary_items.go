@@ -2,8 +2,14 @@ package fastly
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,6 +27,7 @@ func resourceServiceDictionaryItems() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceDictionaryItemsImport,
 		},
+		CustomizeDiff: customizeDictionaryItemsDiff,
 		Schema: map[string]*schema.Schema{
 			"dictionary_id": {
 				Type:        schema.TypeString,
@@ -34,10 +41,22 @@ func resourceServiceDictionaryItems() *schema.Resource {
 				Description:      "A map representing an entry in the dictionary, (key/value)",
 				ValidateDiagFunc: validateDictionaryItems(),
 				Elem:             schema.TypeString,
+				ConflictsWith:    []string{"items_file"},
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return !d.HasChange("dictionary_id") && !d.Get("manage_items").(bool)
 				},
 			},
+			"items_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The path to a JSON or CSV file containing the items, used instead of `items`. A JSON file must contain an object whose values are strings. A CSV file must have two columns, the key and the value, and no header row",
+				ConflictsWith: []string{"items"},
+			},
+			"items_file_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A SHA256 hash of the items when `items_file` is used. This changes whenever the items in the file differ from the items in the dictionary",
+			},
 			"manage_items": {
 				Type:        schema.TypeBool,
 				Default:     false,
@@ -55,12 +74,20 @@ func resourceServiceDictionaryItems() *schema.Resource {
 }
 
 func resourceServiceDictionaryItemsCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	dictionaryID := d.Get("dictionary_id").(string)
 	items := d.Get("items").(map[string]any)
 
+	if path, ok := d.GetOk("items_file"); ok {
+		fileItems, err := readDictionaryItemsFile(path.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		items = fileItems
+	}
+
 	var batchDictionaryItems []*gofastly.BatchDictionaryItem
 
 	for key, val := range items {
@@ -82,63 +109,60 @@ func resourceServiceDictionaryItemsCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceServiceDictionaryItemsUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	dictionaryID := d.Get("dictionary_id").(string)
 
-	if d.HasChange("items") {
-		var batchDictionaryItems []*gofastly.BatchDictionaryItem
-
-		o, n := d.GetChange("items")
-
-		os := o.(map[string]any)
-		ns := n.(map[string]any)
+	var (
+		oldItems, newItems map[string]any
+		err                error
+	)
+	if path, ok := d.GetOk("items_file"); ok {
+		if !d.HasChanges("items_file", "items_file_hash") {
+			return resourceServiceDictionaryItemsRead(ctx, d, meta)
+		}
 
-		// Handle Removal
-		for key := range os {
-			if _, ok := ns[key]; !ok {
-				batchDictionaryItems = append(batchDictionaryItems, &gofastly.BatchDictionaryItem{
-					Operation: gofastly.DeleteBatchOperation,
-					ItemKey:   key,
-				})
-			}
+		// The items from the file aren't kept in state, so compare them with
+		// the items currently in the dictionary.
+		oldItems, err = listDictionaryItems(conn, serviceID, dictionaryID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		newItems, err = readDictionaryItemsFile(path.(string))
+		if err != nil {
+			return diag.FromErr(err)
 		}
+	} else if d.HasChanges("items", "items_file") {
+		o, n := d.GetChange("items")
 
-		for key, val := range ns {
-			// Handle replaces
-			if _, ok := os[key]; ok {
-				batchDictionaryItems = append(batchDictionaryItems, &gofastly.BatchDictionaryItem{
-					Operation: gofastly.UpdateBatchOperation,
-					ItemKey:   key,
-					ItemValue: val.(string),
-				})
-			}
+		oldItems = o.(map[string]any)
+		newItems = n.(map[string]any)
 
-			// Handle additions
-			if _, ok := os[key]; !ok {
-				batchDictionaryItems = append(batchDictionaryItems, &gofastly.BatchDictionaryItem{
-					Operation: gofastly.CreateBatchOperation,
-					ItemKey:   key,
-					ItemValue: val.(string),
-				})
+		// The items weren't kept in state if they previously came from a file.
+		if d.HasChange("items_file") {
+			oldItems, err = listDictionaryItems(conn, serviceID, dictionaryID)
+			if err != nil {
+				return diag.FromErr(err)
 			}
 		}
+	} else {
+		return resourceServiceDictionaryItemsRead(ctx, d, meta)
+	}
 
-		// Process the batch operations
-		err := executeBatchDictionaryOperations(conn, serviceID, dictionaryID, batchDictionaryItems)
-		if err != nil {
-			return diag.Errorf("error updating dictionary items: service %s, dictionary %s, %s", serviceID, dictionaryID, err)
-		}
+	// Process the batch operations
+	err = executeBatchDictionaryOperations(conn, serviceID, dictionaryID, buildBatchDictionaryItems(oldItems, newItems))
+	if err != nil {
+		return diag.Errorf("error updating dictionary items: service %s, dictionary %s, %s", serviceID, dictionaryID, err)
 	}
 
 	return resourceServiceDictionaryItemsRead(ctx, d, meta)
 }
 
-func resourceServiceDictionaryItemsRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceServiceDictionaryItemsRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Print("[DEBUG] Refreshing Dictionary Items Configuration")
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	dictionaryID := d.Get("dictionary_id").(string)
@@ -151,17 +175,39 @@ func resourceServiceDictionaryItemsRead(_ context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	// When the items come from a file only their hash is kept in state, so that
+	// plans aren't filled with every item in the file.
+	if _, ok := d.GetOk("items_file"); ok {
+		if err := d.Set("items", nil); err != nil {
+			return diag.FromErr(err)
+		}
+		items := make(map[string]any)
+		for k, v := range flattenDictionaryItems(dictList) {
+			items[k] = v
+		}
+		err = d.Set("items_file_hash", hashDictionaryItems(items))
+		return diag.FromErr(err)
+	}
+
 	err = d.Set("items", flattenDictionaryItems(dictList))
 	return diag.FromErr(err)
 }
 
-func resourceServiceDictionaryItemsDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceServiceDictionaryItemsDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	dictionaryID := d.Get("dictionary_id").(string)
 	items := d.Get("items").(map[string]any)
 
+	if _, ok := d.GetOk("items_file"); ok {
+		var err error
+		items, err = listDictionaryItems(conn, serviceID, dictionaryID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	var batchDictionaryItems []*gofastly.BatchDictionaryItem
 
 	for key := range items {
@@ -213,6 +259,142 @@ func flattenDictionaryItems(dictItemList []*gofastly.DictionaryItem) map[string]
 	return resultList
 }
 
+// customizeDictionaryItemsDiff loads the items from items_file, if set, and
+// plans an update when they differ from the items in the dictionary. As with
+// items, changes are only planned after creation if manage_items is set.
+func customizeDictionaryItemsDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	path, ok := d.GetOk("items_file")
+	if !ok || !d.NewValueKnown("items_file") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("items_file") && !d.HasChange("dictionary_id") && !d.Get("manage_items").(bool) {
+		return nil
+	}
+
+	items, err := readDictionaryItemsFile(path.(string))
+	if err != nil {
+		return err
+	}
+	if len(items) > gofastly.MaximumDictionarySize {
+		return fmt.Errorf("expected %s to contain at most (%d) items, got %d", path, gofastly.MaximumDictionarySize, len(items))
+	}
+
+	if hash := hashDictionaryItems(items); hash != d.Get("items_file_hash").(string) {
+		return d.SetNew("items_file_hash", hash)
+	}
+	return nil
+}
+
+// readDictionaryItemsFile reads dictionary items from a JSON or CSV file,
+// depending on the file's extension.
+func readDictionaryItemsFile(path string) (map[string]any, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("error reading dictionary items: %w", err)
+	}
+	defer f.Close() // #nosec G307
+
+	items := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		var m map[string]string
+		if err := json.NewDecoder(f).Decode(&m); err != nil {
+			return nil, fmt.Errorf("error reading dictionary items from %s, expected an object whose values are strings: %w", path, err)
+		}
+		for k, v := range m {
+			items[k] = v
+		}
+	case ".csv":
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = 2
+		records, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("error reading dictionary items from %s, expected two columns (key and value): %w", path, err)
+		}
+		for i, record := range records {
+			if _, ok := items[record[0]]; ok {
+				return nil, fmt.Errorf("error reading dictionary items from %s: key %q on line %d is a duplicate", path, record[0], i+1)
+			}
+			items[record[0]] = record[1]
+		}
+	default:
+		return nil, fmt.Errorf("error reading dictionary items from %s: unsupported file extension %q, expected .json or .csv", path, ext)
+	}
+
+	return items, nil
+}
+
+// hashDictionaryItems returns a SHA256 hash of the items which doesn't depend
+// on the order of the items.
+func hashDictionaryItems(items map[string]any) string {
+	// json.Marshal sorts the keys of maps.
+	b, _ := json.Marshal(items)
+	return fmt.Sprintf("%x", sha256.Sum256(b))
+}
+
+// listDictionaryItems returns the items currently in the dictionary.
+func listDictionaryItems(conn *gofastly.Client, serviceID, dictionaryID string) (map[string]any, error) {
+	dictList, err := conn.ListDictionaryItems(&gofastly.ListDictionaryItemsInput{
+		ServiceID:    serviceID,
+		DictionaryID: dictionaryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[string]any)
+	for k, v := range flattenDictionaryItems(dictList) {
+		items[k] = v
+	}
+	return items, nil
+}
+
+// buildBatchDictionaryItems returns the batch operations that change oldItems
+// into newItems: items that were removed are deleted, items that were added are
+// created, and only the items whose value changed are updated. Items that
+// haven't changed aren't sent.
+func buildBatchDictionaryItems(oldItems, newItems map[string]any) []*gofastly.BatchDictionaryItem {
+	keys := make([]string, 0, len(oldItems)+len(newItems))
+	for key := range oldItems {
+		keys = append(keys, key)
+	}
+	for key := range newItems {
+		if _, ok := oldItems[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var deletes, updates, creates []*gofastly.BatchDictionaryItem
+	for _, key := range keys {
+		oldVal, inOld := oldItems[key]
+		newVal, inNew := newItems[key]
+		switch {
+		case !inNew:
+			deletes = append(deletes, &gofastly.BatchDictionaryItem{
+				Operation: gofastly.DeleteBatchOperation,
+				ItemKey:   key,
+			})
+		case !inOld:
+			creates = append(creates, &gofastly.BatchDictionaryItem{
+				Operation: gofastly.CreateBatchOperation,
+				ItemKey:   key,
+				ItemValue: newVal.(string),
+			})
+		case oldVal != newVal:
+			updates = append(updates, &gofastly.BatchDictionaryItem{
+				Operation: gofastly.UpdateBatchOperation,
+				ItemKey:   key,
+				ItemValue: newVal.(string),
+			})
+		}
+	}
+
+	// Deletes go first so that the dictionary doesn't exceed its maximum size
+	// part way through.
+	return append(append(deletes, updates...), creates...)
+}
+
 func executeBatchDictionaryOperations(conn *gofastly.Client, serviceID, dictionaryID string, batchDictionaryItems []*gofastly.BatchDictionaryItem) error {
 	batchSize := gofastly.BatchModifyMaximumOperations
 
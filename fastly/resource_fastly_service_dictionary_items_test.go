@@ -2,6 +2,8 @@ package fastly
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -47,6 +49,86 @@ func TestResourceFastlyFlattenDictionaryItems(t *testing.T) {
 	}
 }
 
+func TestReadDictionaryItemsFile(t *testing.T) {
+	dir := t.TempDir()
+	expected := map[string]any{
+		"key1": "value1",
+		"key2": "value, with a comma",
+	}
+
+	for _, testcase := range []struct {
+		filename    string
+		content     string
+		expectError bool
+	}{
+		{filename: "items.json", content: `{"key1": "value1", "key2": "value, with a comma"}`},
+		{filename: "items.JSON", content: `{"key2": "value, with a comma", "key1": "value1"}`},
+		{filename: "items.csv", content: "key1,value1\nkey2,\"value, with a comma\"\n"},
+		{filename: "numbers.json", content: `{"key1": 1}`, expectError: true},
+		{filename: "array.json", content: `["key1", "value1"]`, expectError: true},
+		{filename: "columns.csv", content: "key1,value1,extra\n", expectError: true},
+		{filename: "duplicates.csv", content: "key1,value1\nkey1,value2\n", expectError: true},
+		{filename: "items.yaml", content: "key1: value1\n", expectError: true},
+	} {
+		path := filepath.Join(dir, testcase.filename)
+		if err := os.WriteFile(path, []byte(testcase.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		items, err := readDictionaryItemsFile(path)
+		if testcase.expectError {
+			if err == nil {
+				t.Errorf("expected an error reading %s", testcase.filename)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error reading %s: %s", testcase.filename, err)
+			continue
+		}
+		if !reflect.DeepEqual(items, expected) {
+			t.Errorf("expected %#v from %s, got %#v", expected, testcase.filename, items)
+		}
+	}
+
+	if _, err := readDictionaryItemsFile(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("expected an error reading a missing file")
+	}
+}
+
+func TestHashDictionaryItems(t *testing.T) {
+	a := hashDictionaryItems(map[string]any{"key1": "value1", "key2": "value2"})
+	b := hashDictionaryItems(map[string]any{"key2": "value2", "key1": "value1"})
+	c := hashDictionaryItems(map[string]any{"key1": "value1", "key2": "changed"})
+
+	if a != b {
+		t.Errorf("expected the hash not to depend on the order of the items")
+	}
+	if a == c {
+		t.Errorf("expected the hash to change when an item changes")
+	}
+}
+
+func TestBuildBatchDictionaryItems(t *testing.T) {
+	batch := buildBatchDictionaryItems(
+		map[string]any{"removed": "a", "updated": "b", "unchanged": "e"},
+		map[string]any{"updated": "c", "added": "d", "unchanged": "e"},
+	)
+
+	var operations []string
+	for _, item := range batch {
+		operations = append(operations, fmt.Sprintf("%s %s", item.Operation, item.ItemKey))
+	}
+	expected := []string{
+		"delete removed",
+		"update updated",
+		"create added",
+	}
+	if !reflect.DeepEqual(operations, expected) {
+		t.Errorf("expected operations %#v, got %#v", expected, operations)
+	}
+}
+
 func TestAccFastlyServiceDictionaryItem_create(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -82,6 +164,62 @@ func TestAccFastlyServiceDictionaryItem_create(t *testing.T) {
 	})
 }
 
+func TestAccFastlyServiceDictionaryItem_items_file(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	dictName := fmt.Sprintf("dict %s", acctest.RandString(10))
+	itemsFile := filepath.Join(t.TempDir(), "items.csv")
+
+	expectedRemoteItems := map[string]string{
+		"key1": "value1",
+		"key2": "value2",
+	}
+	expectedRemoteItemsAfterUpdate := map[string]string{
+		"key1": "value1",
+		"key3": "value3",
+	}
+
+	writeItemsFile := func(items map[string]string) func() {
+		return func() {
+			var content string
+			for k, v := range items {
+				content += fmt.Sprintf("%s,%s\n", k, v)
+			}
+			if err := os.WriteFile(itemsFile, []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeItemsFile(expectedRemoteItems),
+				Config:    testAccServiceDictionaryItemsConfigItemsFile(name, dictName, itemsFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					testAccCheckFastlyServiceDictionaryItemsRemoteState(&service, name, dictName, expectedRemoteItems),
+					resource.TestCheckResourceAttr("fastly_service_dictionary_items.items", "items.%", "0"),
+					resource.TestCheckResourceAttrSet("fastly_service_dictionary_items.items", "items_file_hash"),
+				),
+			},
+			{
+				PreConfig: writeItemsFile(expectedRemoteItemsAfterUpdate),
+				Config:    testAccServiceDictionaryItemsConfigItemsFile(name, dictName, itemsFile),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					testAccCheckFastlyServiceDictionaryItemsRemoteState(&service, name, dictName, expectedRemoteItemsAfterUpdate),
+				),
+			},
+		},
+	})
+}
+
 // TestAccFastlyServiceDictionaryItem_create_inactive_service validates that
 // when creating a new inactive service consisting of a dictionary along with a
 // predefined list of items to populate it with, are applied successfully
@@ -484,6 +622,39 @@ resource "fastly_service_dictionary_items" "items" {
 }`, dictName, dictItems, serviceName, domainName, backendName, activate, manageItems)
 }
 
+func testAccServiceDictionaryItemsConfigItemsFile(serviceName, dictName, itemsFile string) string {
+	backendName := fmt.Sprintf("%s.aws.amazon.com", acctest.RandString(3))
+	domainName := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "foo" {
+  name = "%s"
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "%s"
+    name    = "tf -test backend"
+  }
+
+  dictionary {
+    name = "%s"
+  }
+
+  force_destroy = true
+}
+
+resource "fastly_service_dictionary_items" "items" {
+  service_id    = fastly_service_vcl.foo.id
+  dictionary_id = {for s in fastly_service_vcl.foo.dictionary : s.name => s.dictionary_id}["%s"]
+  manage_items  = true
+  items_file    = "%s"
+}`, serviceName, domainName, backendName, dictName, dictName, itemsFile)
+}
+
 func testAccServiceDictionaryItemsConfigOneDictionaryNoItems(serviceName, dictName string) string {
 	backendName := fmt.Sprintf("%s.aws.amazon.com", acctest.RandString(3))
 	domainName := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
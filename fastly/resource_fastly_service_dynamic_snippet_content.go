@@ -18,6 +18,7 @@ func resourceServiceDynamicSnippetContent() *schema.Resource {
 		ReadContext:   resourceServiceDynamicSnippetRead,
 		UpdateContext: resourceServiceDynamicSnippetUpdate,
 		DeleteContext: resourceServiceDynamicSnippetDelete,
+		CustomizeDiff: resourceServiceDynamicSnippetContentDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceDynamicSnippetContentImport,
 		},
@@ -30,6 +31,11 @@ func resourceServiceDynamicSnippetContent() *schema.Resource {
 					return !d.HasChange("snippet_id") && !d.Get("manage_snippets").(bool)
 				},
 			},
+			"content_diff": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A unified diff of the last change to `content`, from the content of the snippet to the configured content. It is shown when planning a change to the content, so that the change to the snippet can be reviewed",
+			},
 			"manage_snippets": {
 				Type:        schema.TypeBool,
 				Default:     false,
@@ -50,8 +56,18 @@ func resourceServiceDynamicSnippetContent() *schema.Resource {
 	}
 }
 
+// resourceServiceDynamicSnippetContentDiff sets content_diff to a unified diff of a planned change to the content, as
+// Terraform shows a change to a long string as a whole.
+func resourceServiceDynamicSnippetContentDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	if d.Id() == "" || !d.HasChange("content") || !d.NewValueKnown("content") {
+		return nil
+	}
+	o, n := d.GetChange("content")
+	return d.SetNew("content_diff", unifiedDiff("snippet "+d.Get("snippet_id").(string), "configuration", o.(string), n.(string)))
+}
+
 func resourceServiceDynamicSnippetCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	snippetID := d.Get("snippet_id").(string)
@@ -76,7 +92,7 @@ func resourceServiceDynamicSnippetCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceServiceDynamicSnippetUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	snippetID := d.Get("snippet_id").(string)
@@ -97,10 +113,10 @@ func resourceServiceDynamicSnippetUpdate(ctx context.Context, d *schema.Resource
 	return resourceServiceDynamicSnippetRead(ctx, d, meta)
 }
 
-func resourceServiceDynamicSnippetRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceServiceDynamicSnippetRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Print("[DEBUG] Refreshing Dynamic Snippet Configuration")
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	serviceID := d.Get("service_id").(string)
 	snippetID := d.Get("snippet_id").(string)
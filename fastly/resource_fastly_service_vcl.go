@@ -57,6 +57,8 @@ var vclService = &BaseServiceDefinition{
 		NewServiceACL(),
 		NewServiceDictionary(vclAttributes),
 		NewServiceWAF(vclAttributes),
+		NewServiceGeneratedVCL(),
+		NewServiceVerify(),
 	},
 }
 
@@ -1,15 +1,22 @@
 package fastly
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -76,6 +83,7 @@ func TestResourceFastlyFlattenBackend(t *testing.T) {
 				{
 					Name:                "test.notexample.com",
 					Address:             "www.notexample.com",
+					Comment:             "origin pool",
 					OverrideHost:        "origin.example.com",
 					Port:                uint(80),
 					AutoLoadbalance:     false,
@@ -105,6 +113,7 @@ func TestResourceFastlyFlattenBackend(t *testing.T) {
 				{
 					"name":                  "test.notexample.com",
 					"address":               "www.notexample.com",
+					"comment":               "origin pool",
 					"override_host":         "origin.example.com",
 					"port":                  80,
 					"auto_loadbalance":      false,
@@ -141,6 +150,366 @@ func TestResourceFastlyFlattenBackend(t *testing.T) {
 	}
 }
 
+func TestServiceVersionMetadata(t *testing.T) {
+	versions := []*gofastly.Version{
+		{Number: 1, Locked: true},
+		{Number: 2, Locked: true, Active: true},
+		{Number: 3, Locked: true, Staging: true},
+		{Number: 4},
+	}
+
+	if got := latestServiceVersion(versions); got != 4 {
+		t.Errorf("expected latest version 4, got %d", got)
+	}
+	if got := stagedServiceVersion(versions); got != 3 {
+		t.Errorf("expected staged version 3, got %d", got)
+	}
+	if got := stagedServiceVersion(versions[:2]); got != 0 {
+		t.Errorf("expected no staged version, got %d", got)
+	}
+}
+
+func TestIncludeGeneratedVCLDoesNotCloneVersion(t *testing.T) {
+	r := resourceServiceVCL()
+
+	d := r.Data(nil)
+	d.SetId("service-id")
+	for k, s := range r.Schema {
+		if s.Default != nil {
+			if err := d.Set(k, s.Default); err != nil {
+				t.Fatalf("failed to set %s: %s", k, err)
+			}
+		}
+	}
+	for k, v := range map[string]any{
+		"name":           "service",
+		"domain":         []map[string]any{{"name": "example.com", "comment": ""}},
+		"activate":       true,
+		"cloned_version": 1,
+		"active_version": 1,
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("failed to set %s: %s", k, err)
+		}
+	}
+
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"name":                  "service",
+		"domain":                []any{map[string]any{"name": "example.com"}},
+		"include_generated_vcl": true,
+	})
+
+	diff, err := r.Diff(context.Background(), d.State(), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if attr := diff.Attributes["generated_vcl"]; attr == nil || !attr.NewComputed {
+		t.Errorf("expected generated_vcl to be recomputed, got %#v", attr)
+	}
+	if attr := diff.Attributes["cloned_version"]; attr != nil {
+		t.Errorf("expected cloned_version not to change, got %#v", attr)
+	}
+}
+
+func TestVersionChangeAttributes(t *testing.T) {
+	r := resourceServiceVCL()
+
+	d := r.Data(nil)
+	d.SetId("service-id")
+	for k, s := range r.Schema {
+		if s.Default != nil {
+			if err := d.Set(k, s.Default); err != nil {
+				t.Fatalf("failed to set %s: %s", k, err)
+			}
+		}
+	}
+	for k, v := range map[string]any{
+		"name":           "service",
+		"domain":         []map[string]any{{"name": "example.com", "comment": ""}},
+		"activate":       true,
+		"cloned_version": 1,
+		"active_version": 1,
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("failed to set %s: %s", k, err)
+		}
+	}
+
+	for name, testcase := range map[string]struct {
+		config   map[string]any
+		expected []string
+	}{
+		"versionless": {
+			config:   map[string]any{"comment": "changed"},
+			expected: nil,
+		},
+		"activate": {
+			config: map[string]any{"activate": false},
+		},
+		"adopt_external_changes": {
+			config: map[string]any{"adopt_external_changes": false},
+		},
+		"destroy_behavior": {
+			config: map[string]any{"destroy_behavior": DestroyBehaviorDeactivate},
+		},
+		"force_destroy": {
+			config: map[string]any{"force_destroy": true},
+		},
+		"reuse": {
+			config: map[string]any{"reuse": true},
+		},
+		"blocks": {
+			config: map[string]any{
+				"comment":     "changed",
+				"default_ttl": 60,
+				"backend":     []any{map[string]any{"name": "origin", "address": "origin.example.com"}},
+			},
+			expected: []string{"backend", "default_ttl"},
+		},
+	} {
+		raw := map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+		}
+		for k, v := range testcase.config {
+			raw[k] = v
+		}
+
+		diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+
+		var got []string
+		if attr := diff.Attributes["version_change_attributes.#"]; attr != nil {
+			count, _ := strconv.Atoi(attr.New)
+			for i := 0; i < count; i++ {
+				got = append(got, diff.Attributes[fmt.Sprintf("version_change_attributes.%d", i)].New)
+			}
+		}
+		if !reflect.DeepEqual(got, testcase.expected) {
+			t.Errorf("%s: expected %v, got %v", name, testcase.expected, got)
+		}
+		if attr := diff.Attributes["cloned_version"]; (attr != nil) != (len(testcase.expected) > 0) {
+			t.Errorf("%s: expected cloned_version to change only with version changing attributes, got %#v", name, attr)
+		}
+	}
+}
+
+func TestUpgradeServiceStateV0(t *testing.T) {
+	rawState := map[string]any{
+		"name":       "service",
+		"papertrail": []any{map[string]any{"name": "papertrail", "address": "example.com", "port": 3600}},
+		"gcslogging": []any{map[string]any{"name": "gcs", "email": "user@example.com", "bucket_name": "bucket"}},
+		"logging_s3": []any{map[string]any{"name": "current"}},
+		"s3logging":  []any{map[string]any{"name": "stale"}},
+	}
+
+	upgraded, err := upgradeServiceStateV0(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]any{
+		"name":               "service",
+		"logging_papertrail": []any{map[string]any{"name": "papertrail", "address": "example.com", "port": 3600}},
+		"logging_gcs":        []any{map[string]any{"name": "gcs", "user": "user@example.com", "bucket_name": "bucket"}},
+		"logging_s3":         []any{map[string]any{"name": "current"}},
+	}
+	if !reflect.DeepEqual(upgraded, expected) {
+		t.Errorf("Error matching:\nexpected: %#v\n     got: %#v", expected, upgraded)
+	}
+}
+
+func TestUpgradeServiceStateV1(t *testing.T) {
+	rawState := map[string]any{
+		"name": "service",
+		"director": []any{
+			map[string]any{"name": "random", "type": float64(1)},
+			map[string]any{"name": "hash", "type": json.Number("3")},
+			map[string]any{"name": "client", "type": "4"},
+			map[string]any{"name": "named", "type": "hash"},
+		},
+	}
+
+	upgraded, err := upgradeServiceStateV1(context.Background(), rawState, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]any{
+		"name": "service",
+		"director": []any{
+			map[string]any{"name": "random", "type": "1"},
+			map[string]any{"name": "hash", "type": "3"},
+			map[string]any{"name": "client", "type": "4"},
+			map[string]any{"name": "named", "type": "hash"},
+		},
+	}
+	if !reflect.DeepEqual(upgraded, expected) {
+		t.Errorf("Error matching:\nexpected: %#v\n     got: %#v", expected, upgraded)
+	}
+}
+
+// TestUpgradeServiceStateV1Plan checks that upgraded directors don't show a diff, whether the configuration still
+// sets the type as a number, names it, or leaves it to its default.
+func TestUpgradeServiceStateV1Plan(t *testing.T) {
+	r := resourceServiceVCL()
+
+	for name, configType := range map[string]any{"number": "3", "name": "hash", "default": nil} {
+		stateType := float64(3)
+		if configType == nil {
+			stateType = 1
+		}
+		rawState := map[string]any{
+			"director": []any{map[string]any{
+				"name": "director", "backends": []any{"origin"}, "comment": "", "quorum": 75, "retries": 5, "shield": "",
+				"type": stateType,
+			}},
+		}
+		upgraded, err := upgradeServiceStateV1(context.Background(), rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		director := upgraded["director"].([]any)[0].(map[string]any)
+		director["backends"] = schema.NewSet(schema.HashString, director["backends"].([]any))
+
+		d := r.Data(nil)
+		d.SetId("service-id")
+		for k, s := range r.Schema {
+			if s.Default != nil {
+				if err := d.Set(k, s.Default); err != nil {
+					t.Fatalf("%s: failed to set %s: %s", name, k, err)
+				}
+			}
+		}
+		for k, v := range map[string]any{
+			"name":           "service",
+			"domain":         []map[string]any{{"name": "example.com", "comment": ""}},
+			"backend":        []map[string]any{{"name": "origin", "address": "example.net"}},
+			"director":       []any{director},
+			"active_version": 1,
+			"cloned_version": 1,
+			"latest_version": 1,
+		} {
+			if err := d.Set(k, v); err != nil {
+				t.Fatalf("%s: failed to set %s: %s", name, k, err)
+			}
+		}
+
+		configDirector := map[string]any{"name": "director", "backends": []any{"origin"}}
+		if configType != nil {
+			configDirector["type"] = configType
+		}
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"name":     "service",
+			"domain":   []any{map[string]any{"name": "example.com"}},
+			"backend":  []any{map[string]any{"name": "origin", "address": "example.net"}},
+			"director": []any{configDirector},
+		})
+		diff, err := r.Diff(context.Background(), d.State(), config, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if diff != nil {
+			for k, a := range diff.Attributes {
+				if strings.HasPrefix(k, "director") {
+					t.Errorf("%s: expected no diff for directors, got %s: %#v", name, k, a)
+				}
+			}
+		}
+	}
+}
+
+func TestFindVersionActivator(t *testing.T) {
+	older := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	events := []*gofastly.Event{
+		{UserID: "user-a", CreatedAt: &older, Metadata: map[string]any{"version": float64(2)}},
+		{UserID: "user-b", CreatedAt: &newer, Metadata: map[string]any{"version": "2"}},
+		{UserID: "user-c", CreatedAt: &newer, Metadata: map[string]any{"version": float64(3)}},
+		{UserID: "user-d", CreatedAt: &newer},
+	}
+
+	cases := []struct {
+		version  int
+		expected string
+	}{
+		{version: 2, expected: "user-b"},
+		{version: 3, expected: "user-c"},
+		{version: 4, expected: ""},
+	}
+
+	for _, c := range cases {
+		if got := findVersionActivator(events, c.version); got != c.expected {
+			t.Errorf("version %d: expected %q, got %q", c.version, c.expected, got)
+		}
+	}
+}
+
+func TestReadServiceVersionMetadataActivator(t *testing.T) {
+	var requests int32
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/events" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		_, _ = w.Write([]byte(`{"data": []}`))
+	})).conn
+
+	d := resourceServiceVCL().Data(nil)
+	d.SetId("service-id")
+	for _, testcase := range []struct {
+		name           string
+		activeVersion  int
+		expectRequests int32
+	}{
+		{name: "first activation", activeVersion: 3, expectRequests: 1},
+		{name: "refresh without an activator", activeVersion: 3, expectRequests: 1},
+		{name: "new activation", activeVersion: 4, expectRequests: 2},
+	} {
+		s := &gofastly.ServiceDetail{ID: "service-id", ActiveVersion: gofastly.Version{Number: testcase.activeVersion}}
+		if err := readServiceVersionMetadata(d, s, d.Get("active_version").(int), conn); err != nil {
+			t.Fatalf("%s: unexpected error: %s", testcase.name, err)
+		}
+		if err := d.Set("active_version", testcase.activeVersion); err != nil {
+			t.Fatal(err)
+		}
+		if got := atomic.LoadInt32(&requests); got != testcase.expectRequests {
+			t.Errorf("%s: expected %d requests for events, got %d", testcase.name, testcase.expectRequests, got)
+		}
+	}
+}
+
+func TestWaitForActiveVersion(t *testing.T) {
+	var requests int32
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/service/service-id/details" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		// The first two reads are stale.
+		version := 2
+		if atomic.AddInt32(&requests, 1) > 2 {
+			version = 3
+		}
+		fmt.Fprintf(w, `{"id": "service-id", "active_version": {"number": %d}}`, version)
+	})).conn
+
+	if err := waitForActiveVersion(context.Background(), conn, "service-id", 3, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Errorf("expected 3 requests, got %d", n)
+	}
+
+	err := waitForActiveVersion(context.Background(), conn, "service-id", 4, time.Second)
+	if err == nil || !strings.Contains(err.Error(), "the active version is 3") {
+		t.Errorf("expected a timeout waiting for version 4, got %v", err)
+	}
+}
+
 func TestAccFastlyServiceVCL_updateDomain(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -318,6 +687,211 @@ func TestAccFastlyServiceVCL_activateNewVersionExternally(t *testing.T) {
 	})
 }
 
+// ServiceVCL_activateNewVersionExternallyWithoutAdopting tests that a version
+// activated outside of Terraform is reverted when adopt_external_changes is
+// false, by reactivating the version Terraform last activated.
+func TestAccFastlyServiceVCL_activateNewVersionExternallyWithoutAdopting(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	activateNewVersion := func(*terraform.State) error {
+		conn := testAccProvider.Meta().(*APIClient).conn
+		version, err := conn.CloneVersion(&gofastly.CloneVersionInput{
+			ServiceID:      service.ID,
+			ServiceVersion: service.ActiveVersion.Number,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = conn.ActivateVersion(&gofastly.ActivateVersionInput{
+			ServiceID:      service.ID,
+			ServiceVersion: version.Number,
+		})
+		return err
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVCLConfigAdoptExternalChanges(name, domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					activateNewVersion,
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccServiceVCLConfigAdoptExternalChanges(name, domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "cloned_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "latest_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+// ServiceVCL_clonedVersionNotActivated tests that cloned_version identifies
+// the draft version created when a change is applied with activate = false.
+func TestAccFastlyServiceVCL_clonedVersionNotActivated(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain1 := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+	domain2 := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVCLConfigUpdateServiceComment(name, "Managed by Terraform", domain1, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "cloned_version", "1"),
+				),
+			},
+			{
+				Config: testAccServiceVCLConfigUpdateServiceComment(name, "Managed by Terraform", domain2, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "cloned_version", "2"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "latest_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+// ServiceVCL_generatedVCL tests that the generated VCL of the service is only
+// read when include_generated_vcl is set, and that setting it doesn't create a
+// new version.
+func TestAccFastlyServiceVCL_generatedVCL(t *testing.T) {
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVCLConfigGeneratedVCL(name, domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "generated_vcl", ""),
+				),
+			},
+			{
+				Config: testAccServiceVCLConfigGeneratedVCL(name, domain, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestMatchResourceAttr("fastly_service_vcl.foo", "generated_vcl", regexp.MustCompile(`sub vcl_recv`)),
+				),
+			},
+		},
+	})
+}
+
+// ServiceVCL_deactivateExternally tests that a service which is deactivated
+// outside of Terraform produces a plan to reactivate the last known version.
+func TestAccFastlyServiceVCL_deactivateExternally(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	deactivateService := func(*terraform.State) error {
+		conn := testAccProvider.Meta().(*APIClient).conn
+		_, err := conn.DeactivateVersion(&gofastly.DeactivateVersionInput{
+			ServiceID:      service.ID,
+			ServiceVersion: service.ActiveVersion.Number,
+		})
+		return err
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVCLConfig(name, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					deactivateService,
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccServiceVCLConfig(name, domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "cloned_version", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFastlyServiceVCL_destroyBehaviorDeactivate(t *testing.T) {
+	var service gofastly.ServiceDetail
+	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
+	domain := fmt.Sprintf("fastly-test.tf-%s.com", acctest.RandString(10))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckServiceVCLDeactivated(&service),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccServiceVCLConfigDestroyBehavior(name, domain, "deactivate"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckServiceVCLExists("fastly_service_vcl.foo", &service),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "destroy_behavior", "deactivate"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckServiceVCLDeactivated checks the service was deactivated rather
+// than deleted, and then deletes it to clean up after the test.
+func testAccCheckServiceVCLDeactivated(service *gofastly.ServiceDetail) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		conn := testAccProvider.Meta().(*APIClient).conn
+		s, err := conn.GetServiceDetails(&gofastly.GetServiceInput{
+			ID: service.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("error looking up deactivated Fastly Service (%s): %s", service.ID, err)
+		}
+		if s.ActiveVersion.Number != 0 {
+			return fmt.Errorf("expected Service (%s) to be deactivated, but version %d is active", service.ID, s.ActiveVersion.Number)
+		}
+
+		return conn.DeleteService(&gofastly.DeleteServiceInput{
+			ID: service.ID,
+		})
+	}
+}
+
 func TestAccFastlyServiceVCL_updateInvalidBackend(t *testing.T) {
 	var service gofastly.ServiceDetail
 	name := fmt.Sprintf("tf-test-%s", acctest.RandString(10))
@@ -434,6 +1008,9 @@ func TestAccFastlyServiceVCL_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "comment", "Managed by Terraform"),
 					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "version_comment", versionComment1),
 					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "active_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "latest_version", "1"),
+					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "staged_version", "0"),
+					resource.TestCheckResourceAttrSet("fastly_service_vcl.foo", "active_version_created_at"),
 					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "domain.#", "1"),
 					resource.TestCheckResourceAttr("fastly_service_vcl.foo", "backend.#", "1"),
 				),
@@ -465,7 +1042,7 @@ func TestAccFastlyServiceVCL_basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// These attributes are not stored on the Fastly API and must be ignored.
-				ImportStateVerifyIgnore: []string{"activate", "force_destroy", "imported"},
+				ImportStateVerifyIgnore: []string{"activate", "adopt_external_changes", "destroy_behavior", "force_destroy", "imported", "include_generated_vcl"},
 				ImportStateIdFunc: func(_ *terraform.State) (string, error) {
 					return fmt.Sprintf("%s@2", service.ID), nil
 				},
@@ -779,7 +1356,7 @@ func testAccCheckServiceVCLDestroy(s *terraform.State) error {
 		}
 
 		conn := testAccProvider.Meta().(*APIClient).conn
-		l, err := conn.ListServices(&gofastly.ListServicesInput{})
+		l, err := listAllServices(conn)
 		if err != nil {
 			return fmt.Errorf("error listing services when deleting Fastly Service (%s): %s", rs.Primary.ID, err)
 		}
@@ -813,6 +1390,64 @@ resource "fastly_service_vcl" "foo" {
 }`, name, domain)
 }
 
+func testAccServiceVCLConfigAdoptExternalChanges(name, domain string, adopt bool) string {
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "foo" {
+  name                   = "%s"
+  adopt_external_changes = %t
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name    = "amazon docs"
+  }
+
+  force_destroy = true
+}`, name, adopt, domain)
+}
+
+func testAccServiceVCLConfigDestroyBehavior(name, domain, destroyBehavior string) string {
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "foo" {
+  name             = "%s"
+  destroy_behavior = "%s"
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name    = "amazon docs"
+  }
+}`, name, destroyBehavior, domain)
+}
+
+func testAccServiceVCLConfigGeneratedVCL(name, domain string, includeGeneratedVCL bool) string {
+	return fmt.Sprintf(`
+resource "fastly_service_vcl" "foo" {
+  name = "%s"
+
+  domain {
+    name    = "%s"
+    comment = "tf-testing-domain"
+  }
+
+  backend {
+    address = "aws.amazon.com"
+    name    = "amazon docs"
+  }
+
+  include_generated_vcl = %t
+  force_destroy         = true
+}`, name, domain, includeGeneratedVCL)
+}
+
 func testAccServiceVCLConfigUpdateServiceComment(name, comment string, domain string, activate bool) string {
 	return fmt.Sprintf(`
 resource "fastly_service_vcl" "foo" {
@@ -1110,7 +1745,7 @@ func testSweepServices(region string) error {
 		return diagToErr(diagnostics)
 	}
 
-	services, err := client.ListServices(&gofastly.ListServicesInput{})
+	services, err := listAllServices(client)
 	if err != nil {
 		return err
 	}
@@ -24,8 +24,10 @@ func resourceServiceWAFConfiguration() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceWAFConfigurationImport,
 		},
+		Timeouts: operationTimeouts(),
 		CustomizeDiff: customdiff.All(
 			validateWAFConfigurationResource,
+			customizeLatestRuleRevisions,
 			customdiff.ComputedIf("cloned_version", func(_ context.Context, d *schema.ResourceDiff, _ any) bool {
 				// If anything other than "activate" has changed, the current version will be
 				// cloned in resourceServiceWAFConfigurationV1Update so set it as recomputed.
@@ -204,6 +206,12 @@ func resourceServiceWAFConfiguration() *schema.Resource {
 				Description:  "Remote file inclusion attack threshold",
 				ValidateFunc: validation.IntAtLeast(1),
 			},
+			"latest_rule_revisions": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The revisions in use of the rules with `revision` set to `latest`, keyed by their modsecurity ID. The plan shows the latest revisions when newer revisions have been published",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
 			"rule":           activeRule,
 			"rule_exclusion": wafRuleExclusion,
 			"session_fixation_score_threshold": {
@@ -258,13 +266,14 @@ func resourceServiceWAFConfigurationCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceServiceWAFConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
-	// If any attributes other than Computed (unconfigurable) or "activate" have changed, clone a new firewall version.
+	// If any attributes other than Computed (unconfigurable) or "activate" have changed, or a newer revision of a rule that
+	// tracks the latest revision has been published, clone a new firewall version.
 	// Otherwise, don't clone but activate a draft version that was previously created with "activate = false".
 	var needsChange bool
 	for k, v := range resourceServiceWAFConfiguration().Schema {
-		if (v.Computed && !v.Optional) || k == "activate" {
+		if (v.Computed && !v.Optional && k != "latest_rule_revisions") || k == "activate" {
 			continue
 		}
 		if d.HasChange(k) {
@@ -279,7 +288,7 @@ func resourceServiceWAFConfigurationUpdate(ctx context.Context, d *schema.Resour
 	var latestVersion *gofastly.WAFVersion
 	var err error
 	if needsChange {
-		latestVersion, err = getLatestVersion(d, meta)
+		latestVersion, err = getLatestVersion(ctx, d, meta)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -306,14 +315,14 @@ func resourceServiceWAFConfigurationUpdate(ctx context.Context, d *schema.Resour
 			}
 		}
 
-		if d.HasChange("rule") {
-			if err := updateRules(d, meta, wafID, latestVersion.Number); err != nil {
+		if d.HasChanges("rule", "latest_rule_revisions") {
+			if err := updateRules(ctx, d, meta, wafID, latestVersion.Number); err != nil {
 				return diag.FromErr(err)
 			}
 		}
 
 		if d.HasChange("rule_exclusion") {
-			if err := updateWAFRuleExclusions(d, meta, wafID, latestVersion.Number); err != nil {
+			if err := updateWAFRuleExclusions(ctx, d, meta, wafID, latestVersion.Number); err != nil {
 				return diag.FromErr(err)
 			}
 		}
@@ -340,8 +349,12 @@ func resourceServiceWAFConfigurationUpdate(ctx context.Context, d *schema.Resour
 			return diag.FromErr(err)
 		}
 
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutCreate)
+		}
 		statusCheck := &WAFDeploymentChecker{
-			Timeout:    d.Timeout(schema.TimeoutCreate),
+			Timeout:    timeout,
 			Delay:      WAFStatusCheckDelay,
 			MinTimeout: WAFStatusCheckMinTimeout,
 			Check:      DefaultWAFDeploymentChecker(conn),
@@ -355,10 +368,10 @@ func resourceServiceWAFConfigurationUpdate(ctx context.Context, d *schema.Resour
 	return resourceServiceWAFConfigurationRead(ctx, d, meta)
 }
 
-func resourceServiceWAFConfigurationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceServiceWAFConfigurationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing WAF Configuration for (%s)", d.Id())
 
-	latestVersion, err := getLatestVersion(d, meta)
+	latestVersion, err := getLatestVersion(ctx, d, meta)
 	if err != nil {
 		if errRes, ok := err.(*gofastly.HTTPError); ok {
 			if errRes.StatusCode == 404 {
@@ -383,7 +396,7 @@ func resourceServiceWAFConfigurationRead(_ context.Context, d *schema.ResourceDa
 	}
 
 	if !d.Get("activate").(bool) {
-		conn := meta.(*APIClient).conn
+		conn := meta.(*APIClient).connWithContext(ctx)
 		wafID := d.Get("waf_id").(string)
 		versionToRead := d.Get("cloned_version").(int)
 		latestVersion, err = conn.GetWAFVersion(&gofastly.GetWAFVersionInput{
@@ -403,7 +416,7 @@ func resourceServiceWAFConfigurationRead(_ context.Context, d *schema.ResourceDa
 	log.Printf("[INFO] retrieving WAF version number: %d", latestVersion.Number)
 	refreshWAFConfig(d, latestVersion)
 
-	if err := readWAFRules(meta, d, latestVersion.Number); err != nil {
+	if err := readWAFRules(ctx, meta, d, latestVersion.Number); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -412,7 +425,7 @@ func resourceServiceWAFConfigurationRead(_ context.Context, d *schema.ResourceDa
 	//
 	// TODO(phamann): Remove d.GetOk() guard once in limited availability.
 	if _, ok := d.GetOk("rule_exclusion"); ok {
-		if err := readWAFRuleExclusions(meta, d, latestVersion.Number); err != nil {
+		if err := readWAFRuleExclusions(ctx, meta, d, latestVersion.Number); err != nil {
 			return diag.FromErr(err)
 		}
 	}
@@ -421,7 +434,7 @@ func resourceServiceWAFConfigurationRead(_ context.Context, d *schema.ResourceDa
 }
 
 func resourceServiceWAFConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	wafID := d.Get("waf_id").(string)
 	log.Printf("[INFO] destroying configuration by creating empty version of WAF: %s", wafID)
@@ -441,7 +454,7 @@ func resourceServiceWAFConfigurationDelete(ctx context.Context, d *schema.Resour
 	}
 
 	statusCheck := &WAFDeploymentChecker{
-		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		Delay:      WAFStatusCheckDelay,
 		MinTimeout: WAFStatusCheckMinTimeout,
 		Check:      DefaultWAFDeploymentChecker(conn),
@@ -463,8 +476,8 @@ func resourceServiceWAFConfigurationImport(_ context.Context, d *schema.Resource
 	return []*schema.ResourceData{d}, nil
 }
 
-func getLatestVersion(d *schema.ResourceData, meta any) (*gofastly.WAFVersion, error) {
-	conn := meta.(*APIClient).conn
+func getLatestVersion(ctx context.Context, d *schema.ResourceData, meta any) (*gofastly.WAFVersion, error) {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	wafID := d.Get("waf_id").(string)
 	resp, err := conn.ListAllWAFVersions(&gofastly.ListAllWAFVersionsInput{
@@ -2,7 +2,10 @@ package fastly
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -17,6 +20,7 @@ func resourceFastlyTLSActivation() *schema.Resource {
 		ReadContext:   resourceFastlyTLSActivationRead,
 		UpdateContext: resourceFastlyTLSActivationUpdate,
 		DeleteContext: resourceFastlyTLSActivationDelete,
+		CustomizeDiff: resourceFastlyTLSActivationCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -27,11 +31,19 @@ func resourceFastlyTLSActivation() *schema.Resource {
 				Description: "ID of certificate to use. Must have the `domain` specified in the certificate's Subject Alternative Names.",
 			},
 			"configuration_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				ForceNew:    true,
-				Computed:    true,
-				Description: "ID of TLS configuration to be used to terminate TLS traffic, or use the default one if missing.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				Description:   "ID of TLS configuration to be used to terminate TLS traffic, or use the default one if missing. Conflicts with `configuration_name`.",
+				ConflictsWith: []string{"configuration_name"},
+			},
+			"configuration_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Name of the TLS configuration to be used to terminate TLS traffic, as an alternative to `configuration_id`. It must match the name of exactly one configuration. Changing it replaces the activation only if the name matches a different configuration, e.g. not after the configuration was renamed.",
+				ConflictsWith: []string{"configuration_id"},
 			},
 			"created_at": {
 				Type:        schema.TypeString,
@@ -44,16 +56,26 @@ func resourceFastlyTLSActivation() *schema.Resource {
 				ForceNew:    true,
 				Description: "Domain to enable TLS on. Must be assigned to an existing Fastly Service.",
 			},
+			"tls_protocols": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "TLS protocols the TLS configuration must support, e.g. `1.3`. The apply fails if the configuration doesn't support all of them.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
 
 func resourceFastlyTLSActivationCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var configuration *fastly.TLSConfiguration
-	if v, ok := d.GetOk("configuration_id"); ok {
-		configuration = &fastly.TLSConfiguration{ID: v.(string)}
+	configurationID, err := resolveTLSActivationConfiguration(conn, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if configurationID != "" {
+		configuration = &fastly.TLSConfiguration{ID: configurationID}
 	}
 
 	activation, err := conn.CreateTLSActivation(&fastly.CreateTLSActivationInput{
@@ -70,31 +92,33 @@ func resourceFastlyTLSActivationCreate(ctx context.Context, d *schema.ResourceDa
 	return resourceFastlyTLSActivationRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSActivationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSActivationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Activation Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
-	activation, err := conn.GetTLSActivation(&fastly.GetTLSActivationInput{
-		ID: d.Id(),
-	})
+	activation, err := getTLSActivation(conn, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	err = d.Set("certificate_id", activation.Certificate.ID)
+	err = d.Set("certificate_id", activation.relationshipID("tls_certificate"))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = d.Set("configuration_id", activation.relationshipID("tls_configuration"))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("configuration_id", activation.Configuration.ID)
+	err = d.Set("configuration_name", activation.configurationName())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("domain", activation.Domain.ID)
+	err = d.Set("domain", activation.relationshipID("tls_domain"))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	err = d.Set("created_at", activation.CreatedAt.Format(time.RFC3339))
+	err = d.Set("created_at", activation.Data.Attributes.CreatedAt.Format(time.RFC3339))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -102,22 +126,110 @@ func resourceFastlyTLSActivationRead(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
-func resourceFastlyTLSActivationUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+// tlsActivationDocument is a TLS activation with its configuration included, so that the name of the configuration is
+// read with the activation. go-fastly only decodes the ID of the configuration.
+type tlsActivationDocument struct {
+	Data struct {
+		ID         string `json:"id"`
+		Attributes struct {
+			CreatedAt time.Time `json:"created_at"`
+		} `json:"attributes"`
+		Relationships map[string]struct {
+			Data struct {
+				ID string `json:"id"`
+			} `json:"data"`
+		} `json:"relationships"`
+	} `json:"data"`
+	Included []struct {
+		ID         string `json:"id"`
+		Type       string `json:"type"`
+		Attributes struct {
+			Name string `json:"name"`
+		} `json:"attributes"`
+	} `json:"included"`
+}
+
+// relationshipID returns the ID of the object the activation has the named relationship with.
+func (a *tlsActivationDocument) relationshipID(name string) string {
+	return a.Data.Relationships[name].Data.ID
+}
+
+// configurationName returns the name of the included configuration of the activation.
+func (a *tlsActivationDocument) configurationName() string {
+	id := a.relationshipID("tls_configuration")
+	for _, included := range a.Included {
+		if included.Type == "tls_configuration" && included.ID == id {
+			return included.Attributes.Name
+		}
+	}
+	return ""
+}
 
-	_, err := conn.UpdateTLSActivation(&fastly.UpdateTLSActivationInput{
-		ID:          d.Id(),
-		Certificate: &fastly.CustomTLSCertificate{ID: d.Get("certificate_id").(string)},
+// getTLSActivation returns the TLS activation with id, including its configuration.
+func getTLSActivation(conn *fastly.Client, id string) (*tlsActivationDocument, error) {
+	resp, err := conn.Get(fmt.Sprintf("/tls/activations/%s", id), &fastly.RequestOptions{
+		Headers: map[string]string{"Accept": "application/vnd.api+json"},
+		Params:  map[string]string{"include": "tls_configuration"},
 	})
 	if err != nil {
-		return diag.FromErr(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var activation tlsActivationDocument
+	if err := json.NewDecoder(resp.Body).Decode(&activation); err != nil {
+		return nil, fmt.Errorf("error decoding TLS activation (%s): %w", id, err)
+	}
+	return &activation, nil
+}
+
+func resourceFastlyTLSActivationUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
+
+	if d.HasChange("tls_protocols") {
+		if _, err := resolveTLSActivationConfiguration(conn, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// A change to configuration_name that matches the current configuration only needs the state to be refreshed.
+	if d.HasChange("certificate_id") {
+		_, err := conn.UpdateTLSActivation(&fastly.UpdateTLSActivationInput{
+			ID:          d.Id(),
+			Certificate: &fastly.CustomTLSCertificate{ID: d.Get("certificate_id").(string)},
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceFastlyTLSActivationRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSActivationDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+// resourceFastlyTLSActivationCustomizeDiff replaces the activation when configuration_name changes to the name of a
+// different configuration, as the configuration of an activation can't be changed. A change to the new name of the
+// same configuration is applied in place.
+func resourceFastlyTLSActivationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if d.Id() == "" || !d.HasChange("configuration_name") {
+		return nil
+	}
+	name := d.Get("configuration_name").(string)
+	if !d.NewValueKnown("configuration_name") || name == "" || isOffline(meta) {
+		return d.ForceNew("configuration_name")
+	}
+
+	configuration, err := findTLSConfigurationByName(meta.(*APIClient).connWithContext(ctx), name)
+	if err != nil {
+		return err
+	}
+	if configuration.ID != d.Get("configuration_id").(string) {
+		return d.ForceNew("configuration_name")
+	}
+	return nil
+}
+
+func resourceFastlyTLSActivationDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeleteTLSActivation(&fastly.DeleteTLSActivationInput{
 		ID: d.Id(),
@@ -132,3 +244,59 @@ func resourceFastlyTLSActivationDelete(_ context.Context, d *schema.ResourceData
 
 	return nil
 }
+
+// resolveTLSActivationConfiguration returns the ID of the TLS configuration set by configuration_id or
+// configuration_name, or "" to use the default configuration, checking that it supports tls_protocols.
+func resolveTLSActivationConfiguration(conn *fastly.Client, d *schema.ResourceData) (string, error) {
+	var configuration *fastly.CustomTLSConfiguration
+	id := d.Get("configuration_id").(string)
+	name := d.Get("configuration_name").(string)
+	protocols := d.Get("tls_protocols").(*schema.Set).List()
+
+	switch {
+	case name != "" && id == "":
+		c, err := findTLSConfigurationByName(conn, name)
+		if err != nil {
+			return "", err
+		}
+		configuration = c
+	case len(protocols) == 0:
+		return id, nil
+	case id != "":
+		c, err := conn.GetCustomTLSConfiguration(&fastly.GetCustomTLSConfigurationInput{ID: id})
+		if err != nil {
+			return "", err
+		}
+		configuration = c
+	default:
+		configurations, err := listTLSConfigurations(conn, func(c *fastly.CustomTLSConfiguration) bool {
+			return c.Default
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(configurations) == 0 {
+			return "", fmt.Errorf("no default TLS configuration to check tls_protocols against")
+		}
+		configuration = configurations[0]
+	}
+
+	if !containsSubSet(configuration.TLSProtocols, protocols) {
+		return "", fmt.Errorf("TLS configuration %q (%s) supports TLS protocols %s, which doesn't include all of tls_protocols", configuration.Name, configuration.ID, strings.Join(configuration.TLSProtocols, ", "))
+	}
+	return configuration.ID, nil
+}
+
+// findTLSConfigurationByName returns the one TLS configuration named name.
+func findTLSConfigurationByName(conn *fastly.Client, name string) (*fastly.CustomTLSConfiguration, error) {
+	configurations, err := listTLSConfigurations(conn, func(c *fastly.CustomTLSConfiguration) bool {
+		return c.Name == name
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(configurations) != 1 {
+		return nil, fmt.Errorf("configuration_name %q matches %d TLS configurations, expected exactly one", name, len(configurations))
+	}
+	return configurations[0], nil
+}
@@ -1,13 +1,16 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +22,156 @@ func init() {
 	})
 }
 
+func TestResolveTLSActivationConfiguration(t *testing.T) {
+	configurations := map[string]string{
+		"modern": `{"id": "modern", "type": "tls_configuration", "attributes": {"name": "Modern", "tls_protocols": ["1.2", "1.3"]}}`,
+		"legacy": `{"id": "legacy", "type": "tls_configuration", "attributes": {"name": "Legacy", "tls_protocols": ["1.0", "1.1", "1.2"], "default": true}}`,
+		"twin-1": `{"id": "twin-1", "type": "tls_configuration", "attributes": {"name": "Twin", "tls_protocols": ["1.2"]}}`,
+		"twin-2": `{"id": "twin-2", "type": "tls_configuration", "attributes": {"name": "Twin", "tls_protocols": ["1.2"]}}`,
+	}
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if id := strings.TrimPrefix(r.URL.Path, "/tls/configurations/"); id != r.URL.Path {
+			fmt.Fprintf(w, `{"data": %s}`, configurations[id])
+			return
+		}
+		if r.URL.Query().Get("page[number]") != "1" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprintf(w, `{"data": [%s, %s, %s, %s]}`, configurations["modern"], configurations["legacy"], configurations["twin-1"], configurations["twin-2"])
+	})).conn
+
+	for name, testcase := range map[string]struct {
+		config      map[string]any
+		expectID    string
+		expectError string
+	}{
+		"default":             {config: map[string]any{}},
+		"by ID":               {config: map[string]any{"configuration_id": "modern"}, expectID: "modern"},
+		"by name":             {config: map[string]any{"configuration_name": "Modern", "tls_protocols": []any{"1.3"}}, expectID: "modern"},
+		"unknown name":        {config: map[string]any{"configuration_name": "Missing"}, expectError: `configuration_name "Missing" matches 0 TLS configurations`},
+		"ambiguous name":      {config: map[string]any{"configuration_name": "Twin"}, expectError: `configuration_name "Twin" matches 2 TLS configurations`},
+		"unsupported by ID":   {config: map[string]any{"configuration_id": "legacy", "tls_protocols": []any{"1.3"}}, expectError: `TLS configuration "Legacy" (legacy) supports TLS protocols 1.0, 1.1, 1.2`},
+		"unsupported default": {config: map[string]any{"tls_protocols": []any{"1.3"}}, expectError: `TLS configuration "Legacy" (legacy)`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceFastlyTLSActivation().Schema, testcase.config)
+			id, err := resolveTLSActivationConfiguration(conn, d)
+			if testcase.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), testcase.expectError) {
+					t.Fatalf("expected error containing %q, got %v", testcase.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if id != testcase.expectID {
+				t.Errorf("expected configuration %q, got %q", testcase.expectID, id)
+			}
+		})
+	}
+}
+
+func TestResourceFastlyTLSActivationRead(t *testing.T) {
+	var requests int
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/tls/activations/activation-id" || r.URL.Query().Get("include") != "tls_configuration" {
+			t.Errorf("unexpected request for %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		fmt.Fprint(w, `{
+			"data": {
+				"id": "activation-id",
+				"type": "tls_activation",
+				"attributes": {"created_at": "2022-01-01T00:00:00.000Z"},
+				"relationships": {
+					"tls_certificate": {"data": {"id": "certificate-id", "type": "tls_certificate"}},
+					"tls_configuration": {"data": {"id": "modern", "type": "tls_configuration"}},
+					"tls_domain": {"data": {"id": "example.com", "type": "tls_domain"}}
+				}
+			},
+			"included": [{"id": "modern", "type": "tls_configuration", "attributes": {"name": "Modern"}}]
+		}`)
+	}))
+
+	d := resourceFastlyTLSActivation().Data(nil)
+	d.SetId("activation-id")
+	if diags := resourceFastlyTLSActivationRead(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %s", diagToErr(diags))
+	}
+	for k, expected := range map[string]string{
+		"certificate_id":     "certificate-id",
+		"configuration_id":   "modern",
+		"configuration_name": "Modern",
+		"domain":             "example.com",
+		"created_at":         "2022-01-01T00:00:00Z",
+	} {
+		if v := d.Get(k); v != expected {
+			t.Errorf("expected %s %q, got %q", k, expected, v)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the activation to be read with one request, got %d", requests)
+	}
+}
+
+func TestResourceFastlyTLSActivationConfigurationNameChange(t *testing.T) {
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if r.URL.Query().Get("page[number]") != "1" {
+			fmt.Fprint(w, `{"data": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"data": [
+			{"id": "modern", "type": "tls_configuration", "attributes": {"name": "Modern"}},
+			{"id": "legacy", "type": "tls_configuration", "attributes": {"name": "Legacy"}}
+		]}`)
+	}))
+
+	r := resourceFastlyTLSActivation()
+	d := r.Data(nil)
+	d.SetId("activation-id")
+	for k, v := range map[string]any{
+		"certificate_id":     "certificate-id",
+		"configuration_id":   "modern",
+		"configuration_name": "Old Modern",
+		"domain":             "example.com",
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, testcase := range map[string]struct {
+		configurationName string
+		meta              *APIClient
+		expectReplace     bool
+	}{
+		"renamed configuration":   {configurationName: "Modern", meta: meta},
+		"different configuration": {configurationName: "Legacy", meta: meta, expectReplace: true},
+		"offline":                 {configurationName: "Modern", meta: &APIClient{offline: true}, expectReplace: true},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]any{
+			"certificate_id":     "certificate-id",
+			"configuration_name": testcase.configurationName,
+			"domain":             "example.com",
+		})
+		diff, err := r.Diff(context.Background(), d.State(), config, testcase.meta)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if diff == nil || diff.Attributes["configuration_name"] == nil {
+			t.Fatalf("%s: expected configuration_name to change, got %#v", name, diff)
+		}
+		if diff.RequiresNew() != testcase.expectReplace {
+			t.Errorf("%s: expected replacement %t, got %t", name, testcase.expectReplace, diff.RequiresNew())
+		}
+	}
+}
+
 func TestAccFastlyTLSActivation_basic(t *testing.T) {
 	domain := fmt.Sprintf("%s.com", acctest.RandomWithPrefix(testResourcePrefix))
 	key, cert, cert2, err := generateKeyAndMultipleCerts(domain)
@@ -80,7 +80,7 @@ func resourceFastlyTLSCertificate() *schema.Resource {
 }
 
 func resourceFastlyTLSCertificateCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	input := &fastly.CreateCustomTLSCertificateInput{
 		CertBlob: d.Get("certificate_body").(string),
@@ -100,10 +100,10 @@ func resourceFastlyTLSCertificateCreate(ctx context.Context, d *schema.ResourceD
 	return resourceFastlyTLSCertificateRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSCertificateRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSCertificateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Certificate Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
@@ -158,7 +158,7 @@ func resourceFastlyTLSCertificateRead(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceFastlyTLSCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	input := &fastly.UpdateCustomTLSCertificateInput{
 		ID:       d.Id(),
@@ -177,8 +177,8 @@ func resourceFastlyTLSCertificateUpdate(ctx context.Context, d *schema.ResourceD
 	return resourceFastlyTLSCertificateRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSCertificateDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceFastlyTLSCertificateDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeleteCustomTLSCertificate(&fastly.DeleteCustomTLSCertificateInput{
 		ID: d.Id(),
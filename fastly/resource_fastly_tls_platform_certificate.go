@@ -2,6 +2,8 @@ package fastly
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"log"
 	"time"
@@ -18,13 +20,14 @@ func resourceFastlyTLSPlatformCertificate() *schema.Resource {
 		ReadContext:   resourceFastlyTLSPlatformCertificateRead,
 		UpdateContext: resourceFastlyTLSPlatformCertificateUpdate,
 		DeleteContext: resourceFastlyTLSPlatformCertificateDelete,
+		CustomizeDiff: resourceFastlyTLSPlatformCertificateCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"allow_untrusted_root": {
 				Type:        schema.TypeBool,
-				Description: "Disable checking whether the root of the certificate chain is trusted. Useful for development purposes to allow use of self-signed CAs. Defaults to false. Write-only on create.",
+				Description: "Disable checking whether the root of the certificate chain is trusted. Useful for development purposes to allow use of self-signed CAs. Defaults to false. Write-only, sent whenever the certificate is uploaded.",
 				Optional:    true,
 				Default:     false,
 			},
@@ -53,7 +56,7 @@ func resourceFastlyTLSPlatformCertificate() *schema.Resource {
 			},
 			"intermediates_blob": {
 				Type:             schema.TypeString,
-				Description:      "PEM-formatted certificate chain from the `certificate_body` to its root.",
+				Description:      "PEM-formatted certificate chain from the `certificate_body` to its root, starting with the certificate that issued `certificate_body`. Each certificate must be issued by the one that follows it.",
 				Required:         true,
 				ValidateDiagFunc: validatePEMBlocks("CERTIFICATE"),
 			},
@@ -82,7 +85,7 @@ func resourceFastlyTLSPlatformCertificate() *schema.Resource {
 }
 
 func resourceFastlyTLSPlatformCertificateCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	input := &fastly.CreateBulkCertificateInput{
 		CertBlob:          d.Get("certificate_body").(string),
@@ -103,10 +106,10 @@ func resourceFastlyTLSPlatformCertificateCreate(ctx context.Context, d *schema.R
 	return resourceFastlyTLSPlatformCertificateRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSPlatformCertificateRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSPlatformCertificateRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Platform Certificate Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
@@ -117,6 +120,15 @@ func resourceFastlyTLSPlatformCertificateRead(_ context.Context, d *schema.Resou
 		return diag.FromErr(err)
 	}
 
+	// The API doesn't return the uploaded certificate, so drift is detected by comparing the validity of the deployed
+	// certificate with that of the configured one. Clearing certificate_body makes the next plan upload it again.
+	if body := d.Get("certificate_body").(string); body != "" && certificateDrifted(body, certificate) {
+		log.Printf("[WARN] The deployed TLS Platform Certificate (%s) differs from the configured certificate_body", d.Id())
+		if err := d.Set("certificate_body", ""); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	var domains []string
 	for _, domain := range certificate.Domains {
 		domains = append(domains, domain.ID)
@@ -155,7 +167,7 @@ func resourceFastlyTLSPlatformCertificateRead(_ context.Context, d *schema.Resou
 }
 
 func resourceFastlyTLSPlatformCertificateUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	_, err := conn.UpdateBulkCertificate(&fastly.UpdateBulkCertificateInput{
 		ID:                d.Id(),
@@ -170,8 +182,8 @@ func resourceFastlyTLSPlatformCertificateUpdate(ctx context.Context, d *schema.R
 	return resourceFastlyTLSPlatformCertificateRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSPlatformCertificateDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceFastlyTLSPlatformCertificateDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	if err := conn.DeleteBulkCertificate(&fastly.DeleteBulkCertificateInput{
 		ID: d.Id(),
@@ -181,3 +193,74 @@ func resourceFastlyTLSPlatformCertificateDelete(_ context.Context, d *schema.Res
 
 	return nil
 }
+
+func resourceFastlyTLSPlatformCertificateCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ any) error {
+	// Either may be unknown until apply, in which case the API checks the chain when it's uploaded.
+	if !d.NewValueKnown("certificate_body") || !d.NewValueKnown("intermediates_blob") {
+		return nil
+	}
+	if err := validateCertificateChain(d.Get("certificate_body").(string), d.Get("intermediates_blob").(string)); err != nil {
+		return fmt.Errorf("invalid intermediates_blob: %w", err)
+	}
+	return nil
+}
+
+// validateCertificateChain checks that intermediates is the chain of certificate, in order: certificate must be
+// issued by the first of the intermediates, and each of the intermediates by the one that follows it.
+func validateCertificateChain(certificate, intermediates string) error {
+	leaf, err := parsePEMCertificates(certificate)
+	if err != nil || len(leaf) != 1 {
+		// certificate_body's own validation reports it.
+		return nil
+	}
+	chain, err := parsePEMCertificates(intermediates)
+	if err != nil {
+		return err
+	}
+
+	for i, issuer := range chain {
+		subject := leaf[0]
+		if i > 0 {
+			subject = chain[i-1]
+		}
+		if issuer.Equal(leaf[0]) {
+			return fmt.Errorf("certificate %d is certificate_body, which must not be included in the chain", i+1)
+		}
+		if err := subject.CheckSignatureFrom(issuer); err != nil {
+			if i == 0 {
+				return fmt.Errorf("certificate_body was not issued by the first certificate (%s): %w", issuer.Subject, err)
+			}
+			return fmt.Errorf("certificate %d (%s) was not issued by certificate %d (%s), check the order of the chain: %w", i, subject.Subject, i+1, issuer.Subject, err)
+		}
+	}
+	return nil
+}
+
+// certificateDrifted returns whether the deployed certificate isn't the PEM-formatted certificate.
+func certificateDrifted(certificate string, deployed *fastly.BulkCertificate) bool {
+	parsed, err := parsePEMCertificates(certificate)
+	if err != nil || len(parsed) != 1 || deployed.NotAfter == nil || deployed.NotBefore == nil {
+		return false
+	}
+	return !parsed[0].NotAfter.Truncate(time.Second).Equal(deployed.NotAfter.Truncate(time.Second)) ||
+		!parsed[0].NotBefore.Truncate(time.Second).Equal(deployed.NotBefore.Truncate(time.Second))
+}
+
+// parsePEMCertificates parses each of the PEM-formatted certificates in s.
+func parsePEMCertificates(s string) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	rest := []byte(s)
+	for {
+		block, r := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		rest = r
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("certificate %d: %w", len(certificates)+1, err)
+		}
+		certificates = append(certificates, certificate)
+	}
+	return certificates, nil
+}
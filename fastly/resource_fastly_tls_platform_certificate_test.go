@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -56,6 +57,40 @@ func TestAccFastlyTLSPlatformCertificate_basic(t *testing.T) {
 	})
 }
 
+func TestValidateCertificateChain(t *testing.T) {
+	_, cert, ca, err := generateKeyAndCertWithCA("example.com")
+	require.NoError(t, err)
+	_, _, otherCA, err := generateKeyAndCertWithCA("example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, validateCertificateChain(cert, ca))
+
+	err = validateCertificateChain(cert, otherCA)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "certificate_body was not issued by the first certificate")
+
+	err = validateCertificateChain(cert, cert+"\n"+ca)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "certificate 1 is certificate_body")
+
+	err = validateCertificateChain(cert, ca+"\n"+otherCA)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "check the order of the chain")
+}
+
+func TestCertificateDrifted(t *testing.T) {
+	_, cert, err := generateKeyAndCert("example.com")
+	require.NoError(t, err)
+	parsed, err := parsePEMCertificates(cert)
+	require.NoError(t, err)
+
+	notBefore, notAfter := parsed[0].NotBefore, parsed[0].NotAfter
+	require.False(t, certificateDrifted(cert, &fastly.BulkCertificate{NotBefore: &notBefore, NotAfter: &notAfter}))
+
+	renewed := notAfter.Add(24 * time.Hour)
+	require.True(t, certificateDrifted(cert, &fastly.BulkCertificate{NotBefore: &notBefore, NotAfter: &renewed}))
+}
+
 func testAccCheckTLSPlatformCertificateDestroy(s *terraform.State) error {
 	conn := testAccProvider.Meta().(*APIClient).conn
 
@@ -63,7 +63,7 @@ func resourceFastlyTLSPrivateKey() *schema.Resource {
 }
 
 func resourceFastlyTLSPrivateKeyCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	privateKey, err := conn.CreatePrivateKey(&gofastly.CreatePrivateKeyInput{
 		Key:  d.Get("key_pem").(string),
@@ -78,10 +78,10 @@ func resourceFastlyTLSPrivateKeyCreate(ctx context.Context, d *schema.ResourceDa
 	return resourceFastlyTLSPrivateKeyRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSPrivateKeyRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSPrivateKeyRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Private Key Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var diags diag.Diagnostics
 
@@ -127,8 +127,8 @@ func resourceFastlyTLSPrivateKeyRead(_ context.Context, d *schema.ResourceData,
 	return diags
 }
 
-func resourceFastlyTLSPrivateKeyDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceFastlyTLSPrivateKeyDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeletePrivateKey(&gofastly.DeletePrivateKeyInput{
 		ID: d.Id(),
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -24,11 +25,13 @@ func resourceFastlyTLSSubscription() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: operationTimeouts(),
 		// Subscription can only be updated when in "issued" or "pending" state, otherwise needs to delete/recreate
 		CustomizeDiff: customdiff.All(
 			customdiff.ForceNewIf("configuration_id", resourceFastlyTLSSubscriptionIsStateImmutable),
 			customdiff.ForceNewIf("domains", resourceFastlyTLSSubscriptionIsStateImmutable),
 			customdiff.ForceNewIf("common_name", resourceFastlyTLSSubscriptionIsStateImmutable),
+			customdiff.ForceNewIf("reissue_trigger", resourceFastlyTLSSubscriptionIsStateImmutable),
 			customdiff.ValidateValue("domains", resourceFastlyTLSSubscriptionValidateDomains),
 			customdiff.ValidateValue("common_name", resourceFastlyTLSSubscriptionValidateCommonName),
 			resourceFastlyTLSSubscriptionSetNewComputed,
@@ -36,10 +39,10 @@ func resourceFastlyTLSSubscription() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"certificate_authority": {
 				Type:         schema.TypeString,
-				Description:  "The entity that issues and certifies the TLS certificates for your subscription. Valid values are `lets-encrypt` or `globalsign`.",
+				Description:  "The entity that issues and certifies the TLS certificates for your subscription. Valid values are `certainly`, `lets-encrypt` or `globalsign`. Changing it replaces the subscription, so that a certificate is issued by the new authority.",
 				Required:     true,
 				ForceNew:     true,
-				ValidateFunc: validation.StringInSlice([]string{"lets-encrypt", "globalsign"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"certainly", "lets-encrypt", "globalsign"}, false),
 			},
 			"certificate_id": {
 				Type:        schema.TypeString,
@@ -113,6 +116,35 @@ func resourceFastlyTLSSubscription() *schema.Resource {
 					},
 				},
 			},
+			"managed_dns_challenges_by_domain": {
+				Type:        schema.TypeList,
+				Description: "The DNS record to add to respond to the ACME DNS challenge for each of the `domains`, ordered by domain. Wildcard domains share the record of the domain without the `*.` prefix. Unlike `managed_dns_challenges`, this can be turned into a map keyed by domain, e.g. to look up the record for each of the `domains` in a `for_each`.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:        schema.TypeString,
+							Description: "The domain the record verifies the ownership of, as it appears in `domains`.",
+							Computed:    true,
+						},
+						"record_name": {
+							Type:        schema.TypeString,
+							Description: "The name of the DNS record to add. For example `_acme-challenge.example.com`.",
+							Computed:    true,
+						},
+						"record_type": {
+							Type:        schema.TypeString,
+							Description: "The type of DNS record to add, e.g. `A`, or `CNAME`.",
+							Computed:    true,
+						},
+						"record_value": {
+							Type:        schema.TypeString,
+							Description: "The value to which the DNS record should point, e.g. `xxxxx.fastly-validations.com`.",
+							Computed:    true,
+						},
+					},
+				},
+			},
 			"managed_http_challenges": {
 				Type:        schema.TypeSet,
 				Description: "A list of options for configuring DNS to respond to ACME HTTP challenge in order to verify domain ownership. Best accessed through a `for` expression to filter the relevant record.",
@@ -138,6 +170,11 @@ func resourceFastlyTLSSubscription() *schema.Resource {
 					},
 				},
 			},
+			"reissue_trigger": {
+				Type:        schema.TypeString,
+				Description: "An arbitrary value, e.g. a date. Changing it reissues the certificate of the subscription for the same domains, without replacing the subscription.",
+				Optional:    true,
+			},
 			"state": {
 				Type:        schema.TypeString,
 				Description: "The current state of the subscription. The list of possible states are: `pending`, `processing`, `issued`, and `renewing`.",
@@ -153,7 +190,7 @@ func resourceFastlyTLSSubscription() *schema.Resource {
 }
 
 func resourceFastlyTLSSubscriptionCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	var configuration *gofastly.TLSConfiguration
 	if v, ok := d.GetOk("configuration_id"); ok {
@@ -191,10 +228,10 @@ func resourceFastlyTLSSubscriptionCreate(ctx context.Context, d *schema.Resource
 	return resourceFastlyTLSSubscriptionRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSSubscriptionRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSSubscriptionRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Subscription Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	include := "tls_authorizations"
 	subscription, err := conn.GetTLSSubscription(&gofastly.GetTLSSubscriptionInput{
@@ -318,12 +355,16 @@ func resourceFastlyTLSSubscriptionRead(_ context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	err = d.Set("managed_dns_challenges_by_domain", dnsChallengesByDomain(domains, managedDNSChallenges))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
 func resourceFastlyTLSSubscriptionUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	updates := &gofastly.UpdateTLSSubscriptionInput{
 		ID:    d.Id(),
@@ -343,6 +384,10 @@ func resourceFastlyTLSSubscriptionUpdate(ctx context.Context, d *schema.Resource
 	if d.HasChange("configuration_id") {
 		updates.Configuration = &gofastly.TLSConfiguration{ID: d.Get("configuration_id").(string)}
 	}
+	// A forced update reissues the certificate, even when nothing else has changed.
+	if d.HasChange("reissue_trigger") {
+		updates.Force = true
+	}
 
 	_, err := conn.UpdateTLSSubscription(updates)
 	if err != nil {
@@ -352,8 +397,8 @@ func resourceFastlyTLSSubscriptionUpdate(ctx context.Context, d *schema.Resource
 	return resourceFastlyTLSSubscriptionRead(ctx, d, meta)
 }
 
-func resourceFastlyTLSSubscriptionDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceFastlyTLSSubscriptionDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeleteTLSSubscription(&gofastly.DeleteTLSSubscriptionInput{
 		ID:    d.Id(),
@@ -362,6 +407,34 @@ func resourceFastlyTLSSubscriptionDelete(_ context.Context, d *schema.ResourceDa
 	return diag.FromErr(err)
 }
 
+// dnsChallengesByDomain returns the managed DNS challenge record for each domain. The challenge records don't say which
+// domain they are for, so they are matched by their name. A wildcard domain is verified by the record of the domain
+// without the wildcard.
+func dnsChallengesByDomain(domains []string, challenges []map[string]any) []map[string]any {
+	records := make(map[string]map[string]any, len(challenges))
+	for _, c := range challenges {
+		records[strings.ToLower(c["record_name"].(string))] = c
+	}
+
+	sorted := append([]string(nil), domains...)
+	sort.Strings(sorted)
+
+	var result []map[string]any
+	for _, domain := range sorted {
+		c, ok := records["_acme-challenge."+strings.TrimPrefix(domain, "*.")]
+		if !ok {
+			continue
+		}
+		result = append(result, map[string]any{
+			"domain":       domain,
+			"record_name":  c["record_name"],
+			"record_type":  c["record_type"],
+			"record_value": c["record_value"],
+		})
+	}
+	return result
+}
+
 func resourceFastlyTLSSubscriptionIsStateImmutable(_ context.Context, d *schema.ResourceDiff, _ any) bool {
 	state := d.Get("state").(string)
 	return state != "issued" && state != "pending"
@@ -375,8 +448,13 @@ func resourceFastlyTLSSubscriptionSetNewComputed(_ context.Context, d *schema.Re
 	// that are dependent on this resource can properly see the diff and trigger updates accordingly upon applying.
 	if d.HasChange("domains") {
 		d.SetNewComputed("managed_dns_challenges")
+		d.SetNewComputed("managed_dns_challenges_by_domain")
 		d.SetNewComputed("managed_http_challenges")
 	}
+	if d.HasChange("reissue_trigger") && !d.HasChange("certificate_authority") {
+		d.SetNewComputed("certificate_id")
+		d.SetNewComputed("state")
+	}
 
 	return nil
 }
@@ -1,7 +1,9 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"testing"
@@ -161,3 +163,120 @@ func testSweepTLSSubscription(region string) error {
 
 	return nil
 }
+
+func TestResourceFastlyTLSSubscriptionReplacement(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "subscription-id",
+		Attributes: map[string]string{
+			"id":                    "subscription-id",
+			"certificate_authority": "lets-encrypt",
+			"domains.#":             "1",
+			"domains.0":             "example.com",
+			"force_destroy":         "false",
+			"force_update":          "false",
+			"state":                 "issued",
+		},
+	}
+
+	for name, testcase := range map[string]struct {
+		config      map[string]any
+		state       string
+		requiresNew bool
+	}{
+		"unchanged": {
+			config: map[string]any{"certificate_authority": "lets-encrypt", "domains": []any{"example.com"}},
+		},
+		"certificate authority": {
+			config:      map[string]any{"certificate_authority": "certainly", "domains": []any{"example.com"}},
+			requiresNew: true,
+		},
+		"reissue trigger": {
+			config: map[string]any{"certificate_authority": "lets-encrypt", "domains": []any{"example.com"}, "reissue_trigger": "2026-10-17"},
+		},
+		"reissue trigger while processing": {
+			config:      map[string]any{"certificate_authority": "lets-encrypt", "domains": []any{"example.com"}, "reissue_trigger": "2026-10-17"},
+			state:       "processing",
+			requiresNew: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := state.DeepCopy()
+			if testcase.state != "" {
+				state.Attributes["state"] = testcase.state
+			}
+			diff, err := resourceFastlyTLSSubscription().Diff(context.Background(), state, terraform.NewResourceConfigRaw(testcase.config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if requiresNew := diff != nil && diff.RequiresNew(); requiresNew != testcase.requiresNew {
+				t.Errorf("expected the subscription to be replaced: %t, got %t", testcase.requiresNew, requiresNew)
+			}
+		})
+	}
+}
+
+func TestResourceFastlyTLSSubscriptionReissue(t *testing.T) {
+	var force string
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			force = r.URL.Query().Get("force")
+			w.Header().Set("Content-Type", "application/vnd.api+json")
+			_, _ = w.Write([]byte(`{"data": {"id": "subscription-id", "type": "tls_subscription"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	r := resourceFastlyTLSSubscription()
+	state := &terraform.InstanceState{
+		ID: "subscription-id",
+		Attributes: map[string]string{
+			"id":                    "subscription-id",
+			"certificate_authority": "lets-encrypt",
+			"domains.#":             "1",
+			"domains.0":             "example.com",
+			"force_destroy":         "false",
+			"force_update":          "false",
+			"state":                 "issued",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]any{
+		"certificate_authority": "lets-encrypt",
+		"domains":               []any{"example.com"},
+		"reissue_trigger":       "2026-10-17",
+	})
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if _, diags := r.Apply(context.Background(), state, diff, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if force != "true" {
+		t.Errorf("expected the subscription to be updated with force=true, got %q", force)
+	}
+}
+
+func TestDNSChallengesByDomain(t *testing.T) {
+	challenges := []map[string]any{
+		{"record_name": "_acme-challenge.example.com", "record_type": "CNAME", "record_value": "a.fastly-validations.com"},
+		{"record_name": "_acme-challenge.www.example.org", "record_type": "CNAME", "record_value": "b.fastly-validations.com"},
+	}
+
+	result := dnsChallengesByDomain([]string{"www.example.org", "*.example.com", "example.com", "pending.example.net"}, challenges)
+
+	expected := []string{
+		"*.example.com _acme-challenge.example.com a.fastly-validations.com",
+		"example.com _acme-challenge.example.com a.fastly-validations.com",
+		"www.example.org _acme-challenge.www.example.org b.fastly-validations.com",
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d records, got %v", len(expected), result)
+	}
+	for i, r := range result {
+		if got := fmt.Sprintf("%s %s %s", r["domain"], r["record_name"], r["record_value"]); got != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], got)
+		}
+	}
+}
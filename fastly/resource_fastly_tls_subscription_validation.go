@@ -37,7 +37,7 @@ const (
 )
 
 func resourceFastlyTLSSubscriptionValidationCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
 		subscription, err := conn.GetTLSSubscription(&gofastly.GetTLSSubscriptionInput{
@@ -65,10 +65,10 @@ func resourceFastlyTLSSubscriptionValidationCreate(ctx context.Context, d *schem
 	return nil
 }
 
-func resourceFastlyTLSSubscriptionValidationRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceFastlyTLSSubscriptionValidationRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing TLS Subscription Validation Configuration for (%s)", d.Id())
 
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	subscriptionID := d.Get("subscription_id").(string)
 	subscription, err := conn.GetTLSSubscription(&gofastly.GetTLSSubscriptionInput{
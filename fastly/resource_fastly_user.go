@@ -2,6 +2,7 @@ package fastly
 
 import (
 	"context"
+	"fmt"
 	"log"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
@@ -20,6 +21,20 @@ func resourceUser() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"limit_services": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user can only access the services they've been authorized for, e.g. with `fastly_service_authorization`. Only applies to users with the `engineer` or `user` role",
+			},
+
+			"locked": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user is prevented from logging in",
+			},
+
 			"login": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -40,12 +55,32 @@ func resourceUser() *schema.Resource {
 				Description:      "The role of this user. Can be `user` (the default), `billing`, `engineer`, or `superuser`. For detailed information on the abilities granted to each role, see [Fastly's Documentation on User roles](https://docs.fastly.com/en/guides/configuring-user-roles-and-permissions#user-roles-and-what-they-can-do)",
 				ValidateDiagFunc: validateUserRole(),
 			},
+
+			"require_new_password": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user must set a new password the next time they log in",
+			},
+
+			"two_factor_auth_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has set up two-factor authentication",
+			},
+
+			"two_factor_setup_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the user must set up two-factor authentication the next time they log in",
+			},
 		},
 	}
 }
 
-func resourceUserCreate(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	u, err := conn.CreateUser(&gofastly.CreateUserInput{
 		Login: d.Get("login").(string),
@@ -58,12 +93,25 @@ func resourceUserCreate(_ context.Context, d *schema.ResourceData, meta any) dia
 
 	d.SetId(u.ID)
 
-	return nil
+	// The login controls can't be set when the user is created.
+	var controls bool
+	for _, key := range userLoginControls {
+		if _, ok := d.GetOkExists(key); ok { //nolint:staticcheck // Needed to distinguish unset from false.
+			controls = true
+		}
+	}
+	if controls {
+		if err := updateUserLoginControls(conn, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserRead(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
 	log.Printf("[DEBUG] Refreshing User Configuration for (%s)", d.Id())
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	u, err := conn.GetUser(&gofastly.GetUserInput{
 		ID: d.Id(),
@@ -75,12 +123,17 @@ func resourceUserRead(_ context.Context, d *schema.ResourceData, meta any) diag.
 	d.Set("login", u.Login)
 	d.Set("name", u.Name)
 	d.Set("role", u.Role)
+	d.Set("limit_services", u.LimitServices)
+	d.Set("locked", u.Locked)
+	d.Set("require_new_password", u.RequireNewPassword)
+	d.Set("two_factor_auth_enabled", u.TwoFactorAuthEnabled)
+	d.Set("two_factor_setup_required", u.TwoFactorSetupRequired)
 
 	return nil
 }
 
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	// Update Name and/or Role.
 	if d.HasChanges("name", "role") {
@@ -94,11 +147,50 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta any) d
 		}
 	}
 
+	if d.HasChanges(userLoginControls...) {
+		if err := updateUserLoginControls(conn, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserDelete(_ context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
-	conn := meta.(*APIClient).conn
+// userLoginControls are the attributes of a user that control how they log in.
+var userLoginControls = []string{"limit_services", "locked", "require_new_password", "two_factor_setup_required"}
+
+// updateUserLoginInput is the form used to update the login controls of a user, which go-fastly's UpdateUserInput
+// doesn't support. Only superusers can change them.
+type updateUserLoginInput struct {
+	LimitServices          *bool `url:"limit_services,omitempty"`
+	Locked                 *bool `url:"locked,omitempty"`
+	RequireNewPassword     *bool `url:"require_new_password,omitempty"`
+	TwoFactorSetupRequired *bool `url:"two_factor_setup_required,omitempty"`
+}
+
+// updateUserLoginControls updates the login controls of a user that are set in its configuration.
+func updateUserLoginControls(conn *gofastly.Client, d *schema.ResourceData) error {
+	var input updateUserLoginInput
+	for key, field := range map[string]**bool{
+		"limit_services":            &input.LimitServices,
+		"locked":                    &input.Locked,
+		"require_new_password":      &input.RequireNewPassword,
+		"two_factor_setup_required": &input.TwoFactorSetupRequired,
+	} {
+		if v, ok := d.GetOkExists(key); ok { //nolint:staticcheck // Needed to distinguish unset from false.
+			*field = gofastly.Bool(v.(bool))
+		}
+	}
+
+	resp, err := conn.PutForm(fmt.Sprintf("/user/%s", d.Id()), &input, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta any) diag.Diagnostics {
+	conn := meta.(*APIClient).connWithContext(ctx)
 
 	err := conn.DeleteUser(&gofastly.DeleteUserInput{
 		ID: d.Id(),
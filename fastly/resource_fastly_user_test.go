@@ -1,15 +1,55 @@
 package fastly
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"testing"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestResourceUserLoginControls(t *testing.T) {
+	var form url.Values
+	meta := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			fmt.Fprint(w, `{"id": "user-id", "login": "jane@example.com", "name": "Jane", "role": "engineer"}`)
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			form, _ = url.ParseQuery(string(body))
+			fmt.Fprint(w, `{"id": "user-id"}`)
+		case http.MethodGet:
+			fmt.Fprint(w, `{"id": "user-id", "login": "jane@example.com", "name": "Jane", "role": "engineer", "locked": false, "two_factor_setup_required": true}`)
+		}
+	}))
+
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, map[string]any{
+		"login":                     "jane@example.com",
+		"name":                      "Jane",
+		"role":                      "engineer",
+		"locked":                    false,
+		"two_factor_setup_required": true,
+	})
+	if diags := resourceUserCreate(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := url.Values{"locked": {"false"}, "two_factor_setup_required": {"true"}}
+	if form.Encode() != expected.Encode() {
+		t.Errorf("expected the login controls to be updated with %q, got %q", expected.Encode(), form.Encode())
+	}
+	if !d.Get("two_factor_setup_required").(bool) {
+		t.Errorf("expected two_factor_setup_required to be read back")
+	}
+}
+
 func TestAccFastlyUser_basic(t *testing.T) {
 	var user gofastly.User
 	login := fmt.Sprintf("tf-test-%s@example.com", acctest.RandString(10))
@@ -0,0 +1,98 @@
+package fastly
+
+import (
+	"log"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+// retryBaseDelay is how long to wait, at most, before retrying a request for the first time. The longest wait doubles
+// after each attempt, and each wait is a random duration up to it, so that clients don't retry in step.
+var retryBaseDelay = time.Second
+
+// retryMaxDelay caps the longest wait between attempts.
+const retryMaxDelay = 30 * time.Second
+
+// idempotentPOSTPattern matches the paths of POST requests that have the same effect when they are made again:
+// activating, deactivating and locking a version, and purging.
+var idempotentPOSTPattern = regexp.MustCompile(`^/(service/[^/]+/version/[^/]+/(activate|deactivate|lock)|service/[^/]+/purge(_all|/.+)?|purge/.+)$`)
+
+// packageUploadPattern matches the path of package uploads, which updatePackage retries itself.
+var packageUploadPattern = regexp.MustCompile(`^/service/[^/]+/version/[^/]+/package$`)
+
+// retryTransport is a http.RoundTripper that retries requests that fail with a 502, 503 or 504 response, which the
+// Fastly API occasionally returns for valid requests. Only requests that have the same effect when they are made again
+// are retried: GET, HEAD, PUT and DELETE requests, and if retryPOSTs is set the POST requests matched by
+// idempotentPOSTPattern. Package uploads aren't retried here, so that their retries aren't multiplied by those of
+// updatePackage.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	retryPOSTs bool
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.canRetry(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	delay := retryBaseDelay
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || attempt == t.maxRetries || !isTransientStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		retry := req
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			retry = req.Clone(req.Context())
+			retry.Body = body
+		}
+		resp.Body.Close()
+
+		wait := time.Duration(rand.Int63n(int64(delay) + 1))
+		log.Printf("[WARN] %s %s failed with %s on attempt %d of %d, retrying in %s", req.Method, req.URL.Path, resp.Status, attempt+1, t.maxRetries+1, wait)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		if delay *= 2; delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+		req = retry
+	}
+}
+
+// canRetry returns whether req can be made again if it fails.
+func (t *retryTransport) canRetry(req *http.Request) bool {
+	if t.maxRetries <= 0 {
+		return false
+	}
+	// A request with a body can only be made again if the body can be read again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodPut:
+		return !packageUploadPattern.MatchString(req.URL.Path)
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return t.retryPOSTs && idempotentPOSTPattern.MatchString(req.URL.Path)
+	}
+	return false
+}
+
+// isTransientStatus returns whether a response status is one the Fastly API returns for requests that may succeed if
+// they are made again.
+func isTransientStatus(status int) bool {
+	return status == http.StatusBadGateway || status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout
+}
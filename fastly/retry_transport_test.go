@@ -0,0 +1,78 @@
+package fastly
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	var requests int
+	var bodies []string
+	failures := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if requests <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	for name, testcase := range map[string]struct {
+		method, path string
+		body         string
+		retryPOSTs   bool
+		failures     int
+		expected     int
+		expectedCode int
+	}{
+		"GET succeeds after retries":   {method: http.MethodGet, path: "/service/s", failures: 2, expected: 3, expectedCode: http.StatusOK},
+		"PUT is retried with its body": {method: http.MethodPut, path: "/service/s", body: "name=a", failures: 1, expected: 2, expectedCode: http.StatusOK},
+		"package upload isn't retried": {method: http.MethodPut, path: "/service/s/version/1/package", body: "package", failures: 1, expected: 1, expectedCode: http.StatusServiceUnavailable},
+		"retries are bounded":          {method: http.MethodDelete, path: "/service/s", failures: 10, expected: 4, expectedCode: http.StatusServiceUnavailable},
+		"POST isn't retried":           {method: http.MethodPost, path: "/service/s/version/1/activate", failures: 1, expected: 1, expectedCode: http.StatusServiceUnavailable},
+		"idempotent POST is retried":   {method: http.MethodPost, path: "/service/s/version/1/activate", retryPOSTs: true, failures: 1, expected: 2, expectedCode: http.StatusOK},
+		"purge POST is retried":        {method: http.MethodPost, path: "/service/s/purge/key", retryPOSTs: true, failures: 1, expected: 2, expectedCode: http.StatusOK},
+		"other POST isn't retried":     {method: http.MethodPost, path: "/service/s/version/1/clone", retryPOSTs: true, failures: 1, expected: 1, expectedCode: http.StatusServiceUnavailable},
+		"success isn't retried":        {method: http.MethodGet, path: "/service/s", failures: 0, expected: 1, expectedCode: http.StatusOK},
+	} {
+		t.Run(name, func(t *testing.T) {
+			requests, bodies, failures = 0, nil, testcase.failures
+			client := &http.Client{Transport: &retryTransport{base: http.DefaultTransport, maxRetries: 3, retryPOSTs: testcase.retryPOSTs}}
+
+			var body io.Reader
+			if testcase.body != "" {
+				body = strings.NewReader(testcase.body)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), testcase.method, server.URL+testcase.path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != testcase.expectedCode || requests != testcase.expected {
+				t.Errorf("expected %d after %d requests, got %d after %d", testcase.expectedCode, testcase.expected, resp.StatusCode, requests)
+			}
+			for _, b := range bodies {
+				if b != testcase.body {
+					t.Errorf("expected each request to have the body %q, got %q", testcase.body, b)
+				}
+			}
+		})
+	}
+}
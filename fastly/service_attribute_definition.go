@@ -1,7 +1,11 @@
 package fastly
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -29,6 +33,31 @@ type ServiceAttributeDefinition interface {
 	MustProcess(d *schema.ResourceData, initialVersion bool) bool
 }
 
+// ServiceAttributeDiffCustomizer can optionally be implemented by a ServiceAttributeDefinition (or a
+// ServiceCRUDAttributeDefinition) that needs to inspect the plan of the service resource. This allows problems that
+// would otherwise only be reported by the API part way through an apply to be reported when planning instead.
+type ServiceAttributeDiffCustomizer interface {
+	// CustomizeDiff is called with the planned changes to the whole service resource.
+	CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error
+}
+
+// ServiceAttributeActivationHook can optionally be implemented by a ServiceAttributeDefinition that needs to act once a
+// new version of the service has been activated, e.g. to wait until the version is being served.
+type ServiceAttributeActivationHook interface {
+	// AfterActivation is called after the version has been activated. Returning an error fails the apply, but the
+	// version remains active.
+	AfterActivation(ctx context.Context, d *schema.ResourceData, activatedVersion int, conn *gofastly.Client) error
+}
+
+// ServiceAttributeActivationCheck can optionally be implemented by a ServiceAttributeDefinition that can tell when a
+// new version of the service can't be activated yet, e.g. a Compute service whose package is deployed outside of
+// Terraform.
+type ServiceAttributeActivationCheck interface {
+	// CanActivate returns whether the version can be activated, and if not, why. A version that can't be activated is
+	// left as a draft instead of failing the apply.
+	CanActivate(ctx context.Context, d *schema.ResourceData, version int, conn *gofastly.Client) (bool, string, error)
+}
+
 // ServiceMetadata provides a container to pass service attributes into an Attribute handler.
 type ServiceMetadata struct {
 	serviceType string
@@ -76,6 +105,9 @@ func (h *DefaultServiceAttributeHandler) getVCLLoggingAttributes(data map[string
 	if h.GetServiceMetadata().serviceType == ServiceTypeVCL {
 		if val, ok := data["format"]; ok {
 			vla.format = val.(string)
+			if isJSON, _ := data["format_is_json"].(bool); isJSON {
+				vla.format = canonicalLoggingFormat(vla.format)
+			}
 		}
 		if val, ok := data["format_version"]; ok {
 			vla.formatVersion = gofastly.Uint(uint(val.(int)))
@@ -101,3 +133,207 @@ func (h *DefaultServiceAttributeHandler) pruneVCLLoggingAttributes(data map[stri
 	}
 	return data
 }
+
+// preserveVCLLoggingAttributes copies the VCL logging attributes that the API doesn't return as configured from the
+// state of each block with the same name, to avoid d.Set overwriting them:
+//
+// - format_is_json and validate_format_variables only configure the provider.
+// - With format_is_json set, format is configured as the canonical JSON format, so the format in state is kept unless
+// the endpoint's format is a different JSON document.
+func (h *DefaultServiceAttributeHandler) preserveVCLLoggingAttributes(d *schema.ResourceData, elements []map[string]any) {
+	if h.GetServiceMetadata().serviceType != ServiceTypeVCL {
+		return
+	}
+	for _, sr := range d.Get(h.key).(*schema.Set).List() {
+		stateResource := sr.(map[string]any)
+		for _, element := range elements {
+			if element["name"] != stateResource["name"] {
+				continue
+			}
+			element["format_is_json"] = stateResource["format_is_json"]
+			element["validate_format_variables"] = stateResource["validate_format_variables"]
+			if isJSON, _ := stateResource["format_is_json"].(bool); isJSON {
+				stateFormat, _ := stateResource["format"].(string)
+				if format, ok := element["format"].(string); ok && canonicalLoggingFormat(format) == canonicalLoggingFormat(stateFormat) {
+					element["format"] = stateFormat
+				}
+			}
+		}
+	}
+}
+
+// canonicalizeModifiedLoggingFormat makes sure an update to a block with format_is_json set configures the endpoint
+// with the canonical JSON format.
+func (h *DefaultServiceAttributeHandler) canonicalizeModifiedLoggingFormat(resource, modified map[string]any) {
+	if format, ok := modified["format"].(string); ok {
+		if isJSON, _ := resource["format_is_json"].(bool); isJSON {
+			modified["format"] = canonicalLoggingFormat(format)
+		}
+	}
+}
+
+// suppressJSONLoggingFormatDiffs makes the logging blocks of a service schema ignore changes to a format with
+// format_is_json set that only change how the JSON is written, such as whitespace or the order of keys. The format is
+// hashed as its canonical JSON format, so that such a block stays the same element of its set, and the diff of the
+// format itself is suppressed.
+func suppressJSONLoggingFormatDiffs(s *schema.Resource) {
+	for key, sch := range s.Schema {
+		elem, ok := sch.Elem.(*schema.Resource)
+		if !ok || sch.Type != schema.TypeSet {
+			continue
+		}
+		format, ok := elem.Schema["format"]
+		if _, isJSON := elem.Schema["format_is_json"]; !ok || !isJSON {
+			continue
+		}
+		key := key
+		format.DiffSuppressFunc = func(_, old, new string, d *schema.ResourceData) bool {
+			return canonicalLoggingFormat(old) == canonicalLoggingFormat(new) && hasJSONLoggingFormat(d, key, new)
+		}
+
+		hash := sch.Set
+		if hash == nil {
+			hash = schema.HashResource(elem)
+		}
+		sch.Set = func(v any) int {
+			m := v.(map[string]any)
+			if isJSON, _ := m["format_is_json"].(bool); isJSON {
+				if format, ok := m["format"].(string); ok && format != canonicalLoggingFormat(format) {
+					canonical := make(map[string]any, len(m))
+					for k, v := range m {
+						canonical[k] = v
+					}
+					canonical["format"] = canonicalLoggingFormat(format)
+					m = canonical
+				}
+			}
+			return hash(m)
+		}
+	}
+}
+
+// hasJSONLoggingFormat returns whether a block of the given logging set is configured with format, and with
+// format_is_json set.
+func hasJSONLoggingFormat(d *schema.ResourceData, key, format string) bool {
+	blocks, ok := d.Get(key).(*schema.Set)
+	if !ok {
+		return false
+	}
+	for _, b := range blocks.List() {
+		block := b.(map[string]any)
+		if isJSON, _ := block["format_is_json"].(bool); isJSON && block["format"] == format {
+			return true
+		}
+	}
+	return false
+}
+
+// canonicalLoggingFormat returns a JSON logging format serialized canonically: without whitespace between tokens,
+// with the keys of objects sorted, and with its placeholders unchanged. This is what jsonencode produces, so formats
+// that only differ in how the JSON is written are configured the same way. A format that isn't valid JSON once its
+// placeholders are replaced is returned unchanged.
+func canonicalLoggingFormat(format string) string {
+	// Each placeholder is replaced with a marker: inside a string, the marker is part of the string, and outside of
+	// strings, e.g. "status": %>s, the marker is a string of its own, which is unquoted again afterwards.
+	var placeholders []string
+	var marked strings.Builder
+	inString, escaped, last := false, false, 0
+	for _, m := range loggingFormatPlaceholder.FindAllStringIndex(format, -1) {
+		for _, c := range format[last:m[0]] {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\' && inString:
+				escaped = true
+			case c == '"':
+				inString = !inString
+			}
+		}
+		marked.WriteString(format[last:m[0]])
+		last, escaped = m[1], false
+		if inString {
+			fmt.Fprintf(&marked, "@@fastly_placeholder_%d@@", len(placeholders))
+		} else {
+			fmt.Fprintf(&marked, `"@@fastly_bare_placeholder_%d@@"`, len(placeholders))
+		}
+		placeholders = append(placeholders, format[m[0]:m[1]])
+	}
+	marked.WriteString(format[last:])
+
+	decoder := json.NewDecoder(strings.NewReader(marked.String()))
+	decoder.UseNumber()
+	var v any
+	if err := decoder.Decode(&v); err != nil || decoder.More() {
+		return format
+	}
+	var canonical bytes.Buffer
+	encoder := json.NewEncoder(&canonical)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return format
+	}
+
+	result := strings.TrimSuffix(canonical.String(), "\n")
+	for i, placeholder := range placeholders {
+		result = strings.Replace(result, fmt.Sprintf(`"@@fastly_bare_placeholder_%d@@"`, i), placeholder, 1)
+		result = strings.Replace(result, fmt.Sprintf("@@fastly_placeholder_%d@@", i), placeholder, 1)
+	}
+	return result
+}
+
+// validateLoggingEndpoints reports, when planning, every endpoint of a logging block whose configuration the API would
+// reject, or that has a format that uses unknown VCL variables (when validate_format_variables is set) or, with
+// format_is_json set, isn't valid JSON.
+func (h *DefaultServiceAttributeHandler) validateLoggingEndpoints(d *schema.ResourceDiff) error {
+	resources, ok := d.Get(h.key).(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		if err := validateLoggingCompression(resource); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], err))
+		}
+		if h.GetServiceMetadata().serviceType == ServiceTypeVCL {
+			invalid = append(invalid, validateLoggingFormat(resource)...)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid %s: %s", h.key, strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// validateLoggingCompression returns an error if both compression_codec and gzip_level are set, which the API rejects.
+func validateLoggingCompression(resource map[string]any) error {
+	codec, _ := resource["compression_codec"].(string)
+	level, ok := resource["gzip_level"].(int)
+	if ok && codec != "" && level != 0 {
+		return fmt.Errorf("sets both compression_codec and gzip_level. Set compression_codec to gzip, or leave compression_codec unset to use a gzip_level other than the default")
+	}
+	return nil
+}
+
+// validateLoggingFormat returns the problems with the format of a logging endpoint.
+func validateLoggingFormat(resource map[string]any) []string {
+	var invalid []string
+	format, _ := resource["format"].(string)
+	// A format that isn't known until apply is empty when planning.
+	if format == "" {
+		return nil
+	}
+	if validate, _ := resource["validate_format_variables"].(bool); validate {
+		if err := validateLoggingFormatVariables(format); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q format has %s", resource["name"], err))
+		}
+	}
+	if isJSON, _ := resource["format_is_json"].(bool); isJSON {
+		if err := validateJSONLoggingFormat(format); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%q format is not valid JSON (%s)", resource["name"], err))
+		}
+	}
+	return invalid
+}
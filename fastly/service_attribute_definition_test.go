@@ -0,0 +1,292 @@
+package fastly
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLoggingFormatVersion1(t *testing.T) {
+	h := &DefaultServiceAttributeHandler{
+		key:             "logging_datadog",
+		serviceMetadata: ServiceMetadata{serviceType: ServiceTypeVCL},
+	}
+
+	if vla := h.getVCLLoggingAttributes(map[string]any{"format": "%h", "format_version": 1}); *vla.formatVersion != 1 {
+		t.Errorf("expected an endpoint with format_version 1 to be created with version 1, got %d", *vla.formatVersion)
+	}
+
+	modified := map[string]any{"format_version": 1}
+	h.canonicalizeModifiedLoggingFormat(map[string]any{"format_version": 1}, modified)
+	if modified["format_version"] != 1 {
+		t.Errorf("expected an endpoint updated to format_version 1 to be configured with version 1, got %v", modified["format_version"])
+	}
+
+	d := resourceServiceVCL().Data(&terraform.InstanceState{
+		ID: "service-id",
+		Attributes: map[string]string{
+			"logging_datadog.#":                    "1",
+			"logging_datadog.0.name":               "datadog",
+			"logging_datadog.0.format_version":     "1",
+			"logging_datadog.0.format_is_json":     "true",
+			"logging_datadog.0.token":              "token",
+			"logging_datadog.0.region":             "US",
+			"logging_datadog.0.format":             "%h",
+			"logging_datadog.0.placement":          "",
+			"logging_datadog.0.response_condition": "",
+		},
+	})
+	elements := []map[string]any{
+		{"name": "datadog", "format": "%h", "format_version": uint(1)},
+	}
+	h.preserveVCLLoggingAttributes(d, elements)
+	if elements[0]["format_version"] != uint(1) {
+		t.Errorf("expected the deployed format_version to be stored, got %v", elements[0]["format_version"])
+	}
+	if elements[0]["format_is_json"] != true {
+		t.Errorf("expected format_is_json to be kept from state, got %v", elements[0]["format_is_json"])
+	}
+}
+
+// TestLoggingFormatVersion1Plan checks that a block configured with format_version 1 plans version 1, and that
+// changing it to version 2 is shown as a diff.
+func TestLoggingFormatVersion1Plan(t *testing.T) {
+	r := resourceServiceVCL()
+	d := testDatadogServiceState(t, r, map[string]any{"format_version": 1})
+
+	config := map[string]any{
+		"name":            "service",
+		"domain":          []any{map[string]any{"name": "example.com"}},
+		"logging_datadog": []any{map[string]any{"name": "datadog", "token": "token", "format_version": 1}},
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil {
+		for k, a := range diff.Attributes {
+			if strings.HasPrefix(k, "logging_datadog") {
+				t.Errorf("expected no diff for logging_datadog, got %s: %#v", k, a)
+			}
+		}
+	}
+
+	diff, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for k, a := range diff.Attributes {
+		if strings.HasPrefix(k, "logging_datadog.") && strings.HasSuffix(k, ".format_version") && a.New != "1" {
+			t.Errorf("expected a new block to plan format_version 1, got %s: %#v", k, a)
+		}
+	}
+
+	config["logging_datadog"] = []any{map[string]any{"name": "datadog", "token": "token", "format_version": 2}}
+	diff, err = r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var upgraded bool
+	if diff != nil {
+		for k, a := range diff.Attributes {
+			if strings.HasPrefix(k, "logging_datadog.") && strings.HasSuffix(k, ".format_version") && a.New == "2" {
+				upgraded = true
+			}
+		}
+	}
+	if !upgraded {
+		t.Error("expected changing format_version to 2 to plan version 2")
+	}
+}
+
+// testDatadogServiceState returns the state of a VCL service with a single logging_datadog block, set to its defaults
+// and then to the given attributes.
+func testDatadogServiceState(t *testing.T, r *schema.Resource, attributes map[string]any) *schema.ResourceData {
+	t.Helper()
+	d := r.Data(nil)
+	d.SetId("service-id")
+	for k, s := range r.Schema {
+		if s.Default != nil {
+			if err := d.Set(k, s.Default); err != nil {
+				t.Fatalf("failed to set %s: %s", k, err)
+			}
+		}
+	}
+	datadog := map[string]any{"name": "datadog", "token": "token"}
+	for k, s := range r.Schema["logging_datadog"].Elem.(*schema.Resource).Schema {
+		if s.Default != nil {
+			datadog[k] = s.Default
+		}
+	}
+	for k, v := range attributes {
+		datadog[k] = v
+	}
+	for k, v := range map[string]any{
+		"name":            "service",
+		"domain":          []map[string]any{{"name": "example.com", "comment": ""}},
+		"logging_datadog": []map[string]any{datadog},
+		"active_version":  1,
+		"cloned_version":  1,
+		"latest_version":  1,
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("failed to set %s: %s", k, err)
+		}
+	}
+	return d
+}
+
+// TestSuppressJSONLoggingFormatDiffs checks that a format with format_is_json set only shows a diff when the JSON
+// document changes.
+func TestSuppressJSONLoggingFormatDiffs(t *testing.T) {
+	r := resourceServiceVCL()
+	for name, testcase := range map[string]struct {
+		isJSON       bool
+		format       string
+		expectedDiff bool
+	}{
+		"whitespace and key order": {
+			isJSON: true,
+			format: "{\n  \"url\": \"%U\",\n  \"host\": \"%h\"\n}",
+		},
+		"different document": {
+			isJSON:       true,
+			format:       `{"host":"%h","url":"%U","status":%>s}`,
+			expectedDiff: true,
+		},
+		"not JSON": {
+			format:       "{\n  \"url\": \"%U\",\n  \"host\": \"%h\"\n}",
+			expectedDiff: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := testDatadogServiceState(t, r, map[string]any{
+				"format":         `{"host":"%h","url":"%U"}`,
+				"format_is_json": testcase.isJSON,
+			})
+			config := map[string]any{
+				"name":   "service",
+				"domain": []any{map[string]any{"name": "example.com"}},
+				"logging_datadog": []any{map[string]any{
+					"name":           "datadog",
+					"token":          "token",
+					"format":         testcase.format,
+					"format_is_json": testcase.isJSON,
+				}},
+			}
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var diffs []string
+			if diff != nil {
+				for k := range diff.Attributes {
+					if strings.HasPrefix(k, "logging_datadog") {
+						diffs = append(diffs, k)
+					}
+				}
+			}
+			if testcase.expectedDiff && len(diffs) == 0 {
+				t.Error("expected a diff for logging_datadog")
+			}
+			if !testcase.expectedDiff && len(diffs) > 0 {
+				t.Errorf("expected no diff for logging_datadog, got %v", diffs)
+			}
+		})
+	}
+}
+
+func TestCanonicalLoggingFormat(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		format   string
+		expected string
+	}{
+		"whitespace and key order": {
+			format:   "{\n  \"url\": \"%{json.escape(req.url)}V\",\n  \"host\": \"%h\"\n}",
+			expected: `{"host":"%h","url":"%{json.escape(req.url)}V"}`,
+		},
+		"bare placeholders": {
+			format:   `{ "status": %>s, "bytes": %B }`,
+			expected: `{"bytes":%B,"status":%>s}`,
+		},
+		"placeholders inside a string": {
+			format:   `{"request": "%m %U%q", "percent": "100%%"}`,
+			expected: `{"percent":"100%%","request":"%m %U%q"}`,
+		},
+		"placeholder with a quoted argument": {
+			format:   `{"time": "%{strftime(\{"%Y-%m-%dT%H:%M:%S%z"\}, time.start)}V"}`,
+			expected: `{"time":"%{strftime(\{"%Y-%m-%dT%H:%M:%S%z"\}, time.start)}V"}`,
+		},
+		"escaped by jsonencode": {
+			format:   `{"status":"%\u003es"}`,
+			expected: `{"status":"%>s"}`,
+		},
+		"numbers are kept as written": {
+			format:   `{"version": 1.0, "sample": 1e2}`,
+			expected: `{"sample":1e2,"version":1.0}`,
+		},
+		"not JSON": {
+			format:   `%h %l %u %t "%r" %>s %b`,
+			expected: `%h %l %u %t "%r" %>s %b`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if actual := canonicalLoggingFormat(testcase.format); actual != testcase.expected {
+				t.Errorf("expected %s, got %s", testcase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestJSONLoggingFormat(t *testing.T) {
+	h := &DefaultServiceAttributeHandler{
+		key:             "logging_datadog",
+		serviceMetadata: ServiceMetadata{serviceType: ServiceTypeVCL},
+	}
+	format := "{\n  \"status\": %>s,\n  \"host\": \"%h\"\n}"
+	canonical := `{"host":"%h","status":%>s}`
+
+	if vla := h.getVCLLoggingAttributes(map[string]any{"format": format, "format_is_json": true}); vla.format != canonical {
+		t.Errorf("expected an endpoint with format_is_json to be created with format %s, got %s", canonical, vla.format)
+	}
+	if vla := h.getVCLLoggingAttributes(map[string]any{"format": format}); vla.format != format {
+		t.Errorf("expected an endpoint without format_is_json to be created with format %s, got %s", format, vla.format)
+	}
+
+	modified := map[string]any{"format": format}
+	h.canonicalizeModifiedLoggingFormat(map[string]any{"format": format, "format_is_json": true}, modified)
+	if modified["format"] != canonical {
+		t.Errorf("expected an endpoint with format_is_json to be updated with format %s, got %s", canonical, modified["format"])
+	}
+
+	d := resourceServiceVCL().Data(&terraform.InstanceState{
+		ID: "service-id",
+		Attributes: map[string]string{
+			"logging_datadog.#":                "1",
+			"logging_datadog.0.name":           "datadog",
+			"logging_datadog.0.format_version": "2",
+			"logging_datadog.0.format_is_json": "true",
+			"logging_datadog.0.token":          "token",
+			"logging_datadog.0.region":         "US",
+			"logging_datadog.0.format":         format,
+		},
+	})
+	elements := []map[string]any{
+		{"name": "datadog", "format": canonical, "format_version": uint(2)},
+	}
+	h.preserveVCLLoggingAttributes(d, elements)
+	if elements[0]["format"] != format {
+		t.Errorf("expected the format to be kept from state, got %s", elements[0]["format"])
+	}
+
+	elements = []map[string]any{
+		{"name": "datadog", "format": `{"host":"%h"}`, "format_version": uint(2)},
+	}
+	h.preserveVCLLoggingAttributes(d, elements)
+	if elements[0]["format"] != `{"host":"%h"}` {
+		t.Errorf("expected a format changed outside of Terraform to be read, got %s", elements[0]["format"])
+	}
+}
@@ -3,6 +3,7 @@ package fastly
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -65,6 +66,11 @@ type blockSetAttributeHandler struct {
 	handler ServiceCRUDAttributeDefinition
 }
 
+// GetKey returns the name of the nested block.
+func (h *blockSetAttributeHandler) GetKey() string {
+	return h.handler.Key()
+}
+
 func (h *blockSetAttributeHandler) Register(s *schema.Resource) error {
 	s.Schema[h.handler.Key()] = h.handler.GetSchema()
 	return nil
@@ -103,16 +109,12 @@ func (h *blockSetAttributeHandler) Process(ctx context.Context, d *schema.Resour
 		resource := resource.(map[string]any)
 		err := h.handler.Delete(ctx, d, resource, serviceVersion, conn)
 		if err != nil {
-			return err
+			return h.attributeError(resource, err)
 		}
 	}
 
-	for _, resource := range diffResult.Added {
-		resource := resource.(map[string]any)
-		err := h.handler.Create(ctx, d, resource, serviceVersion, conn)
-		if err != nil {
-			return err
-		}
+	if err := h.createAll(ctx, d, diffResult.Added, serviceVersion, conn); err != nil {
+		return err
 	}
 
 	for _, resource := range diffResult.Modified {
@@ -122,13 +124,105 @@ func (h *blockSetAttributeHandler) Process(ctx context.Context, d *schema.Resour
 
 		err := h.handler.Update(ctx, d, resource, modified, serviceVersion, conn)
 		if err != nil {
-			return err
+			return h.attributeError(resource, err)
 		}
 	}
 
 	return nil
 }
 
+// createAll creates the given instances of the nested block one at a time, stopping at the first error. They are all
+// created in the same service version, and the API can reject concurrent changes to a version, so they aren't created
+// concurrently.
+func (h *blockSetAttributeHandler) createAll(ctx context.Context, d *schema.ResourceData, resources []any, serviceVersion int, conn *gofastly.Client) error {
+	for _, resource := range resources {
+		resource := resource.(map[string]any)
+		if err := h.handler.Create(ctx, d, resource, serviceVersion, conn); err != nil {
+			return h.attributeError(resource, err)
+		}
+	}
+	return nil
+}
+
+// attributeError records which instance of the block an error is for.
+func (h *blockSetAttributeHandler) attributeError(resource map[string]any, err error) error {
+	name, _ := resource["name"].(string)
+	return &serviceAttributeError{key: h.handler.Key(), name: name, err: err}
+}
+
+// uploadConnKey is the context key for the client used to upload Compute packages and custom VCL.
+type uploadConnKey struct{}
+
+// withUploadConn returns a context carrying the client to use for uploads, which may have a longer timeout.
+func withUploadConn(ctx context.Context, conn *gofastly.Client) context.Context {
+	return context.WithValue(ctx, uploadConnKey{}, conn)
+}
+
+// uploadConnFromContext returns the client added to the context by withUploadConn, or conn if there isn't one.
+func uploadConnFromContext(ctx context.Context, conn *gofastly.Client) *gofastly.Client {
+	if uploadConn, ok := ctx.Value(uploadConnKey{}).(*gofastly.Client); ok {
+		return uploadConn
+	}
+	return conn
+}
+
+type registryClientKey struct{}
+
+// withRegistryClient returns a context carrying the HTTP client to pull packages from OCI registries with.
+func withRegistryClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, registryClientKey{}, client)
+}
+
+// registryClientFromContext returns the client added to the context by withRegistryClient, or http.DefaultClient if
+// there isn't one.
+func registryClientFromContext(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(registryClientKey{}).(*http.Client); ok && client != nil {
+		return client
+	}
+	return http.DefaultClient
+}
+
+// CustomizeDiff calls the CustomizeDiff of the wrapped handler if it implements ServiceAttributeDiffCustomizer.
+func (h *blockSetAttributeHandler) CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta any) error {
+	if c, ok := h.handler.(ServiceAttributeDiffCustomizer); ok {
+		return c.CustomizeDiff(ctx, d, meta)
+	}
+	return nil
+}
+
+// removedSetElements returns the elements of the nested block that are present in the prior state but not in the
+// plan, matching elements by their "name" attribute in the same way as Process.
+func removedSetElements(d *schema.ResourceDiff, key string) ([]map[string]any, error) {
+	oldVal, newVal := d.GetChange(key)
+	oldSet, ok := oldVal.(*schema.Set)
+	if !ok {
+		return nil, nil
+	}
+	newSet, ok := newVal.(*schema.Set)
+	if !ok {
+		newSet = new(schema.Set)
+	}
+
+	setDiff := NewSetDiff(func(resource any) (any, error) {
+		t, ok := resource.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("resource failed to be type asserted: %+v", resource)
+		}
+		return t["name"], nil
+	})
+
+	diffResult, err := setDiff.Diff(oldSet, newSet)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make([]map[string]any, 0, len(diffResult.Deleted))
+	for _, resource := range diffResult.Deleted {
+		removed = append(removed, resource.(map[string]any))
+	}
+	return removed, nil
+}
+
 func (h *blockSetAttributeHandler) HasChange(d *schema.ResourceData) bool {
 	return d.HasChanges(h.handler.Key())
 }
@@ -0,0 +1,65 @@
+package fastly
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// recordingCRUDAttribute records the instances created through it, failing for any instance named in failures.
+type recordingCRUDAttribute struct {
+	ServiceCRUDAttributeDefinition
+
+	created  []string
+	failures map[string]error
+}
+
+func (r *recordingCRUDAttribute) Key() string {
+	return "backend"
+}
+
+func (r *recordingCRUDAttribute) Create(_ context.Context, _ *schema.ResourceData, resource map[string]any, _ int, _ *gofastly.Client) error {
+	name := resource["name"].(string)
+	if err, ok := r.failures[name]; ok {
+		return err
+	}
+	r.created = append(r.created, name)
+	return nil
+}
+
+func TestBlockSetAttributeHandler_createAll(t *testing.T) {
+	resources := []any{
+		map[string]any{"name": "a"},
+		map[string]any{"name": "b"},
+		map[string]any{"name": "c"},
+	}
+
+	r := &recordingCRUDAttribute{}
+	h := &blockSetAttributeHandler{handler: r}
+	if err := h.createAll(context.Background(), nil, resources, 1, &gofastly.Client{}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := []string{"a", "b", "c"}; !reflect.DeepEqual(r.created, expected) {
+		t.Errorf("expected %v to be created in order, got %v", expected, r.created)
+	}
+
+	rateLimited := &gofastly.HTTPError{StatusCode: http.StatusTooManyRequests}
+	r = &recordingCRUDAttribute{failures: map[string]error{"b": rateLimited}}
+	h = &blockSetAttributeHandler{handler: r}
+	err := h.createAll(context.Background(), nil, resources, 1, &gofastly.Client{})
+	if !errors.Is(err, rateLimited) {
+		t.Fatalf("expected the error to be returned, got %v", err)
+	}
+	var attrErr *serviceAttributeError
+	if !errors.As(err, &attrErr) || attrErr.name != "b" {
+		t.Errorf("expected the error to be for the instance that failed, got %v", err)
+	}
+	if expected := []string{"a"}; !reflect.DeepEqual(r.created, expected) {
+		t.Errorf("expected creating to stop at the first error, got %v", r.created)
+	}
+}
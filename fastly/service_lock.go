@@ -0,0 +1,96 @@
+package fastly
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+)
+
+// serviceLockTTL is how long the lock of an apply is honoured, so that an apply that was interrupted before it could
+// release its lock doesn't block other applies forever.
+const serviceLockTTL = time.Hour
+
+// serviceLockPattern matches the marker an apply with apply_lock set adds to the comment of the version it is
+// preparing and activating, capturing the ID of the apply and when its lock expires.
+var serviceLockPattern = regexp.MustCompile(`^\[terraform apply lock ([0-9a-f]+) until ([^\]]+)\] ?`)
+
+// serviceLock is a cooperative lock on a service, held by an apply while it prepares and activates a new version. The lock is a
+// marker in the comment of the version, so it is only honoured by applies that have apply_lock set.
+type serviceLock struct {
+	id      string
+	expires time.Time
+}
+
+// newServiceLock returns a lock with a random ID that expires after serviceLockTTL.
+func newServiceLock() (*serviceLock, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("error generating the ID of the apply lock: %s", err)
+	}
+	return &serviceLock{id: hex.EncodeToString(b), expires: time.Now().Add(serviceLockTTL).UTC().Truncate(time.Second)}, nil
+}
+
+// comment returns versionComment with the marker of the lock.
+func (l *serviceLock) comment(versionComment string) string {
+	marker := fmt.Sprintf("[terraform apply lock %s until %s]", l.id, l.expires.Format(time.RFC3339))
+	if versionComment == "" {
+		return marker
+	}
+	return marker + " " + versionComment
+}
+
+// check returns an error if another apply holds a lock on the service. version is the version this apply has
+// marked, or 0 if it hasn't marked one yet. When two applies mark a version at the same time, the one that marked the
+// lower version number holds the lock.
+func (l *serviceLock) check(conn *gofastly.Client, serviceID string, version int) error {
+	versions, err := conn.ListVersions(&gofastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return fmt.Errorf("error listing the versions of Fastly Service (%s) to check for an apply lock: %s", serviceID, err)
+	}
+	if holder, expires := serviceLockHolder(versions, l.id, version, time.Now()); holder != nil {
+		return fmt.Errorf("another apply is updating Fastly Service (%s): it holds the apply lock on version %d until %s. Wait for it to finish, or if it was interrupted, remove the lock from the comment of version %d", serviceID, holder.Number, expires.Format(time.RFC3339), holder.Number)
+	}
+	return nil
+}
+
+// release replaces the comment of version, which holds the lock, with versionComment.
+func (l *serviceLock) release(conn *gofastly.Client, serviceID string, version int, versionComment string) error {
+	log.Printf("[DEBUG] Releasing the apply lock on Fastly Service (%s), version (%d)", serviceID, version)
+	_, err := conn.UpdateVersion(&gofastly.UpdateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: version,
+		Comment:        gofastly.String(versionComment),
+	})
+	if err != nil {
+		return fmt.Errorf("error releasing the apply lock on Fastly Service (%s), version (%d): %s", serviceID, version, err)
+	}
+	return nil
+}
+
+// serviceLockHolder returns the version with a lock that hasn't expired at now, other than the lock with ID id, and
+// when the lock expires. When version isn't 0, only locks on lower version numbers are considered. The lock is held
+// until the version has been activated, so the comments of active and locked versions are checked too.
+func serviceLockHolder(versions []*gofastly.Version, id string, version int, now time.Time) (*gofastly.Version, time.Time) {
+	for _, v := range versions {
+		if version != 0 && v.Number >= version {
+			continue
+		}
+		m := serviceLockPattern.FindStringSubmatch(v.Comment)
+		if m == nil || m[1] == id {
+			continue
+		}
+		expires, err := time.Parse(time.RFC3339, m[2])
+		if err != nil || !now.Before(expires) {
+			continue
+		}
+		return v, expires
+	}
+	return nil, time.Time{}
+}
@@ -0,0 +1,67 @@
+package fastly
+
+import (
+	"testing"
+	"time"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+)
+
+func TestServiceLockHolder(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	ours := &serviceLock{id: "0123456789abcdef", expires: now.Add(serviceLockTTL)}
+	theirs := &serviceLock{id: "fedcba9876543210", expires: now.Add(time.Minute)}
+	expired := &serviceLock{id: "00000000000000ff", expires: now.Add(-time.Minute)}
+
+	if expected, actual := "[terraform apply lock 0123456789abcdef until 2022-06-01T13:00:00Z] Deploy", ours.comment("Deploy"); actual != expected {
+		t.Errorf("expected comment %q, got %q", expected, actual)
+	}
+
+	for name, testcase := range map[string]struct {
+		versions []*gofastly.Version
+		version  int
+		expected int
+	}{
+		"no locks": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: "draft"}},
+		},
+		"another apply's lock": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: theirs.comment("Deploy")}},
+			expected: 2,
+		},
+		"our lock": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: ours.comment("")}},
+		},
+		"expired lock": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: expired.comment("")}},
+		},
+		"version being activated": {
+			versions: []*gofastly.Version{{Number: 1, Active: true, Locked: true, Comment: theirs.comment("")}},
+			expected: 1,
+		},
+		"lock on an earlier version": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: theirs.comment("")}, {Number: 3, Comment: ours.comment("")}},
+			version:  3,
+			expected: 2,
+		},
+		"lock on a later version": {
+			versions: []*gofastly.Version{{Number: 1, Active: true}, {Number: 2, Comment: ours.comment("")}, {Number: 3, Comment: theirs.comment("")}},
+			version:  2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			holder, expires := serviceLockHolder(testcase.versions, ours.id, testcase.version, now)
+			if testcase.expected == 0 {
+				if holder != nil {
+					t.Errorf("expected no lock, got version %d", holder.Number)
+				}
+				return
+			}
+			if holder == nil || holder.Number != testcase.expected {
+				t.Errorf("expected the lock on version %d, got %v", testcase.expected, holder)
+			} else if !expires.Equal(theirs.expires) {
+				t.Errorf("expected the lock to expire at %s, got %s", theirs.expires, expires)
+			}
+		})
+	}
+}
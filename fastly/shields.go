@@ -0,0 +1,175 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fallbackShields are the shield codes used to validate shields when the
+// datacenters API can't be reached. It may be out of date.
+var fallbackShields = []string{
+	"amsterdam-nl",
+	"bfi-wa-us",
+	"bos-ma-us",
+	"brussels-be",
+	"bur-ca-us",
+	"bwi-va-us",
+	"cdg-par-fr",
+	"chi-il-us",
+	"cmh-oh-us",
+	"dca-dc-us",
+	"dfw-tx-us",
+	"dub-ie",
+	"frankfurt-de",
+	"gru-br-sa",
+	"hkg-hongkong-hk",
+	"hnd-tokyo-jp",
+	"iad-va-us",
+	"itm-osaka-jp",
+	"jfk-ny-us",
+	"lga-ny-us",
+	"london-uk",
+	"london_city-uk",
+	"madrid-es",
+	"mdw-il-us",
+	"mia-fl-us",
+	"msp-mn-us",
+	"pao-ca-us",
+	"pdk-ga-us",
+	"sin-singapore-sg",
+	"sjc-ca-us",
+	"stockholm-bma",
+	"sydney-au",
+	"tyo-tokyo-jp",
+	"yul-qc-ca",
+	"yvr-bc-ca",
+	"yyz-on-ca",
+}
+
+// shieldCodes returns the valid shield codes, fetching them from the
+// datacenters API until a fetch succeeds. The fallback list is used if the API
+// can't be reached, e.g. because ctx was cancelled, in which case fallback is
+// true and the next call tries again.
+func (c *APIClient) shieldCodes(ctx context.Context) (shields []string, fallback bool) {
+	if c.offline {
+		return fallbackShields, true
+	}
+
+	c.shieldsMu.Lock()
+	defer c.shieldsMu.Unlock()
+	if c.shields != nil {
+		return c.shields, false
+	}
+	datacenters, err := c.connWithContext(ctx).AllDatacenters()
+	if err != nil {
+		log.Printf("[WARN] Error fetching datacenters, validating shields against the built-in list: %s", err)
+		return fallbackShields, true
+	}
+	shields = []string{}
+	for _, dc := range shieldDatacenters(datacenters, "") {
+		shields = append(shields, dc.Shield)
+	}
+	c.shields = shields
+	return shields, false
+}
+
+// validateBlockShields checks the shield of each instance of the key block
+// against the valid shield codes, if the provider's validate_shields is set.
+func validateBlockShields(ctx context.Context, d *schema.ResourceDiff, key string, meta any) error {
+	client, ok := meta.(*APIClient)
+	if !ok || !client.validateShields {
+		return nil
+	}
+	resources, ok := d.Get(key).(*schema.Set)
+	if !ok || resources.Len() == 0 {
+		return nil
+	}
+
+	shields, fallback := client.shieldCodes(ctx)
+
+	var invalid []string
+	for _, r := range resources.List() {
+		resource := r.(map[string]any)
+		// Shields that aren't known until apply are empty when planning.
+		shield, _ := resource["shield"].(string)
+		if problem := validateShield(shield, shields); problem != "" {
+			invalid = append(invalid, fmt.Sprintf("%q %s", resource["name"], problem))
+		}
+	}
+
+	if len(invalid) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("invalid %s: %s", key, strings.Join(invalid, ", "))
+	if fallback {
+		err = fmt.Errorf("%w (checked against a built-in list of shields because the datacenters API couldn't be reached, which may be out of date; set validate_shields = false in the provider to skip the check)", err)
+	}
+	return err
+}
+
+// validateShield returns why shield isn't one of shields, suggesting the
+// closest valid code. An empty shield is valid.
+func validateShield(shield string, shields []string) string {
+	if shield == "" {
+		return ""
+	}
+	i := sort.SearchStrings(shields, shield)
+	if i < len(shields) && shields[i] == shield {
+		return ""
+	}
+
+	problem := fmt.Sprintf("has unknown shield %q", shield)
+	if suggestion := closestShield(shield, shields); suggestion != "" {
+		problem = fmt.Sprintf("%s, did you mean %q?", problem, suggestion)
+	}
+	return problem
+}
+
+// closestShield returns the shield code with the smallest edit distance to
+// shield, or "" if none is close enough to be a likely typo.
+func closestShield(shield string, shields []string) string {
+	maxDistance := len(shield) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	closest := ""
+	for _, s := range shields {
+		if distance := editDistance(strings.ToLower(shield), s); distance <= maxDistance {
+			closest, maxDistance = s, distance-1
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,100 @@
+package fastly
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestValidateShield(t *testing.T) {
+	if !sort.StringsAreSorted(fallbackShields) {
+		t.Fatal("expected the fallback shields to be sorted")
+	}
+
+	for name, testcase := range map[string]struct {
+		shield   string
+		expected string
+	}{
+		"empty":   {},
+		"valid":   {shield: "lga-ny-us"},
+		"typo":    {shield: "lga-ny-su", expected: `has unknown shield "lga-ny-su", did you mean "lga-ny-us"?`},
+		"case":    {shield: "LGA-NY-US", expected: `has unknown shield "LGA-NY-US", did you mean "lga-ny-us"?`},
+		"unknown": {shield: "nowhere", expected: `has unknown shield "nowhere"`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := validateShield(testcase.shield, fallbackShields); got != testcase.expected {
+				t.Errorf("expected %q, got %q", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateBlockShields(t *testing.T) {
+	available := true
+	requests := 0
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"code": "LHR", "shield": "london-uk"}, {"code": "NEW"}, {"code": "XYZ", "shield": "xyz-new-us"}]`))
+	})).conn
+
+	diff := func(meta any, shield string) error {
+		_, err := resourceServiceVCL().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]any{
+			"name":   "service",
+			"domain": []any{map[string]any{"name": "example.com"}},
+			"backend": []any{
+				map[string]any{"name": "origin", "address": "origin.example.com", "shield": shield},
+			},
+		}), meta)
+		return err
+	}
+
+	meta := &APIClient{conn: conn, apiKey: "someapikey", validateShields: true}
+	if err := diff(meta, "xyz-new-us"); err != nil {
+		t.Errorf("expected a shield from the API to be valid, got %s", err)
+	}
+	err := diff(meta, "london-kk")
+	if err == nil || !strings.Contains(err.Error(), `invalid backend: "origin" has unknown shield "london-kk", did you mean "london-uk"?`) {
+		t.Errorf("expected the unknown shield to be reported, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected the datacenters to be fetched once, got %d requests", requests)
+	}
+
+	if err := diff(&APIClient{conn: conn, apiKey: "someapikey"}, "london-kk"); err != nil {
+		t.Errorf("expected shields not to be checked unless validate_shields is set, got %s", err)
+	}
+
+	available = false
+	meta = &APIClient{conn: conn, apiKey: "someapikey", validateShields: true}
+	if err := diff(meta, "lga-ny-us"); err != nil {
+		t.Errorf("expected a shield from the built-in list to be valid, got %s", err)
+	}
+	err = diff(meta, "xyz-new-us")
+	if err == nil || !strings.Contains(err.Error(), "built-in list of shields") {
+		t.Errorf("expected the error to say the built-in list was used, got %v", err)
+	}
+
+	available = true
+	if err := diff(meta, "xyz-new-us"); err != nil {
+		t.Errorf("expected the datacenters to be fetched again after an error, got %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	meta = &APIClient{conn: conn, apiKey: "someapikey", validateShields: true}
+	if _, fallback := meta.shieldCodes(ctx); !fallback {
+		t.Error("expected the built-in list when the context is cancelled")
+	}
+	if shields, fallback := meta.shieldCodes(context.Background()); fallback || len(shields) != 2 {
+		t.Errorf("expected the datacenters to be fetched after a cancelled context, got %v, %t", shields, fallback)
+	}
+}
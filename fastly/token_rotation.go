@@ -0,0 +1,59 @@
+package fastly
+
+import (
+	"log"
+	"net/http"
+	"sync/atomic"
+)
+
+// apiKeyHeader is the header go-fastly authenticates requests with.
+const apiKeyHeader = "Fastly-Key"
+
+// tokenFallbackTransport is a http.RoundTripper that makes a request again with a secondary API token when the API
+// rejects the primary token, e.g. because it has expired or been revoked while tokens are being rotated. Once the
+// primary token has been rejected, the secondary token is used for all further requests.
+type tokenFallbackTransport struct {
+	base      http.RoundTripper
+	secondary string
+	// rejected is set to 1 once the API has rejected the primary token.
+	rejected int32
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *tokenFallbackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.LoadInt32(&t.rejected) == 1 {
+		return t.base.RoundTrip(withAPIKey(req, t.secondary))
+	}
+
+	// A request with a body can only be made again if the body can be read again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || req.Header.Get(apiKeyHeader) == t.secondary {
+		return resp, err
+	}
+
+	retry := withAPIKey(req, t.secondary)
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	log.Printf("[WARN] The Fastly API rejected the api_key, retrying %s %s with api_key_secondary", req.Method, req.URL.Path)
+	resp, err = t.base.RoundTrip(retry)
+	if err == nil && resp.StatusCode != http.StatusUnauthorized && atomic.CompareAndSwapInt32(&t.rejected, 0, 1) {
+		log.Printf("[WARN] The Fastly API accepted api_key_secondary, using it for all further requests")
+	}
+	return resp, err
+}
+
+// withAPIKey returns a copy of req authenticated with key.
+func withAPIKey(req *http.Request, key string) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set(apiKeyHeader, key)
+	return r
+}
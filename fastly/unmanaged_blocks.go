@@ -0,0 +1,111 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// unmanagedBlock is a block that exists on the version of a service Terraform reads, but isn't in its configuration.
+type unmanagedBlock struct {
+	key  string
+	name string
+}
+
+// detectsUnmanagedBlocks returns whether a block is checked for elements that aren't in the configuration when
+// detect_unmanaged_blocks is set: backends, domains and logging endpoints, which are the blocks most often added
+// outside of Terraform.
+func detectsUnmanagedBlocks(key string) bool {
+	return key == "backend" || key == "domain" || strings.HasPrefix(key, "logging_")
+}
+
+// findUnmanagedBlocks returns the backends, domains and logging endpoints of the version s of the service that aren't
+// in its configuration, ordered by block and name. Blocks that managed_blocks doesn't list are left to the UI, so
+// they aren't reported.
+//
+// A block is only refreshed when the configuration has one, so the elements of a block that is configured are in
+// state, and those that aren't in the configuration show as removals in the plan. The blocks that aren't configured
+// are read here, by readDetachedBlocks.
+func findUnmanagedBlocks(ctx context.Context, d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client, handlers []ServiceAttributeDefinition) ([]unmanagedBlock, error) {
+	var unmanaged []unmanagedBlock
+	for _, a := range handlers {
+		h, ok := a.(*blockSetAttributeHandler)
+		if !ok || !detectsUnmanagedBlocks(h.GetKey()) || !managesHandler(d, a) {
+			continue
+		}
+		key := h.GetKey()
+		if set, ok := d.Get(key).(*schema.Set); !ok || set.Len() > 0 {
+			continue
+		}
+
+		blocks, err := readDetachedBlocks(ctx, h, s, conn)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range blocks.List() {
+			name, _ := v.(map[string]any)["name"].(string)
+			unmanaged = append(unmanaged, unmanagedBlock{key: key, name: name})
+		}
+	}
+
+	sort.SliceStable(unmanaged, func(i, j int) bool {
+		if unmanaged[i].key != unmanaged[j].key {
+			return unmanaged[i].key < unmanaged[j].key
+		}
+		return unmanaged[i].name < unmanaged[j].name
+	})
+	return unmanaged, nil
+}
+
+// readDetachedBlocks returns the blocks of h of the version s of a service, read into a ResourceData of their own so
+// that the state of the service isn't changed.
+func readDetachedBlocks(ctx context.Context, h *blockSetAttributeHandler, s *gofastly.ServiceDetail, conn *gofastly.Client) (*schema.Set, error) {
+	r := &schema.Resource{Schema: map[string]*schema.Schema{
+		"imported": {Type: schema.TypeBool, Computed: true},
+	}}
+	if err := h.Register(r); err != nil {
+		return nil, err
+	}
+	scratch := r.Data(&terraform.InstanceState{ID: s.ID, Attributes: map[string]string{"imported": "true"}})
+	if err := h.Read(ctx, scratch, s, conn); err != nil {
+		return nil, err
+	}
+	return scratch.Get(h.GetKey()).(*schema.Set), nil
+}
+
+// readUnmanagedBlocks sets unmanaged_blocks when detect_unmanaged_blocks is set, and returns a warning for each block
+// that isn't in the configuration.
+func readUnmanagedBlocks(ctx context.Context, d *schema.ResourceData, s *gofastly.ServiceDetail, conn *gofastly.Client, handlers []ServiceAttributeDefinition) diag.Diagnostics {
+	var unmanaged []unmanagedBlock
+	if d.Get("detect_unmanaged_blocks").(bool) && !d.Get("imported").(bool) && s.ActiveVersion.Number != 0 {
+		log.Printf("[DEBUG] Looking for blocks of Fastly Service (%s), version (%d) that aren't in the configuration", d.Id(), s.ActiveVersion.Number)
+		var err error
+		if unmanaged, err = findUnmanagedBlocks(ctx, d, s, conn, handlers); err != nil {
+			return diag.Errorf("error looking for unmanaged blocks of Fastly Service (%s): %s", d.Id(), err)
+		}
+	}
+
+	var diags diag.Diagnostics
+	blocks := make([]map[string]any, len(unmanaged))
+	for i, b := range unmanaged {
+		blocks[i] = map[string]any{"block": b.key, "name": b.name}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s %q isn't managed by Terraform", b.key, b.name),
+			Detail: fmt.Sprintf("Version %d of Fastly Service (%s) has the %s %q, but the configuration has no %s blocks, so Terraform neither refreshes nor removes it, and keeps it in each version it clones. Add it to the configuration, or remove it from the service.",
+				s.ActiveVersion.Number, d.Id(), b.key, b.name, b.key),
+		})
+	}
+	if err := d.Set("unmanaged_blocks", blocks); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
@@ -0,0 +1,89 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	gofastly "github.com/fastly/go-fastly/v6/fastly"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestReadUnmanagedBlocks(t *testing.T) {
+	var requested []string
+	conn := testAPIClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		prefix := "/service/service-id/version/3/"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		endpoint := strings.TrimPrefix(r.URL.Path, prefix)
+		requested = append(requested, endpoint)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch endpoint {
+		case "backend":
+			fmt.Fprint(w, `[{"name": "shadow", "address": "example.com"}]`)
+		case "logging/s3":
+			fmt.Fprint(w, `[{"name": "archive", "bucket_name": "logs"}, {"name": "audit", "bucket_name": "audit"}]`)
+		default:
+			fmt.Fprint(w, `[]`)
+		}
+	})).conn
+
+	d := resourceServiceVCL().Data(&terraform.InstanceState{
+		ID: "service-id",
+		Attributes: map[string]string{
+			"detect_unmanaged_blocks": "true",
+			"domain.#":                "1",
+			"domain.0.name":           "example.com",
+		},
+	})
+	s := &gofastly.ServiceDetail{ID: "service-id", ActiveVersion: gofastly.Version{Number: 3}}
+
+	diags := readUnmanagedBlocks(context.Background(), d, s, conn, vclService.GetAttributeHandler())
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var summaries []string
+	for _, diag := range diags {
+		summaries = append(summaries, diag.Summary)
+	}
+	expected := []string{
+		`backend "shadow" isn't managed by Terraform`,
+		`logging_s3 "archive" isn't managed by Terraform`,
+		`logging_s3 "audit" isn't managed by Terraform`,
+	}
+	if !reflect.DeepEqual(summaries, expected) {
+		t.Errorf("expected warnings %v, got %v", expected, summaries)
+	}
+	if actual := d.Get("unmanaged_blocks.#"); actual != 3 {
+		t.Errorf("expected 3 unmanaged blocks, got %v", actual)
+	}
+	if actual := d.Get("unmanaged_blocks.1").(map[string]any); actual["block"] != "logging_s3" || actual["name"] != "archive" {
+		t.Errorf("expected the second unmanaged block to be logging_s3 archive, got %v", actual)
+	}
+	for _, endpoint := range requested {
+		if endpoint == "domain" {
+			t.Error("expected the configured domains not to be read again")
+		}
+	}
+	if set := d.Get("backend").(*schema.Set); set.Len() != 0 {
+		t.Errorf("expected the state of the backends to be unchanged, got %d backends", set.Len())
+	}
+
+	if err := d.Set("detect_unmanaged_blocks", false); err != nil {
+		t.Fatal(err)
+	}
+	requested = nil
+	if diags := readUnmanagedBlocks(context.Background(), d, s, conn, vclService.GetAttributeHandler()); len(diags) > 0 {
+		t.Errorf("expected no warnings without detect_unmanaged_blocks, got %v", diags)
+	}
+	if len(requested) > 0 || d.Get("unmanaged_blocks.#") != 0 {
+		t.Errorf("expected no requests and no unmanaged blocks without detect_unmanaged_blocks, got %v and %v", requested, d.Get("unmanaged_blocks"))
+	}
+}
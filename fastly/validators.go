@@ -1,9 +1,15 @@
 package fastly
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 	"github.com/hashicorp/go-cty/cty"
@@ -12,8 +18,22 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// validateLoggingFormatVersion returns a schema validation function that checks the format version is 1 or 2, and
+// warns that version 1 is deprecated.
 func validateLoggingFormatVersion() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.IntBetween(1, 2))
+	validateRange := validation.ToDiagFunc(validation.IntBetween(1, 2))
+	return func(i any, path cty.Path) diag.Diagnostics {
+		diags := validateRange(i, path)
+		if !diags.HasError() && i.(int) == 1 {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "format_version 1 is deprecated",
+				Detail:        "Fastly is retiring version 1 of the custom logging format. The endpoint is configured with format_version 1 until it is changed. Version 2 interprets the same formatting directives, but places the logging call in vcl_log rather than vcl_deliver. Set format_version to 2 to remove this warning.",
+				AttributePath: path,
+			})
+		}
+		return diags
+	}
 }
 
 func validateLoggingMessageType() schema.SchemaValidateDiagFunc {
@@ -25,34 +45,90 @@ func validateLoggingMessageType() schema.SchemaValidateDiagFunc {
 	}, false))
 }
 
+// allowEmpty returns a schema validation function that accepts an empty string, and otherwise validates with f. It is
+// for optional attributes of blocks that have no default: the attributes a block doesn't set are stored as empty
+// strings, so the configuration Terraform generates when a service is imported sets them to "".
+func allowEmpty(f schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
+	return func(i any, path cty.Path) diag.Diagnostics {
+		if s, ok := i.(string); ok && s == "" {
+			return nil
+		}
+		return f(i, path)
+	}
+}
+
 func validateLoggingCompressionCodec() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.StringInSlice([]string{
+	return allowEmpty(validation.ToDiagFunc(validation.StringInSlice([]string{
 		"zstd",
 		"snappy",
 		"gzip",
-	}, false))
+	}, false)))
+}
+
+// validateLoggingRequestMax returns a schema validation function that checks a limit on the logs batched into one
+// request isn't negative. 0 means the batch size isn't limited.
+func validateLoggingRequestMax() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.IntAtLeast(0))
 }
 
 func validateLoggingPlacement() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.StringInSlice([]string{
+	return allowEmpty(validation.ToDiagFunc(validation.StringInSlice([]string{
 		"none",
 		"waf_debug",
-	}, false))
+	}, false)))
 }
 
 func validateLoggingServerSideEncryption() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.StringInSlice([]string{
+	return allowEmpty(validation.ToDiagFunc(validation.StringInSlice([]string{
 		string(gofastly.S3ServerSideEncryptionAES),
 		string(gofastly.S3ServerSideEncryptionKMS),
+	}, false)))
+}
+
+// validateLoggingRegion returns a schema validation function that checks the region of a logging endpoint with US and
+// EU ingest endpoints is one the API accepts. The API returns the region in upper case, so lower case isn't accepted
+// to avoid a diff after each apply.
+func validateLoggingRegion() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice([]string{
+		"US",
+		"EU",
 	}, false))
 }
 
+func validateServiceDestroyBehavior() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringInSlice([]string{
+		DestroyBehaviorDelete,
+		DestroyBehaviorDeactivate,
+	}, false))
+}
+
+// validateHealthCheckExpectedResponse returns a schema validation function that checks the status code a health check
+// expects is an HTTP status code.
+func validateHealthCheckExpectedResponse() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.IntBetween(100, 599))
+}
+
+// validateBackendTimeout returns a schema validation function that checks whether a backend timeout, in milliseconds,
+// is positive and at most max, the longest the Fastly platform allows.
+func validateBackendTimeout(max int) schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(val any, key string) ([]string, []error) {
+		v := val.(int)
+		if v < 1 {
+			return nil, []error{fmt.Errorf("expected %s to be at least 1 millisecond, got %d", key, v)}
+		}
+		if v > max {
+			return nil, []error{fmt.Errorf("expected %s to be at most %d milliseconds (%s), the longest Fastly allows, got %d. Longer timeouts are rejected when the version is activated", key, max, time.Duration(max)*time.Millisecond, v)}
+		}
+		return nil, nil
+	})
+}
+
 func validateDirectorQuorum() schema.SchemaValidateDiagFunc {
 	return validation.ToDiagFunc(validation.IntBetween(0, 100))
 }
 
 func validateDirectorType() schema.SchemaValidateDiagFunc {
-	return validation.ToDiagFunc(validation.IntInSlice([]int{1, 3, 4}))
+	return validation.ToDiagFunc(validation.StringInSlice([]string{"random", "hash", "client", "1", "3", "4"}, false))
 }
 
 func validateConditionType() schema.SchemaValidateDiagFunc {
@@ -107,6 +183,23 @@ func validateRuleStatusType() schema.SchemaValidateDiagFunc {
 	}, false))
 }
 
+func validateWAFRuleRevision() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(i any, k string) (s []string, es []error) {
+		v, ok := i.(string)
+		if !ok {
+			es = append(es, fmt.Errorf("expected type of %s to be string", k))
+			return
+		}
+		if v == latestRuleRevision {
+			return
+		}
+		if n, err := strconv.Atoi(v); err != nil || n < 1 {
+			es = append(es, fmt.Errorf("expected %s to be a positive revision number or %q, got %q", k, latestRuleRevision, v))
+		}
+		return
+	})
+}
+
 func validateDictionaryItems() schema.SchemaValidateDiagFunc {
 	max := gofastly.MaximumDictionarySize
 
@@ -150,6 +243,17 @@ func validateUserRole() schema.SchemaValidateDiagFunc {
 	))
 }
 
+// validateOCIReference returns a schema validation function that checks whether a string is a reference to an artifact
+// in an OCI registry that is pinned by digest.
+func validateOCIReference() schema.SchemaValidateDiagFunc {
+	return allowEmpty(validation.ToDiagFunc(func(val any, key string) ([]string, []error) {
+		if _, err := parseOCIReference(val.(string)); err != nil {
+			return nil, []error{fmt.Errorf("invalid %s: %s", key, err)}
+		}
+		return nil, nil
+	}))
+}
+
 // validatePEMBlock returns a schema validation function that checks whether a string contains a single PEM block of
 // type `pemType`.
 func validatePEMBlock(pemType string) schema.SchemaValidateDiagFunc {
@@ -194,6 +298,357 @@ func validatePEMBlocks(pemType string) schema.SchemaValidateDiagFunc {
 	})
 }
 
+// validatePEMCertificates returns a schema validation function that checks whether a string is one or more PEM-encoded
+// X.509 certificates, e.g. a certificate followed by its intermediates, or a bundle of CA certificates. An empty string
+// is valid.
+func validatePEMCertificates() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(val any, key string) ([]string, []error) {
+		rest := []byte(strings.TrimSpace(val.(string)))
+		if len(rest) == 0 {
+			return nil, nil
+		}
+		for n := 1; len(rest) > 0; n++ {
+			// pem.Decode skips anything before a block, which would otherwise go unnoticed.
+			if !bytes.HasPrefix(rest, []byte("-----BEGIN ")) {
+				return nil, []error{fmt.Errorf("expected %s to only contain PEM-format certificates, found something else before certificate %d", key, n)}
+			}
+			var b *pem.Block
+			b, rest = pem.Decode(rest)
+			if b == nil {
+				return nil, []error{fmt.Errorf("expected %s to only contain PEM-format certificates, certificate %d isn't a valid PEM-format block", key, n)}
+			}
+			if b.Type != "CERTIFICATE" {
+				return nil, []error{fmt.Errorf("expected %s to only contain PEM-format certificates, block %d is of type '%s'", key, n, b.Type)}
+			}
+			if _, err := x509.ParseCertificate(b.Bytes); err != nil {
+				return nil, []error{fmt.Errorf("expected %s to only contain valid certificates, certificate %d is invalid: %s", key, n, err)}
+			}
+			rest = bytes.TrimSpace(rest)
+		}
+		return nil, nil
+	})
+}
+
+// validatePEMPrivateKey returns a schema validation function that checks whether a string is a single PEM-encoded
+// PKCS #1, PKCS #8 or SEC 1 private key. An empty string is valid. Errors never include the value.
+func validatePEMPrivateKey() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(val any, key string) ([]string, []error) {
+		v := []byte(strings.TrimSpace(val.(string)))
+		if len(v) == 0 {
+			return nil, nil
+		}
+		b, rest := pem.Decode(v)
+		if b == nil || !bytes.HasPrefix(v, []byte("-----BEGIN ")) || len(rest) != 0 {
+			return nil, []error{fmt.Errorf("expected %s to be a single PEM-format private key", key)}
+		}
+		var err error
+		switch b.Type {
+		case "PRIVATE KEY":
+			_, err = x509.ParsePKCS8PrivateKey(b.Bytes)
+		case "RSA PRIVATE KEY":
+			_, err = x509.ParsePKCS1PrivateKey(b.Bytes)
+		case "EC PRIVATE KEY":
+			_, err = x509.ParseECPrivateKey(b.Bytes)
+		default:
+			return nil, []error{fmt.Errorf("expected %s to be a PEM-format private key, it is of type '%s'", key, b.Type)}
+		}
+		if err != nil {
+			return nil, []error{fmt.Errorf("expected %s to be a valid private key: %s", key, err)}
+		}
+		return nil, nil
+	})
+}
+
+// validateAll returns a schema validation function that returns the diagnostics of all the validators.
+func validateAll(validators ...schema.SchemaValidateDiagFunc) schema.SchemaValidateDiagFunc {
+	return func(i any, path cty.Path) diag.Diagnostics {
+		var diags diag.Diagnostics
+		for _, validator := range validators {
+			diags = append(diags, validator(i, path)...)
+		}
+		return diags
+	}
+}
+
+// validateGzipContentType returns a schema validation function that checks whether a string is a MIME type without
+// parameters, e.g. text/html.
+func validateGzipContentType() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]*/[a-zA-Z0-9][a-zA-Z0-9!#$&^_.+-]*$`), "expected a MIME type without parameters, e.g. text/html"))
+}
+
+// validateGzipExtension returns a schema validation function that checks whether a string is a file extension, with
+// or without a leading dot, e.g. css.
+func validateGzipExtension() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^\.?[a-zA-Z0-9_+-]+$`), "expected a file extension, e.g. css"))
+}
+
+// validateACLEntryIP returns a schema validation function that checks whether a string is an IP address, optionally
+// in CIDR notation.
+func validateACLEntryIP() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(func(val any, key string) ([]string, []error) {
+		if _, _, err := normalizeACLEntry(val.(string), ""); err != nil {
+			return nil, []error{fmt.Errorf("expected %s to be an IP address or a CIDR: %s", key, err)}
+		}
+		return nil, nil
+	})
+}
+
+// validateACLEntrySubnet returns a schema validation function that checks whether a string is a subnet mask length.
+// Whether the length is valid for the entry's IP address is checked when planning.
+func validateACLEntrySubnet() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[0-9]{1,3}$`), "expected a subnet mask length, e.g. 24"))
+}
+
+// validateSHA256 returns a schema validation function that checks whether a string is a hex encoded SHA-256 checksum.
+func validateSHA256() schema.SchemaValidateDiagFunc {
+	return allowEmpty(validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[0-9a-fA-F]{64}$`), "expected a hex encoded SHA-256 checksum")))
+}
+
+// validateDigitalOceanSpacesRegion returns a schema validation function that checks whether a string looks like the
+// slug of a DigitalOcean Spaces region, e.g. sfo3.
+func validateDigitalOceanSpacesRegion() schema.SchemaValidateDiagFunc {
+	return allowEmpty(validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[a-z]{3}[0-9]$`), "expected the slug of a DigitalOcean Spaces region, e.g. sfo3")))
+}
+
+// validateDigitalOceanSpacesDomain returns a schema validation function that checks whether a string is the domain of
+// the endpoint of a DigitalOcean Spaces region, without a scheme, port or path.
+func validateDigitalOceanSpacesDomain() schema.SchemaValidateDiagFunc {
+	return validation.ToDiagFunc(validation.StringMatch(regexp.MustCompile(`^[a-z]{3}[0-9]`+regexp.QuoteMeta(digitalOceanSpacesDomainSuffix)+`$`), "expected the domain of a DigitalOcean Spaces endpoint, e.g. sfo3.digitaloceanspaces.com"))
+}
+
+// loggingFormatPlaceholder matches the placeholders in a logging format, e.g. %h, %>s or %{req.http.host}V, and
+// escaped percent signs.
+var loggingFormatPlaceholder = regexp.MustCompile(`%%|%[<>]?(?:\{(?:\\.|[^\\}])*\})?[<>]?[a-zA-Z]`)
+
+// validateJSONLoggingFormat returns an error if a logging format isn't valid JSON once its placeholders are replaced.
+// Placeholders are replaced with a number, so that they are valid both inside strings and as bare values.
+func validateJSONLoggingFormat(format string) error {
+	stripped := loggingFormatPlaceholder.ReplaceAllStringFunc(format, func(placeholder string) string {
+		if placeholder == "%%" {
+			return "%"
+		}
+		return "0"
+	})
+
+	var v any
+	if err := json.Unmarshal([]byte(stripped), &v); err != nil {
+		return err
+	}
+	return nil
+}
+
+// loggingFormatVariables are the VCL variables that can be logged with a %{...}V placeholder.
+var loggingFormatVariables = map[string]bool{
+	"bereq.body_bytes_written":       true,
+	"bereq.bytes_written":            true,
+	"bereq.header_bytes_written":     true,
+	"bereq.method":                   true,
+	"bereq.proto":                    true,
+	"bereq.url":                      true,
+	"beresp.backend.ip":              true,
+	"beresp.backend.name":            true,
+	"beresp.backend.port":            true,
+	"beresp.backend.requests":        true,
+	"beresp.cacheable":               true,
+	"beresp.grace":                   true,
+	"beresp.proto":                   true,
+	"beresp.response":                true,
+	"beresp.status":                  true,
+	"beresp.ttl":                     true,
+	"client.as.name":                 true,
+	"client.as.number":               true,
+	"client.bot.name":                true,
+	"client.browser.name":            true,
+	"client.browser.version":         true,
+	"client.identified":              true,
+	"client.identity":                true,
+	"client.ip":                      true,
+	"client.os.name":                 true,
+	"client.os.version":              true,
+	"client.port":                    true,
+	"client.requests":                true,
+	"client.sess_timeout":            true,
+	"now":                            true,
+	"now.sec":                        true,
+	"obj.age":                        true,
+	"obj.cacheable":                  true,
+	"obj.entered":                    true,
+	"obj.grace":                      true,
+	"obj.hits":                       true,
+	"obj.is_pci":                     true,
+	"obj.lastuse":                    true,
+	"obj.proto":                      true,
+	"obj.response":                   true,
+	"obj.stale_if_error":             true,
+	"obj.stale_while_revalidate":     true,
+	"obj.status":                     true,
+	"obj.ttl":                        true,
+	"req.backend":                    true,
+	"req.backend.healthy":            true,
+	"req.backend.is_origin":          true,
+	"req.backend.is_shield":          true,
+	"req.backend.name":               true,
+	"req.body":                       true,
+	"req.body.base64":                true,
+	"req.body_bytes_read":            true,
+	"req.bytes_read":                 true,
+	"req.customer_id":                true,
+	"req.digest":                     true,
+	"req.digest.ratio":               true,
+	"req.esi":                        true,
+	"req.esi_level":                  true,
+	"req.grace":                      true,
+	"req.hash":                       true,
+	"req.hash_always_miss":           true,
+	"req.hash_ignore_busy":           true,
+	"req.header_bytes_read":          true,
+	"req.is_clustering":              true,
+	"req.is_esi_subreq":              true,
+	"req.is_ipv6":                    true,
+	"req.is_purge":                   true,
+	"req.is_ssl":                     true,
+	"req.max_stale_if_error":         true,
+	"req.max_stale_while_revalidate": true,
+	"req.method":                     true,
+	"req.postbody":                   true,
+	"req.proto":                      true,
+	"req.protocol":                   true,
+	"req.request":                    true,
+	"req.restarts":                   true,
+	"req.service_id":                 true,
+	"req.topurl":                     true,
+	"req.url":                        true,
+	"req.url.basename":               true,
+	"req.url.dirname":                true,
+	"req.url.ext":                    true,
+	"req.url.path":                   true,
+	"req.url.qs":                     true,
+	"req.vcl":                        true,
+	"req.vcl.generation":             true,
+	"req.vcl.md5":                    true,
+	"req.vcl.version":                true,
+	"req.xid":                        true,
+	"resp.body_bytes_written":        true,
+	"resp.bytes_written":             true,
+	"resp.completed":                 true,
+	"resp.header_bytes_written":      true,
+	"resp.is_locally_generated":      true,
+	"resp.proto":                     true,
+	"resp.response":                  true,
+	"resp.stale":                     true,
+	"resp.stale.is_error":            true,
+	"resp.stale.is_revalidating":     true,
+	"resp.status":                    true,
+	"server.billing_region":          true,
+	"server.datacenter":              true,
+	"server.hostname":                true,
+	"server.identity":                true,
+	"server.ip":                      true,
+	"server.pop":                     true,
+	"server.port":                    true,
+	"server.region":                  true,
+	"stale.exists":                   true,
+	"time.elapsed":                   true,
+	"time.elapsed.msec":              true,
+	"time.elapsed.msec_frac":         true,
+	"time.elapsed.sec":               true,
+	"time.elapsed.usec":              true,
+	"time.elapsed.usec_frac":         true,
+	"time.end":                       true,
+	"time.end.msec":                  true,
+	"time.end.msec_frac":             true,
+	"time.end.sec":                   true,
+	"time.end.usec":                  true,
+	"time.end.usec_frac":             true,
+	"time.start":                     true,
+	"time.start.msec":                true,
+	"time.start.msec_frac":           true,
+	"time.start.sec":                 true,
+	"time.start.usec":                true,
+	"time.start.usec_frac":           true,
+	"time.to_first_byte":             true,
+}
+
+// loggingFormatVariablePrefixes are the prefixes of VCL variables that can be logged with a %{...}V placeholder, but
+// whose names are open ended, e.g. headers, or too many to list.
+var loggingFormatVariablePrefixes = []string{
+	"backend.socket.",
+	"bereq.http.",
+	"beresp.http.",
+	"client.class.",
+	"client.display.",
+	"client.geo.",
+	"client.platform.",
+	"client.socket.",
+	"esi.",
+	"fastly.",
+	"fastly_info.",
+	"geo.",
+	"h2.",
+	"h3.",
+	"math.",
+	"obj.http.",
+	"quic.",
+	"req.http.",
+	"resp.http.",
+	"segmented_caching.",
+	"tls.",
+	"var.",
+	"waf.",
+	"workspace.",
+}
+
+// loggingFormatVariablePlaceholder matches the %{...}V placeholders in a logging format.
+var loggingFormatVariablePlaceholder = regexp.MustCompile(`%\{((?:\\.|[^\\}])*)\}V`)
+
+// vclStringLiteral matches VCL string literals, both "short" and {"long"} strings.
+var vclStringLiteral = regexp.MustCompile(`\{"[\s\S]*?"\}|"(?:\\.|[^"\\])*"`)
+
+// vclNumberLiteral matches VCL INTEGER, FLOAT and RTIME literals, e.g. 10, 0.5 and 1s, so that their units aren't
+// taken for variables.
+var vclNumberLiteral = regexp.MustCompile(`\b[0-9][0-9A-Za-z_.]*`)
+
+// vclIdentifier matches a VCL variable or function name, followed by a ( if it is a function.
+var vclIdentifier = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_.:-]*\s*\(?`)
+
+// validateLoggingFormatVariables returns an error listing the variables in the %{...}V placeholders of a logging format
+// that aren't known VCL variables.
+func validateLoggingFormatVariables(format string) error {
+	var unknown []string
+	for _, m := range loggingFormatVariablePlaceholder.FindAllStringSubmatch(format, -1) {
+		expr := strings.NewReplacer(`\{`, "{", `\}`, "}").Replace(m[1])
+		expr = vclStringLiteral.ReplaceAllString(expr, " ")
+		expr = vclNumberLiteral.ReplaceAllString(expr, " ")
+		for _, ident := range vclIdentifier.FindAllString(expr, -1) {
+			ident = strings.TrimSpace(ident)
+			if strings.HasSuffix(ident, "(") || ident == "true" || ident == "false" {
+				continue
+			}
+			if !isLoggingFormatVariable(ident) {
+				unknown = append(unknown, ident)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown variables %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// isLoggingFormatVariable returns whether a name is a known VCL variable. Variable names aren't case sensitive.
+func isLoggingFormatVariable(name string) bool {
+	name = strings.ToLower(name)
+	if loggingFormatVariables[name] {
+		return true
+	}
+	for _, prefix := range loggingFormatVariablePrefixes {
+		if strings.HasPrefix(name, prefix) && len(name) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func validateStringTrimmed(i any, path cty.Path) diag.Diagnostics {
 	v := i.(string)
 	attr := path[len(path)-1].(cty.GetAttrStep)
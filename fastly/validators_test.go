@@ -1,10 +1,17 @@
 package fastly
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	gofastly "github.com/fastly/go-fastly/v6/fastly"
 )
@@ -16,7 +23,7 @@ func TestValidateLoggingFormatVersion(t *testing.T) {
 		expectedErrors int
 	}{
 		"0": {0, 0, 1},
-		"1": {1, 0, 0},
+		"1": {1, 1, 0},
 		"2": {2, 0, 0},
 		"3": {3, 0, 1},
 		"4": {4, 0, 1},
@@ -61,6 +68,28 @@ func TestValidateLoggingMessageType(t *testing.T) {
 	}
 }
 
+func TestValidateLoggingRequestMax(t *testing.T) {
+	for _, testcase := range []struct {
+		value          int
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{0, 0, 0},
+		{1000, 0, 0},
+		{-1, 0, 1},
+	} {
+		t.Run(fmt.Sprint(testcase.value), func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateLoggingRequestMax()(testcase.value, cty.GetAttrPath("request_max_bytes")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
 func TestValidateLoggingPlacement(t *testing.T) {
 	for _, testcase := range []struct {
 		value          string
@@ -109,6 +138,102 @@ func TestValidateLoggingServerSideEncryption(t *testing.T) {
 	}
 }
 
+func TestValidateLoggingRegion(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"US", 0, 0},
+		{"EU", 0, 0},
+		{"eu", 0, 1},
+		{"AU", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateLoggingRegion()(testcase.value, cty.GetAttrPath("region")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
+func TestValidateServiceDestroyBehavior(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"delete", 0, 0},
+		{"deactivate", 0, 0},
+		{"Deactivate", 0, 1},
+		{"reuse", 0, 1},
+		{"", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateServiceDestroyBehavior()(testcase.value, cty.GetAttrPath("destroy_behavior")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
+func TestValidateBackendTimeout(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		value         int
+		expectedError string
+	}{
+		"1":      {value: 1},
+		"15000":  {value: 15000},
+		"600000": {value: 600000},
+		"0":      {value: 0, expectedError: "expected first_byte_timeout to be at least 1 millisecond, got 0"},
+		"600001": {value: 600001, expectedError: "expected first_byte_timeout to be at most 600000 milliseconds (10m0s), the longest Fastly allows, got 600001. Longer timeouts are rejected when the version is activated"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, actualErrors := diagToWarnsAndErrs(validateBackendTimeout(backendMaxFirstByteTimeout)(testcase.value, cty.GetAttrPath("first_byte_timeout")))
+			if testcase.expectedError == "" && len(actualErrors) != 0 {
+				t.Errorf("expected no errors, got %v", actualErrors)
+			}
+			if testcase.expectedError != "" && (len(actualErrors) != 1 || actualErrors[0] != testcase.expectedError) {
+				t.Errorf("expected error %q, got %v", testcase.expectedError, actualErrors)
+			}
+		})
+	}
+}
+
+func TestValidateHealthCheckExpectedResponse(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		value          int
+		expectedWarns  int
+		expectedErrors int
+	}{
+		"100": {100, 0, 0},
+		"204": {204, 0, 0},
+		"301": {301, 0, 0},
+		"599": {599, 0, 0},
+		"0":   {0, 0, 1},
+		"99":  {99, 0, 1},
+		"600": {600, 0, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateHealthCheckExpectedResponse()(testcase.value, cty.GetAttrPath("expected_response")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
 func TestValidateDirectorQuorum(t *testing.T) {
 	for name, testcase := range map[string]struct {
 		value          int
@@ -136,16 +261,21 @@ func TestValidateDirectorQuorum(t *testing.T) {
 
 func TestValidateDirectorType(t *testing.T) {
 	for name, testcase := range map[string]struct {
-		value          int
+		value          string
 		expectedWarns  int
 		expectedErrors int
 	}{
-		"0": {0, 0, 1},
-		"1": {1, 0, 0},
-		"2": {2, 0, 1},
-		"3": {3, 0, 0},
-		"4": {4, 0, 0},
-		"5": {5, 0, 1},
+		"0":           {"0", 0, 1},
+		"1":           {"1", 0, 0},
+		"2":           {"2", 0, 1},
+		"3":           {"3", 0, 0},
+		"4":           {"4", 0, 0},
+		"5":           {"5", 0, 1},
+		"random":      {"random", 0, 0},
+		"hash":        {"hash", 0, 0},
+		"client":      {"client", 0, 0},
+		"round_robin": {"round_robin", 0, 1},
+		"HASH":        {"HASH", 0, 1},
 	} {
 		t.Run(name, func(t *testing.T) {
 			actualWarns, actualErrors := diagToWarnsAndErrs(validateDirectorType()(testcase.value, cty.GetAttrPath("type")))
@@ -267,6 +397,32 @@ func TestValidateRuleStatusType(t *testing.T) {
 	}
 }
 
+func TestValidateWAFRuleRevision(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"1", 0, 0},
+		{"12", 0, 0},
+		{"latest", 0, 0},
+		{"0", 0, 1},
+		{"-1", 0, 1},
+		{"Latest", 0, 1},
+		{"1.5", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateWAFRuleRevision()(testcase.value, cty.GetAttrPath("revision")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
 func TestValidateSnippetType(t *testing.T) {
 	for _, testcase := range []struct {
 		value          string
@@ -367,6 +523,109 @@ func TestValidateUserRole(t *testing.T) {
 	}
 }
 
+func TestValidateDigitalOceanSpacesDomain(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"nyc3.digitaloceanspaces.com", 0, 0},
+		{"sfo3.digitaloceanspaces.com", 0, 0},
+		{"https://sfo3.digitaloceanspaces.com", 0, 1},
+		{"sfo3.digitaloceanspaces.com/bucket", 0, 1},
+		{"bucket.sfo3.digitaloceanspaces.com", 0, 1},
+		{"sfo3.digitaloceanspaces.co", 0, 1},
+		{"sfo3", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateDigitalOceanSpacesDomain()(testcase.value, cty.GetAttrPath("domain")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
+func TestValidateDigitalOceanSpacesRegion(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"nyc3", 0, 0},
+		{"sgp1", 0, 0},
+		{"SFO3", 0, 1},
+		{"sfo", 0, 1},
+		{"sfo3.digitaloceanspaces.com", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateDigitalOceanSpacesRegion()(testcase.value, cty.GetAttrPath("region")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
+func TestValidateGzipContentType(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"text/html", 0, 0},
+		{"application/vnd.api+json", 0, 0},
+		{"image/svg+xml", 0, 0},
+		{"text/html; charset=utf-8", 0, 1},
+		{"text/html text/css", 0, 1},
+		{"text", 0, 1},
+		{"text/", 0, 1},
+		{"*/*", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateGzipContentType()(testcase.value, cty.GetAttrPath("content_types")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
+func TestValidateGzipExtension(t *testing.T) {
+	for _, testcase := range []struct {
+		value          string
+		expectedWarns  int
+		expectedErrors int
+	}{
+		{"css", 0, 0},
+		{".js", 0, 0},
+		{"woff2", 0, 0},
+		{"css js", 0, 1},
+		{"tar.gz", 0, 1},
+		{".", 0, 1},
+		{"*.css", 0, 1},
+	} {
+		t.Run(testcase.value, func(t *testing.T) {
+			actualWarns, actualErrors := diagToWarnsAndErrs(validateGzipExtension()(testcase.value, cty.GetAttrPath("extensions")))
+			if len(actualWarns) != testcase.expectedWarns {
+				t.Errorf("expected %d warnings, actual %d ", testcase.expectedWarns, len(actualWarns))
+			}
+			if len(actualErrors) != testcase.expectedErrors {
+				t.Errorf("expected %d errors, actual %d ", testcase.expectedErrors, len(actualErrors))
+			}
+		})
+	}
+}
+
 func TestValidatePEMCertificate(t *testing.T) {
 	key, cert, ca, err := generateKeyAndCertWithCA()
 	if err != nil {
@@ -429,3 +688,169 @@ func TestValidateMultiplePEMBlocks(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePEMCertificates(t *testing.T) {
+	key, cert, ca, err := generateKeyAndCertWithCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, testCase := range map[string]struct {
+		value          string
+		expectedErrors int
+	}{
+		"empty string":          {"", 0},
+		"single cert":           {cert, 0},
+		"chain":                 {fmt.Sprintf("%s\n%s", cert, ca), 0},
+		"trailing newline":      {cert + "\n", 0},
+		"private key":           {key, 1},
+		"cert and private key":  {fmt.Sprintf("%s\n%s", cert, key), 1},
+		"text before cert":      {"ca.pem\n" + cert, 1},
+		"text after cert":       {cert + "\nca.pem", 1},
+		"invalid cert contents": {"-----BEGIN CERTIFICATE-----\ncafebabe\n-----END CERTIFICATE-----\n", 1},
+		"gibberish":             {"jkljansdfj\nasldfjhadskjfh", 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actualWarnings, actualErrors := diagToWarnsAndErrs(validatePEMCertificates()(testCase.value, cty.GetAttrPath("tls_ca_cert")))
+			if len(actualWarnings) != 0 {
+				t.Errorf("expected no warnings, got %d", len(actualWarnings))
+			}
+			if len(actualErrors) != testCase.expectedErrors {
+				t.Errorf("expected %d errors, got %v", testCase.expectedErrors, actualErrors)
+			}
+		})
+	}
+}
+
+func TestValidatePEMPrivateKey(t *testing.T) {
+	key, cert, _, err := generateKeyAndCertWithCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyDER, err := x509.MarshalECPrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKeyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecKeyDER}))
+
+	for name, testCase := range map[string]struct {
+		value         string
+		expectedError string
+	}{
+		"empty string":     {value: ""},
+		"PKCS #8 key":      {value: key},
+		"SEC 1 key":        {value: ecKeyPEM},
+		"trailing newline": {value: key + "\n"},
+		"certificate":      {value: cert, expectedError: "expected tls_client_key to be a PEM-format private key, it is of type 'CERTIFICATE'"},
+		"two keys":         {value: key + ecKeyPEM, expectedError: "expected tls_client_key to be a single PEM-format private key"},
+		"wrong type":       {value: strings.Replace(ecKeyPEM, "EC PRIVATE KEY", "RSA PRIVATE KEY", 2), expectedError: "expected tls_client_key to be a valid private key"},
+		"gibberish":        {value: "secret", expectedError: "expected tls_client_key to be a single PEM-format private key"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, actualErrors := diagToWarnsAndErrs(validatePEMPrivateKey()(testCase.value, cty.GetAttrPath("tls_client_key")))
+			if testCase.expectedError == "" {
+				if len(actualErrors) != 0 {
+					t.Errorf("expected no errors, got %v", actualErrors)
+				}
+				return
+			}
+			if len(actualErrors) != 1 || !strings.HasPrefix(actualErrors[0], testCase.expectedError) {
+				t.Errorf("expected error %q, got %v", testCase.expectedError, actualErrors)
+			}
+			if strings.Contains(strings.Join(actualErrors, ""), "BEGIN") {
+				t.Errorf("expected the errors not to contain the key, got %v", actualErrors)
+			}
+		})
+	}
+}
+
+func TestValidateJSONLoggingFormat(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		value       string
+		expectError bool
+	}{
+		"object with placeholders": {
+			value: `{"timestamp": "%{strftime(\{"%Y-%m-%dT%H:%M:%S%z"\}, time.start)}V", "host": "%{req.http.host}V", "status": %>s, "bytes": %B}`,
+		},
+		"escaped percent":   {value: `{"ratio": "100%%"}`},
+		"apache format":     {value: `%h %l %u %t "%r" %>s %b`, expectError: true},
+		"missing brace":     {value: `{"status": %>s`, expectError: true},
+		"unquoted variable": {value: `{"url": %{req.url}V}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateJSONLoggingFormat(testcase.value)
+			if testcase.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !testcase.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestValidateLoggingFormatVariables(t *testing.T) {
+	for name, testcase := range map[string]struct {
+		value       string
+		expectError bool
+	}{
+		"variables": {
+			value: `{"host": "%{req.http.host}V", "status": %{resp.status}V, "start": "%{strftime(\{"%Y-%m-%dT%H:%M:%S%z"\}, time.start)}V"}`,
+		},
+		"functions and strings": {
+			value: `%{if(fastly_info.state~"^(HIT|MISS)$", "true", "false")}V %{json.escape(req.http.User-Agent)}V`,
+		},
+		"apache directives": {value: `%h %l %u %t "%r" %>s %b %{User-Agent}i`},
+		"case insensitive":  {value: `%{REQ.URL}V`},
+		"literals":          {value: `%{if(time.elapsed > 1s, "slow", "fast")}V %{std.itoa(10)}V %{math.round(0.5)}V`},
+		"protocols":         {value: `%{req.protocol}V %{h2.stream_id}V %{h3.alt_svc}V %{quic.rtt.smoothed}V %{stale.exists}V %{backend.socket.tcpi_rtt}V`},
+		"typo":              {value: `%{req.hdr}V`, expectError: true},
+		"typo in function":  {value: `%{json.escape(req.ulr)}V`, expectError: true},
+		"empty prefix":      {value: `%{req.http.}V`, expectError: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validateLoggingFormatVariables(testcase.value)
+			if testcase.expectError && err == nil {
+				t.Error("expected an error")
+			}
+			if !testcase.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+// TestBlockAttributesAcceptEmpty checks that the configuration Terraform generates when a service is imported is
+// valid. The optional attributes of a block that have no default are stored as empty strings when the block doesn't
+// set them, so the generated configuration sets them to "".
+func TestBlockAttributesAcceptEmpty(t *testing.T) {
+	var check func(path string, attributes map[string]*schema.Schema)
+	check = func(path string, attributes map[string]*schema.Schema) {
+		for name, s := range attributes {
+			if r, ok := s.Elem.(*schema.Resource); ok {
+				check(path+"."+name, r.Schema)
+				continue
+			}
+			if s.Type != schema.TypeString || !s.Optional || s.Computed || s.Default != nil || s.ValidateDiagFunc == nil {
+				continue
+			}
+			if diags := s.ValidateDiagFunc("", cty.GetAttrPath(name)); diags.HasError() {
+				t.Errorf("%s.%s doesn't accept an empty string: %s", path, name, diags[0].Summary)
+			}
+		}
+	}
+	for name, r := range map[string]*schema.Resource{
+		"fastly_service_vcl":     resourceServiceVCL(),
+		"fastly_service_compute": resourceServiceCompute(),
+	} {
+		for key, s := range r.Schema {
+			if block, ok := s.Elem.(*schema.Resource); ok {
+				check(name+"."+key, block.Schema)
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package fastly
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// vclReturnStates are the states that each of the Fastly VCL subroutines can return.
+var vclReturnStates = map[string][]string{
+	"vcl_recv":    {"error", "lookup", "pass", "restart", "upgrade"},
+	"vcl_hash":    {"hash"},
+	"vcl_hit":     {"deliver", "deliver_stale", "error", "pass", "restart"},
+	"vcl_miss":    {"deliver_stale", "error", "fetch", "pass"},
+	"vcl_pass":    {"error", "pass", "restart"},
+	"vcl_fetch":   {"deliver", "deliver_stale", "error", "pass", "restart"},
+	"vcl_error":   {"deliver", "deliver_stale", "restart"},
+	"vcl_deliver": {"deliver", "restart"},
+	"vcl_log":     {"deliver"},
+}
+
+var (
+	// vclSubroutine matches the declaration of a subroutine, up to its opening brace.
+	vclSubroutine = regexp.MustCompile(`\bsub\s+([A-Za-z_][A-Za-z0-9_]*)\s*\{`)
+	// vclReturn matches a return statement with a state, e.g. return(pass).
+	vclReturn = regexp.MustCompile(`\breturn\s*\(\s*([A-Za-z_]+)\s*\)`)
+)
+
+// lintVCL returns the problems found in VCL without compiling it: unbalanced brackets, declarations of vcl_
+// subroutines that Fastly doesn't have, and return states that aren't valid in the subroutine they're returned from.
+// If subroutine isn't empty, the VCL is the body of that subroutine, as the content of a snippet is.
+//
+// The checks are deliberately conservative, so that VCL that compiles is never reported. Anything else is left to
+// the API to report when the service version is validated.
+func lintVCL(content, subroutine string) []string {
+	code := stripVCLLiterals(content)
+	line := func(offset int) int {
+		return strings.Count(code[:offset], "\n") + 1
+	}
+
+	var problems []string
+
+	// closing maps the offset of each opening brace to the offset of the brace that closes it.
+	closing := map[int]int{}
+	var open []int
+	for i, c := range code {
+		switch c {
+		case '{', '(':
+			open = append(open, i)
+		case '}', ')':
+			expected := byte('{')
+			if c == ')' {
+				expected = '('
+			}
+			if len(open) == 0 || code[open[len(open)-1]] != expected {
+				problems = append(problems, fmt.Sprintf("line %d: unexpected %q", line(i), c))
+				return problems
+			}
+			closing[open[len(open)-1]] = i
+			open = open[:len(open)-1]
+		}
+	}
+	if len(open) > 0 {
+		i := open[len(open)-1]
+		problems = append(problems, fmt.Sprintf("line %d: %q is never closed", line(i), code[i]))
+		return problems
+	}
+
+	// bodies are the subroutines declared in the VCL, and the offsets of their bodies.
+	type body struct {
+		name       string
+		start, end int
+	}
+	var bodies []body
+	for _, m := range vclSubroutine.FindAllStringSubmatchIndex(code, -1) {
+		name := code[m[2]:m[3]]
+		if _, ok := vclReturnStates[name]; !ok && strings.HasPrefix(name, "vcl_") {
+			problems = append(problems, fmt.Sprintf("line %d: unknown subroutine %s", line(m[0]), name))
+		}
+		bodies = append(bodies, body{name: name, start: m[1] - 1, end: closing[m[1]-1]})
+	}
+
+	for _, m := range vclReturn.FindAllStringSubmatchIndex(code, -1) {
+		name := subroutine
+		for _, b := range bodies {
+			if b.start < m[0] && m[0] < b.end {
+				name = b.name
+			}
+		}
+		states, ok := vclReturnStates[name]
+		if !ok {
+			// Custom subroutines return on behalf of the subroutine that called them.
+			continue
+		}
+		state := strings.ToLower(code[m[2]:m[3]])
+		if i := sort.SearchStrings(states, state); i == len(states) || states[i] != state {
+			problems = append(problems, fmt.Sprintf("line %d: return(%s) isn't valid in %s, expected one of %s", line(m[0]), state, name, strings.Join(states, ", ")))
+		}
+	}
+
+	return problems
+}
+
+// stripVCLLiterals returns VCL with its comments and the contents of its strings replaced with spaces, keeping line
+// breaks so that offsets still map to the same lines.
+func stripVCLLiterals(content string) string {
+	b := []byte(content)
+	blank := func(from, to int) {
+		for i := from; i < to && i < len(b); i++ {
+			if b[i] != '\n' {
+				b[i] = ' '
+			}
+		}
+	}
+
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '#' || (b[i] == '/' && i+1 < len(b) && b[i+1] == '/'):
+			end := strings.IndexByte(content[i:], '\n')
+			if end < 0 {
+				end = len(b) - i
+			}
+			blank(i, i+end)
+			i += end
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := strings.Index(content[i+2:], "*/")
+			if end < 0 {
+				blank(i, len(b))
+				return string(b)
+			}
+			blank(i, i+end+4)
+			i += end + 3
+		case b[i] == '"':
+			end := strings.IndexAny(content[i+1:], "\"\n")
+			if end < 0 {
+				end = len(b) - i - 1
+			}
+			blank(i+1, i+1+end)
+			i += end + 1
+		case b[i] == '{':
+			// Long strings are delimited by {" and "}, optionally with a delimiter, e.g. {xyz"...."xyz}.
+			delimiter := longStringDelimiter.FindString(content[i+1:])
+			if delimiter == "" {
+				continue
+			}
+			terminator := `"` + delimiter[:len(delimiter)-1] + `}`
+			end := strings.Index(content[i+1+len(delimiter):], terminator)
+			if end < 0 {
+				blank(i, len(b))
+				return string(b)
+			}
+			blank(i, i+1+len(delimiter)+end+len(terminator))
+			i += len(delimiter) + end + len(terminator)
+		}
+	}
+	return string(b)
+}
+
+// longStringDelimiter matches the start of a long string after its opening brace.
+var longStringDelimiter = regexp.MustCompile(`^[A-Za-z0-9_]*"`)
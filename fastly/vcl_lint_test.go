@@ -0,0 +1,91 @@
+package fastly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLintVCL(t *testing.T) {
+	for name, states := range vclReturnStates {
+		if !sort.StringsAreSorted(states) {
+			t.Fatalf("expected the return states of %s to be sorted", name)
+		}
+	}
+
+	for name, testcase := range map[string]struct {
+		content    string
+		subroutine string
+		expected   []string
+	}{
+		"valid": {
+			content: `
+sub vcl_recv {
+#FASTLY recv
+  if (req.url ~ "^/admin" && req.http.X-Token != {"se}cr"et"}) {
+    error 403 "Forbidden {";
+  }
+  call normalize;
+  return(lookup);
+}
+
+# A custom subroutine returns on behalf of its caller.
+sub normalize {
+  /* return(fetch) isn't checked } */
+  return(pass);
+}
+`,
+		},
+		"unclosed brace": {
+			content:  "sub vcl_recv {\n  if (req.http.host) {\n    return(pass);\n}\n",
+			expected: []string{`line 1: '{' is never closed`},
+		},
+		"unexpected brace": {
+			content:  "sub vcl_recv {\n  return(pass);\n}\n}\n",
+			expected: []string{`line 4: unexpected '}'`},
+		},
+		"mismatched brackets": {
+			content:  "sub vcl_recv {\n  if (req.http.host {\n  }\n}\n",
+			expected: []string{`line 4: unexpected '}'`},
+		},
+		"unknown subroutine": {
+			content:  "sub vcl_recieve {\n}\n",
+			expected: []string{"line 1: unknown subroutine vcl_recieve"},
+		},
+		"invalid return state": {
+			content:  "sub vcl_deliver {\n  return(lookup);\n}\n",
+			expected: []string{"line 2: return(lookup) isn't valid in vcl_deliver, expected one of deliver, restart"},
+		},
+		"snippet": {
+			content:    "if (req.http.host) {\n  return(fetch);\n}\n",
+			subroutine: "vcl_recv",
+			expected:   []string{"line 2: return(fetch) isn't valid in vcl_recv, expected one of error, lookup, pass, restart, upgrade"},
+		},
+		"init snippet": {
+			content: "table redirects {\n  \"/old\": \"/new\",\n}\n",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := lintVCL(testcase.content, testcase.subroutine); fmt.Sprint(got) != fmt.Sprint(testcase.expected) {
+				t.Errorf("expected %q, got %q", testcase.expected, got)
+			}
+		})
+	}
+}
+
+func TestSnippetLinting(t *testing.T) {
+	_, err := resourceServiceVCL().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]any{
+		"name":   "service",
+		"domain": []any{map[string]any{"name": "example.com"}},
+		"snippet": []any{
+			map[string]any{"name": "deliver", "type": "deliver", "content": "return(pass);"},
+		},
+	}), nil)
+	if err == nil || !strings.Contains(err.Error(), `invalid snippet: "deliver" line 1: return(pass) isn't valid in vcl_deliver`) {
+		t.Errorf("expected the snippet to be linted, got %v", err)
+	}
+}